@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net"
 	"sync"
 	"time"
 
@@ -89,21 +90,18 @@ func (a *APIv1) Engine() *gin.Engine {
 //	@license.url	http://www.apache.org/licenses/LICENSE-2.0.html
 func (a *APIv1) Start() error {
 	address := fmt.Sprintf("%s:%d", a.Host, a.Port)
-	// Use buffered channel to not block goroutine
-	errChan := make(chan error, 1)
+	// Create the listener up front so that bind failures are returned to the caller
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
 
 	go func() {
-		// Capture the error returned by Run
-		errChan <- a.engine.Run(address)
+		if err := a.engine.RunListener(listener); err != nil {
+			log.Printf("API server failed: %s", err)
+		}
 	}()
 
-	select {
-	case err := <-errChan:
-		return err
-	default:
-		// No starting errors, start server
-	}
-
 	return nil
 }
 
@@ -151,6 +149,8 @@ func ConfigureRouter(debug bool) *gin.Engine {
 	g.Use(gin.LoggerWithFormatter(accessLogger))
 	// Healthcheck endpoint
 	g.GET("/healthcheck", handleHealthcheck)
+	// Prometheus metrics endpoint
+	g.GET("/metrics", handleMetrics())
 	// No-op API endpoint for testing
 	g.GET("/ping", func(c *gin.Context) {
 		c.String(200, "pong")
@@ -6,7 +6,10 @@ import (
 	"testing"
 
 	"github.com/blinklabs-io/adder/api"
+	"github.com/blinklabs-io/adder/input/chainsync"
 	"github.com/blinklabs-io/adder/output/push"
+	"github.com/blinklabs-io/adder/output/webhook"
+	"github.com/blinklabs-io/adder/pipeline"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -40,3 +43,58 @@ func TestRouteRegistration(t *testing.T) {
 	// TODO check for JSON response
 	// assert.Equal(t, `{"fcmToken":"someToken"}`, rr.Body.String())
 }
+
+func TestMetricsRegistrar(t *testing.T) {
+	apiInstance := api.New(true)
+
+	chainsyncPlugin := chainsync.New()
+	webhookPlugin := webhook.New()
+	for _, p := range []interface{}{chainsyncPlugin, webhookPlugin} {
+		registrar, ok := p.(api.MetricsRegistrar)
+		if !ok {
+			t.Fatalf("%T does NOT implement MetricsRegistrar", p)
+		}
+		if err := registrar.RegisterMetrics(api.MetricsRegistry()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	apiInstance.Engine().ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assert.Contains(t, body, "adder_chainsync_blocks_total 0")
+	assert.Contains(t, body, "adder_chainsync_sync_lag_slots 0")
+	assert.Contains(t, body, "adder_webhook_deliveries_total 0")
+	assert.Contains(t, body, "adder_webhook_failures_total 0")
+}
+
+type mockStatsProvider struct{}
+
+func (mockStatsProvider) Stats() pipeline.Stats {
+	return pipeline.Stats{Input: pipeline.StageStats{Events: 5}}
+}
+
+func TestStatsRoute(t *testing.T) {
+	apiInstance := api.New(true)
+	apiInstance.AddStatsRoute(mockStatsProvider{})
+
+	req, err := http.NewRequest(http.MethodGet, "/stats", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	apiInstance.Engine().ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(
+		t,
+		`{"input":{"events":5},"filter":{"events":0},"output":{"events":0}}`,
+		rr.Body.String(),
+	)
+}
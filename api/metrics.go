@@ -0,0 +1,49 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsRegistrar is implemented by plugins that provide Prometheus metrics
+type MetricsRegistrar interface {
+	RegisterMetrics(prometheus.Registerer) error
+}
+
+var metricsRegistry = newMetricsRegistry()
+
+func newMetricsRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return registry
+}
+
+// MetricsRegistry returns the shared registry exposed by the metrics endpoint
+func MetricsRegistry() *prometheus.Registry {
+	return metricsRegistry
+}
+
+func handleMetrics() gin.HandlerFunc {
+	return gin.WrapH(
+		promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{}),
+	)
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blinklabs-io/adder/pipeline"
+)
+
+// StatsProvider is implemented by types that report pipeline throughput, such as pipeline.Pipeline
+type StatsProvider interface {
+	Stats() pipeline.Stats
+}
+
+// AddStatsRoute registers the stats endpoint, which reports the stats from the specified provider
+func (a *APIv1) AddStatsRoute(provider StatsProvider) {
+	a.AddRoute("GET", "/stats", handleStats(provider))
+}
+
+// @Summary		Pipeline stats
+// @Description	Report the number of events seen by each pipeline stage and when the last one was seen
+// @Produce		json
+// @Success		200	{object}	pipeline.Stats	"Stats"
+// @Router			/stats [get]
+func handleStats(provider StatsProvider) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, provider.Stats())
+	}
+}
@@ -18,10 +18,14 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	_ "go.uber.org/automaxprocs"
 
 	"github.com/blinklabs-io/adder/api"
+	"github.com/blinklabs-io/adder/event"
 	_ "github.com/blinklabs-io/adder/filter"
 	_ "github.com/blinklabs-io/adder/input"
 	"github.com/blinklabs-io/adder/internal/config"
@@ -49,6 +53,14 @@ func main() {
 		os.Exit(0)
 	}
 
+	if cfg.Schema {
+		if err := printSchemas(os.Stdout); err != nil {
+			fmt.Printf("Failed to generate schemas: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if cfg.Input == "list" {
 		fmt.Printf("Available input plugins:\n\n")
 		for _, plugin := range plugin.GetPlugins(plugin.PluginTypeInput) {
@@ -117,6 +129,9 @@ func main() {
 		}()
 	}
 
+	// Prefix the type of all events with the configured source identifier
+	event.SetTypePrefix(cfg.EventTypePrefix)
+
 	// Create API instance with debug disabled
 	apiInstance := api.New(false,
 		api.WithGroup("/v1"),
@@ -124,43 +139,183 @@ func main() {
 		api.WithPort(cfg.Api.ListenPort))
 
 	// Create pipeline
-	pipe := pipeline.New()
+	pipe := pipeline.New(
+		pipeline.WithDropOnFull(cfg.OutputDropOnFull),
+		pipeline.WithMaxEventAge(cfg.OutputMaxEventAge),
+	)
+	pipe.SetOutputBufferSize(cfg.OutputBufferSize)
+	apiInstance.AddStatsRoute(pipe)
+	if err := pipe.RegisterMetrics(api.MetricsRegistry()); err != nil {
+		logger.Fatalf("failed to register pipeline metrics: %s", err)
+	}
 
 	// Configure input
 	input := plugin.GetPlugin(plugin.PluginTypeInput, cfg.Input)
 	if input == nil {
 		logger.Fatalf("unknown input: %s", cfg.Input)
 	}
+	// Check if input plugin implements APIRouteRegistrar
+	if registrar, ok := interface{}(input).(api.APIRouteRegistrar); ok {
+		registrar.RegisterRoutes()
+	}
+	// Check if input plugin implements MetricsRegistrar
+	if registrar, ok := interface{}(input).(api.MetricsRegistrar); ok {
+		if err := registrar.RegisterMetrics(api.MetricsRegistry()); err != nil {
+			logger.Fatalf("failed to register input metrics: %s", err)
+		}
+	}
 	pipe.AddInput(input)
 
 	// Configure filters
-	for _, filterEntry := range plugin.GetPlugins(plugin.PluginTypeFilter) {
+	for _, filterEntry := range filterEntries() {
 		filter := plugin.GetPlugin(plugin.PluginTypeFilter, filterEntry.Name)
+		// Check if filter plugin implements APIRouteRegistrar
+		if registrar, ok := interface{}(filter).(api.APIRouteRegistrar); ok {
+			registrar.RegisterRoutes()
+		}
 		pipe.AddFilter(filter)
 	}
 
-	// Configure output
-	output := plugin.GetPlugin(plugin.PluginTypeOutput, cfg.Output)
-	if output == nil {
-		logger.Fatalf("unknown output: %s", cfg.Output)
+	// Configure outputs
+	outputs := map[string]plugin.Plugin{}
+	for _, outputName := range cfg.OutputNames() {
+		if _, ok := outputs[outputName]; ok {
+			logger.Fatalf("duplicate output: %s", outputName)
+		}
+		output := plugin.GetPlugin(plugin.PluginTypeOutput, outputName)
+		if output == nil {
+			logger.Fatalf("unknown output: %s", outputName)
+		}
+		// Check if output plugin implements APIRouteRegistrar
+		if registrar, ok := interface{}(output).(api.APIRouteRegistrar); ok {
+			registrar.RegisterRoutes()
+		}
+		// Check if output plugin implements MetricsRegistrar
+		if registrar, ok := interface{}(output).(api.MetricsRegistrar); ok {
+			if err := registrar.RegisterMetrics(api.MetricsRegistry()); err != nil {
+				logger.Fatalf("failed to register output metrics: %s", err)
+			}
+		}
+		outputs[outputName] = output
+		pipe.AddNamedOutput(outputName, output)
 	}
-	// Check if output plugin implements APIRouteRegistrar
-	if registrar, ok := interface{}(output).(api.APIRouteRegistrar); ok {
-		registrar.RegisterRoutes()
+
+	// Configure dead letter output
+	if cfg.DeadLetterOutput != "" {
+		if _, ok := outputs[cfg.DeadLetterOutput]; ok {
+			logger.Fatalf("dead letter output must be different from the main outputs")
+		}
+		deadLetter := plugin.GetPlugin(plugin.PluginTypeOutput, cfg.DeadLetterOutput)
+		if deadLetter == nil {
+			logger.Fatalf("unknown dead letter output: %s", cfg.DeadLetterOutput)
+		}
+		for _, outputName := range cfg.OutputNames() {
+			if _, ok := outputs[outputName].(pipeline.FailedEventsProvider); !ok {
+				logger.Warnf("output %s doesn't report failed events", outputName)
+			}
+		}
+		pipe.SetDeadLetter(deadLetter)
 	}
-	pipe.AddOutput(output)
 
-	// Start API after plugins are configured
-	if err := apiInstance.Start(); err != nil {
-		logger.Fatalf("failed to start API: %s", err)
+	// Start API and pipeline and wait for error
+	if err := start(cfg, logger, apiInstance, pipe); err != nil {
+		logger.Fatal(err)
+	}
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case sig := <-sigChan:
+		logger.Infof("received %s, shutting down", sig)
+		stopWithForceExit(
+			logger,
+			sigChan,
+			func() {
+				if err := pipe.Stop(); err != nil {
+					logger.Errorf("failed to stop pipeline: %s", err)
+				}
+				logStopReport(logger, pipe.StopReport())
+			},
+			func() { os.Exit(1) },
+		)
+	case err, ok := <-pipe.ErrorChan():
+		// The pipeline stops itself on error, so this waits for that to finish
+		_ = pipe.Stop()
+		logStopReport(logger, pipe.StopReport())
+		if ok {
+			logger.Fatalf("pipeline failed: %s", err)
+		}
 	}
+}
 
-	// Start pipeline and wait for error
-	if err := pipe.Start(); err != nil {
-		logger.Fatalf("failed to start pipeline: %s", err)
+// filterEntries returns the filter plugins in the order they're added to the pipeline. The project filter replaces
+// pruned payloads with generic JSON, so it's added last to leave the original types for the other filters
+func filterEntries() []plugin.PluginEntry {
+	ret := []plugin.PluginEntry{}
+	var last []plugin.PluginEntry
+	for _, filterEntry := range plugin.GetPlugins(plugin.PluginTypeFilter) {
+		if filterEntry.Name == "project" {
+			last = append(last, filterEntry)
+			continue
+		}
+		ret = append(ret, filterEntry)
+	}
+	return append(ret, last...)
+}
+
+// stopWithForceExit runs the provided stop function, calling forceExit if another signal is received before it
+// finishes. This allows a slow shutdown, such as an output waiting on an unresponsive server, to be cut short
+func stopWithForceExit(
+	logger *logging.Logger,
+	sigChan <-chan os.Signal,
+	stop func(),
+	forceExit func(),
+) {
+	doneChan := make(chan struct{})
+	go func() {
+		select {
+		case sig := <-sigChan:
+			logger.Warnf("received %s during shutdown, exiting immediately", sig)
+			forceExit()
+		case <-doneChan:
+		}
+	}()
+	stop()
+	close(doneChan)
+}
+
+// logStopReport logs the summary of the pipeline run on shutdown
+func logStopReport(logger *logging.Logger, report pipeline.StopReport) {
+	logger.Infof(
+		"pipeline stopped: uptime=%s events=%d lastSlot=%d",
+		report.Uptime.Round(time.Second),
+		report.Stats.Output.Events,
+		report.LastSlot,
+	)
+	for _, failure := range report.FailedOutputs {
+		logger.Errorf(
+			"output %s failed: %s",
+			failure.Output,
+			failure.Error,
+		)
 	}
-	err, ok := <-pipe.ErrorChan()
-	if ok {
-		logger.Fatalf("pipeline failed: %s", err)
+}
+
+// start starts the API after plugins are configured, followed by the pipeline. A failure to start the API is
+// only logged if the API is configured to be non-fatal
+func start(
+	cfg *config.Config,
+	logger *logging.Logger,
+	apiInstance api.API,
+	pipe *pipeline.Pipeline,
+) error {
+	if err := apiInstance.Start(); err != nil {
+		if !cfg.Api.NonFatal {
+			return fmt.Errorf("failed to start API: %s", err)
+		}
+		logger.Errorf("failed to start API, continuing without it: %s", err)
+	}
+	if err := pipe.Start(); err != nil {
+		return fmt.Errorf("failed to start pipeline: %s", err)
 	}
+	return nil
 }
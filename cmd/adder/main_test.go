@@ -0,0 +1,122 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/api"
+	"github.com/blinklabs-io/adder/internal/config"
+	"github.com/blinklabs-io/adder/pipeline"
+	"github.com/blinklabs-io/adder/plugin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestStartApiPortInUse(t *testing.T) {
+	// Occupy a port so that the API fails to bind
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error creating listener: %s", err)
+	}
+	defer listener.Close()
+	apiInstance := api.New(
+		true,
+		api.WithHost("127.0.0.1"),
+		api.WithPort(uint(listener.Addr().(*net.TCPAddr).Port)),
+	)
+	logger := zap.NewNop().Sugar()
+
+	testDefs := []struct {
+		nonFatal    bool
+		expectedErr string
+	}{
+		{
+			nonFatal: true,
+		},
+		{
+			nonFatal:    false,
+			expectedErr: "failed to start API",
+		},
+	}
+	for _, testDef := range testDefs {
+		cfg := &config.Config{
+			Api: config.ApiConfig{NonFatal: testDef.nonFatal},
+		}
+		pipe := pipeline.New()
+		err := start(cfg, logger, apiInstance, pipe)
+		if testDef.expectedErr != "" {
+			assert.ErrorContains(t, err, testDef.expectedErr)
+		} else {
+			assert.NoError(t, err)
+			_ = pipe.Stop()
+		}
+	}
+}
+
+func TestPrintSchemas(t *testing.T) {
+	var buf1, buf2 bytes.Buffer
+	assert.NoError(t, printSchemas(&buf1))
+	assert.NoError(t, printSchemas(&buf2))
+	// The output is deterministic so that it can be diffed
+	assert.Equal(t, buf1.String(), buf2.String())
+	var schemas map[string]map[string]any
+	assert.NoError(t, json.Unmarshal(buf1.Bytes(), &schemas))
+	for _, eventType := range []string{"chainsync.block", "chainsync.transaction", "chainsync.rollback"} {
+		assert.Contains(t, schemas, eventType)
+	}
+	payload := schemas["chainsync.transaction"]["properties"].(map[string]any)["payload"].(map[string]any)
+	assert.Contains(t, payload["properties"], "fee")
+	assert.NotContains(t, payload["properties"], "Transaction")
+}
+
+func TestStopWithForceExit(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	sigChan := make(chan os.Signal, 1)
+	exitChan := make(chan struct{})
+	stopChan := make(chan struct{})
+	go stopWithForceExit(
+		logger,
+		sigChan,
+		// The stop function blocks until the force exit, like a stuck shutdown
+		func() { <-stopChan },
+		func() { close(exitChan) },
+	)
+	sigChan <- syscall.SIGINT
+	select {
+	case <-exitChan:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for force exit")
+	}
+	close(stopChan)
+}
+
+func TestFilterEntriesOrder(t *testing.T) {
+	entries := filterEntries()
+	if assert.NotEmpty(t, entries) {
+		assert.Equal(t, "project", entries[len(entries)-1].Name)
+	}
+	names := map[string]bool{}
+	for _, entry := range entries {
+		names[entry.Name] = true
+	}
+	assert.Len(t, names, len(plugin.GetPlugins(plugin.PluginTypeFilter)))
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/filter/wallet"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/input/txsubmit"
+)
+
+// eventSchemaTypes maps each event type to zero values of its context and payload types. Governance data doesn't
+// have its own event type, and is included in the Extra map of transaction events
+var eventSchemaTypes = map[string]struct {
+	context interface{}
+	payload interface{}
+}{
+	"chainsync.block":                {chainsync.BlockContext{}, chainsync.BlockEvent{}},
+	"chainsync.transaction":          {chainsync.TransactionContext{}, chainsync.TransactionEvent{}},
+	"chainsync.rollback":             {nil, chainsync.RollbackEvent{}},
+	"chainsync.reset":                {nil, chainsync.ResetEvent{}},
+	"chainsync.unstable":             {nil, chainsync.ChainUnstableEvent{}},
+	"chainsync.certificate":          {chainsync.CertificateContext{}, chainsync.CertificateEvent{}},
+	"chainsync.stake_registration":   {chainsync.CertificateContext{}, chainsync.StakeRegistrationEvent{}},
+	"chainsync.stake_deregistration": {chainsync.CertificateContext{}, chainsync.StakeRegistrationEvent{}},
+	"chainsync.pool":                 {chainsync.CertificateContext{}, chainsync.PoolRegistrationEvent{}},
+	"chainsync.reference_script":     {chainsync.TransactionContext{}, chainsync.ReferenceScriptEvent{}},
+	"txsubmit.result":                {txsubmit.SubmitResultContext{}, txsubmit.SubmitResultEvent{}},
+	"wallet.activity":                {chainsync.TransactionContext{}, wallet.WalletActivityEvent{}},
+	"dead_letter":                    {nil, event.DeadLetterEvent{}},
+}
+
+// printSchemas writes the JSON schemas for all event types, keyed by event type. The output is deterministic, so
+// that it can be committed and diffed
+func printSchemas(w io.Writer) error {
+	schemas := make(map[string]any, len(eventSchemaTypes))
+	for eventType, types := range eventSchemaTypes {
+		schemas[eventType] = event.Schema(types.context, types.payload)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(schemas)
+}
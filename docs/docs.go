@@ -23,6 +23,44 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/epoch": {
+            "get": {
+                "description": "Get the epoch containing a slot on the configured network",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Epoch lookup",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot number",
+                        "name": "slot",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Epoch",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid slot or network",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
         "/fcm": {
             "post": {
                 "description": "Store a new FCM token",
@@ -127,9 +165,396 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/filters": {
+            "get": {
+                "description": "Get the addresses and policy IDs currently being filtered on",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Current filters",
+                "responses": {
+                    "200": {
+                        "description": "Current filters",
+                        "schema": {
+                            "$ref": "#/definitions/chainsync.FiltersResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/filters/addresses": {
+            "post": {
+                "description": "Start filtering on an address without restarting",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Add address filter",
+                "parameters": [
+                    {
+                        "description": "Address",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/chainsync.AddressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Current filters",
+                        "schema": {
+                            "$ref": "#/definitions/chainsync.FiltersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/filters/addresses/{address}": {
+            "delete": {
+                "description": "Stop filtering on an address without restarting. The last address can't be removed, since that would match every transaction",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Remove address filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Current filters",
+                        "schema": {
+                            "$ref": "#/definitions/chainsync.FiltersResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Last address in filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/filters/policies": {
+            "post": {
+                "description": "Start filtering on an asset policy ID without restarting",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Add policy filter",
+                "parameters": [
+                    {
+                        "description": "Policy ID",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/chainsync.PolicyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Current filters",
+                        "schema": {
+                            "$ref": "#/definitions/chainsync.FiltersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/filters/policies/{policyId}": {
+            "delete": {
+                "description": "Stop filtering on an asset policy ID without restarting. The last policy ID can't be removed, since that would match every transaction",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Remove policy filter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Policy ID",
+                        "name": "policyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Current filters",
+                        "schema": {
+                            "$ref": "#/definitions/chainsync.FiltersResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Last policy ID in filter",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/ready": {
+            "get": {
+                "description": "Report whether the initial sync has reached the chain tip",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Readiness",
+                "responses": {
+                    "200": {
+                        "description": "Ready",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    },
+                    "503": {
+                        "description": "Not ready",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "boolean"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Report the number of events seen by each pipeline stage and when the last one was seen",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Pipeline stats",
+                "responses": {
+                    "200": {
+                        "description": "Stats",
+                        "schema": {
+                            "$ref": "#/definitions/pipeline.Stats"
+                        }
+                    }
+                }
+            }
+        },
+        "/tx/{hash}": {
+            "get": {
+                "description": "Get a recently seen transaction event by transaction hash",
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Transaction lookup",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Transaction hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Transaction event",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {}
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/txsubmit": {
+            "post": {
+                "description": "Submit a raw CBOR transaction to the local node",
+                "consumes": [
+                    "application/cbor"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Submit transaction",
+                "responses": {
+                    "202": {
+                        "description": "Transaction hash",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or rejected transaction",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Submission failed",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
+        "chainsync.AddressRequest": {
+            "type": "object",
+            "required": [
+                "address"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                }
+            }
+        },
+        "chainsync.FiltersResponse": {
+            "type": "object",
+            "properties": {
+                "addresses": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "policies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "chainsync.PolicyRequest": {
+            "type": "object",
+            "required": [
+                "policyId"
+            ],
+            "properties": {
+                "policyId": {
+                    "type": "string"
+                }
+            }
+        },
+        "pipeline.StageStats": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "integer"
+                },
+                "lastEvent": {
+                    "type": "string"
+                }
+            }
+        },
+        "pipeline.Stats": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "description": "Filter covers events that made it through all filter plugins (or all input events if there are no filters)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipeline.StageStats"
+                        }
+                    ]
+                },
+                "input": {
+                    "description": "Input covers events copied from input plugins into the pipeline",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipeline.StageStats"
+                        }
+                    ]
+                },
+                "output": {
+                    "description": "Output covers events delivered to the output plugins",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pipeline.StageStats"
+                        }
+                    ]
+                }
+            }
+        },
         "push.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -0,0 +1,40 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"time"
+)
+
+// DeadLetterEvent wraps an event that an output permanently failed to deliver, along with details of the failure
+type DeadLetterEvent struct {
+	Output string `json:"output"`
+	Error  string `json:"error"`
+	Event  Event  `json:"event"`
+}
+
+// NewDeadLetterEvent returns a new "dead_letter" event for an event that the named output failed to deliver
+func NewDeadLetterEvent(evt Event, output string, err error) Event {
+	return New(
+		"dead_letter",
+		time.Now(),
+		nil,
+		DeadLetterEvent{
+			Output: output,
+			Error:  err.Error(),
+			Event:  evt,
+		},
+	)
+}
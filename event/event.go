@@ -15,14 +15,20 @@
 package event
 
 import (
+	"strings"
 	"time"
 )
 
+// typePrefix is prepended to the type of all new events
+var typePrefix string
+
 type Event struct {
 	Type      string      `json:"type"`
 	Timestamp time.Time   `json:"timestamp"`
 	Context   interface{} `json:"context,omitempty"`
 	Payload   interface{} `json:"payload"`
+	// Destinations limits delivery of the event to the named outputs. The event is delivered to all outputs if empty
+	Destinations []string `json:"-"`
 }
 
 func New(
@@ -30,6 +36,9 @@ func New(
 	timestamp time.Time,
 	context, payload interface{},
 ) Event {
+	if typePrefix != "" {
+		eventType = typePrefix + "." + eventType
+	}
 	return Event{
 		Type:      eventType,
 		Timestamp: timestamp,
@@ -37,3 +46,18 @@ func New(
 		Payload:   payload,
 	}
 }
+
+// SetTypePrefix specifies a source identifier that is prepended to the type of all events created afterward,
+// separated by a '.' (e.g. "mainnet.chainsync.block"). This should be called before the pipeline is started
+func SetTypePrefix(prefix string) {
+	typePrefix = prefix
+}
+
+// BaseType returns the event type with any configured prefix removed. Code that handles specific event types
+// should compare against this rather than the full type
+func BaseType(eventType string) string {
+	if typePrefix == "" {
+		return eventType
+	}
+	return strings.TrimPrefix(eventType, typePrefix+".")
+}
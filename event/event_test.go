@@ -0,0 +1,33 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypePrefix(t *testing.T) {
+	event.SetTypePrefix("mainnet")
+	t.Cleanup(func() { event.SetTypePrefix("") })
+	evt := event.New("chainsync.block", time.Now(), nil, nil)
+	assert.Equal(t, "mainnet.chainsync.block", evt.Type)
+	assert.Equal(t, "chainsync.block", event.BaseType(evt.Type))
+	// Types without the prefix are returned as-is
+	assert.Equal(t, "chainsync.block", event.BaseType("chainsync.block"))
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// maxSafeInteger is the largest integer that a JavaScript number can represent exactly (2^53 - 1)
+var maxSafeInteger = big.NewInt(1<<53 - 1)
+
+// addressPrefixes are the bech32 prefixes (including the separator) of the addresses masked by MaskAddresses
+var addressPrefixes = []string{"addr1", "addr_test1", "stake1", "stake_test1"}
+
+const (
+	// Number of characters of a masked address shown after its prefix and at the end
+	maskedAddressChars = 6
+	// Suffix of the keys holding hex-encoded CBOR, such as 'blockCbor' and 'transactionCbor'
+	cborKeySuffix = "Cbor"
+	// Suffix added to a CBOR key for its diagnostic notation
+	cborDiagnosticKeySuffix = "Diagnostic"
+)
+
+// JSONOptions controls how events are rendered by MarshalJSON
+type JSONOptions struct {
+	// LargeIntsAsStrings renders integers outside of the JavaScript safe integer range as strings
+	LargeIntsAsStrings bool
+	// SortKeys renders the keys of every object in sorted order, regardless of struct field order, so that
+	// the same event always produces byte-identical output
+	SortKeys bool
+	// MaskAddresses replaces the middle of every address with '...', keeping its prefix and the first and last
+	// few characters, for feeds where full addresses shouldn't be shown
+	MaskAddresses bool
+	// CborDiagnostic adds the CBOR diagnostic notation of every hex-encoded CBOR value alongside it, under the same
+	// key with a 'Diagnostic' suffix (such as 'blockCborDiagnostic'), for protocol debugging
+	CborDiagnostic bool
+}
+
+// MarshalJSON encodes the provided value as JSON, applying any post-processing specified in opts
+func MarshalJSON(v any, opts JSONOptions) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.LargeIntsAsStrings && !opts.SortKeys && !opts.MaskAddresses && !opts.CborDiagnostic {
+		return data, nil
+	}
+	// Decode into generic values, preserving the original number representation. Re-encoding
+	// the resulting maps always writes their keys in sorted order
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var tmpData any
+	if err := dec.Decode(&tmpData); err != nil {
+		return nil, err
+	}
+	tmpData = processJSONValue(tmpData, opts)
+	return json.Marshal(tmpData)
+}
+
+func processJSONValue(v any, opts JSONOptions) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			val[k] = processJSONValue(item, opts)
+		}
+		if opts.CborDiagnostic {
+			addCborDiagnostics(val)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = processJSONValue(item, opts)
+		}
+	case json.Number:
+		if opts.LargeIntsAsStrings && isLargeInteger(val) {
+			return val.String()
+		}
+	case string:
+		if opts.MaskAddresses {
+			return MaskAddress(val)
+		}
+	}
+	return v
+}
+
+// addCborDiagnostics adds the diagnostic notation for each hex-encoded CBOR value in the object. Values that
+// can't be decoded are left alone
+func addCborDiagnostics(obj map[string]any) {
+	for k, item := range obj {
+		if !strings.HasSuffix(k, cborKeySuffix) {
+			continue
+		}
+		hexData, ok := item.(string)
+		if !ok {
+			continue
+		}
+		data, err := hex.DecodeString(hexData)
+		if err != nil {
+			continue
+		}
+		diag, err := CborDiagnostic(data)
+		if err != nil {
+			continue
+		}
+		obj[k+cborDiagnosticKeySuffix] = diag
+	}
+}
+
+// CborDiagnostic returns the CBOR diagnostic notation (RFC 8949 section 8) for the CBOR data, with byte strings
+// rendered as hex
+func CborDiagnostic(data []byte) (string, error) {
+	return cbor.Diagnose(data)
+}
+
+// MaskAddress returns the address with the middle replaced by '...', keeping the bech32 prefix and the first and
+// last few characters of the data. Strings that aren't bech32 addresses are returned unchanged
+func MaskAddress(address string) string {
+	for _, prefix := range addressPrefixes {
+		if !strings.HasPrefix(address, prefix) {
+			continue
+		}
+		if len(address) <= len(prefix)+maskedAddressChars*2 {
+			return address
+		}
+		return address[:len(prefix)+maskedAddressChars] + "..." + address[len(address)-maskedAddressChars:]
+	}
+	return address
+}
+
+// isLargeInteger returns true if the number is an integer outside of the JavaScript safe integer range
+func isLargeInteger(n json.Number) bool {
+	if strings.ContainsAny(n.String(), ".eE") {
+		return false
+	}
+	tmpInt, ok := new(big.Int).SetString(n.String(), 10)
+	if !ok {
+		return false
+	}
+	return tmpInt.CmpAbs(maxSafeInteger) > 0
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPayload struct {
+	Amount uint64 `json:"amount"`
+	Fee    uint64 `json:"fee"`
+}
+
+func TestMarshalJSONLargeIntsAsStrings(t *testing.T) {
+	evt := event.New(
+		"chainsync.transaction",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		nil,
+		testPayload{
+			Amount: 45_000_000_000_000_123,
+			Fee:    170_000,
+		},
+	)
+	// Default behavior keeps large values as numbers
+	data, err := event.MarshalJSON(evt, event.JSONOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"amount":45000000000000123`)
+	// Enabled option renders large values as strings and leaves safe values alone
+	data, err = event.MarshalJSON(
+		evt,
+		event.JSONOptions{LargeIntsAsStrings: true},
+	)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"amount":"45000000000000123"`)
+	assert.Contains(t, string(data), `"fee":170000`)
+}
+
+func TestMarshalJSONSortKeys(t *testing.T) {
+	evt := event.New(
+		"chainsync.transaction",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		map[string]any{"slotNumber": 123, "blockNumber": 45},
+		map[string]any{
+			"fee":    170_000,
+			"amount": 45_000_000_000_000_123,
+			"extra": map[string]any{
+				"zeta":  true,
+				"alpha": []any{map[string]any{"b": 2, "a": 1}},
+			},
+		},
+	)
+	data, err := event.MarshalJSON(evt, event.JSONOptions{SortKeys: true})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"context":{"blockNumber":45,"slotNumber":123},"payload":{"amount":45000000000000123,"extra":{"alpha":[{"a":1,"b":2}],"zeta":true},"fee":170000},"timestamp":"2024-01-01T00:00:00Z","type":"chainsync.transaction"}`,
+		string(data),
+	)
+	// Marshaling the same event again produces identical output
+	for i := 0; i < 10; i++ {
+		tmpData, err := event.MarshalJSON(
+			evt,
+			event.JSONOptions{SortKeys: true},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, data, tmpData)
+	}
+}
+
+func TestMarshalJSONMaskAddresses(t *testing.T) {
+	evt := event.New(
+		"chainsync.transaction",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		nil,
+		map[string]any{
+			"outputs": []any{
+				map[string]any{
+					"address": "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x",
+				},
+			},
+			"stakeAddress": "stake_test1uqevw2xnsc0pvn9t9r9c7qryfqfeerchgrlm3ea2nefr9hqp8n5xl",
+			"blockHash":    "abcd",
+		},
+	)
+	data, err := event.MarshalJSON(evt, event.JSONOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x")
+
+	data, err = event.MarshalJSON(evt, event.JSONOptions{MaskAddresses: true})
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		`{"payload":{"blockHash":"abcd","outputs":[{"address":"addr1qx2fxv...e35a3x"}],"stakeAddress":"stake_test1uqevw2...p8n5xl"},"timestamp":"2024-01-01T00:00:00Z","type":"chainsync.transaction"}`,
+		string(data),
+	)
+}
+
+func TestCborDiagnostic(t *testing.T) {
+	// [1, h'abcd', {"a": -2}]
+	diag, err := event.CborDiagnostic([]byte{0x83, 0x01, 0x42, 0xab, 0xcd, 0xa1, 0x61, 0x61, 0x21})
+	assert.NoError(t, err)
+	assert.Equal(t, `[1, h'abcd', {"a": -2}]`, diag)
+
+	evt := event.New(
+		"chainsync.transaction",
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		nil,
+		map[string]any{
+			"transactionCbor": "820102",
+			"blockCbor":       "not hex",
+			"fee":             170000,
+		},
+	)
+	data, err := event.MarshalJSON(evt, event.JSONOptions{CborDiagnostic: true})
+	assert.NoError(t, err)
+	// Values that aren't valid CBOR are left without a diagnostic
+	assert.Equal(
+		t,
+		`{"payload":{"blockCbor":"not hex","fee":170000,"transactionCbor":"820102","transactionCborDiagnostic":"[1, 2]"},"timestamp":"2024-01-01T00:00:00Z","type":"chainsync.transaction"}`,
+		string(data),
+	)
+}
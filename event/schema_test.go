@@ -0,0 +1,80 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/stretchr/testify/assert"
+)
+
+type schemaTestEmbedded struct {
+	Slot uint64 `json:"slot"`
+}
+
+type schemaTestPayload struct {
+	schemaTestEmbedded
+	Hash     string            `json:"hash"`
+	Fee      int64             `json:"fee,omitempty"`
+	Ratio    float64           `json:"ratio"`
+	Valid    bool              `json:"valid"`
+	Tags     []string          `json:"tags"`
+	Labels   map[string]uint32 `json:"labels,omitempty"`
+	Raw      []byte            `json:"raw,omitempty"`
+	Any      interface{}       `json:"any"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+func TestSchema(t *testing.T) {
+	schema := event.Schema(nil, schemaTestPayload{})
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	assert.JSONEq(
+		t,
+		`{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"type": {"type": "string"},
+				"timestamp": {"type": "string", "format": "date-time"},
+				"payload": {
+					"type": "object",
+					"properties": {
+						"slot": {"type": "integer", "minimum": 0},
+						"hash": {"type": "string"},
+						"fee": {"type": "integer"},
+						"ratio": {"type": "number"},
+						"valid": {"type": "boolean"},
+						"tags": {"type": "array", "items": {"type": "string"}},
+						"labels": {"type": "object", "additionalProperties": {"type": "integer", "minimum": 0}},
+						"raw": {"type": "string", "contentEncoding": "base64"},
+						"any": {}
+					},
+					"required": ["any", "hash", "ratio", "slot", "tags", "valid"]
+				}
+			},
+			"required": ["payload", "timestamp", "type"]
+		}`,
+		string(data),
+	)
+	// The context is only included when given
+	schema = event.Schema(schemaTestEmbedded{}, schemaTestPayload{})
+	assert.Contains(t, schema["properties"], "context")
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import "sync"
+
+// SerializeFunc encodes an event for delivery by an output
+type SerializeFunc func(Event) ([]byte, error)
+
+// SerializedEvent is an event along with its encoded form, or the error from encoding it
+type SerializedEvent struct {
+	Event Event
+	Data  []byte
+	Err   error
+}
+
+type serializeJob struct {
+	evt    Event
+	result chan SerializedEvent
+}
+
+// SerializeOrdered encodes the events read from input using the provided function across a pool of workers. The
+// results are sent to the returned channel in the order the events were read, regardless of which worker finishes
+// first. At most workers events are in flight at a time. The returned channel is closed once input is closed and
+// all of its events have been delivered
+func SerializeOrdered(
+	input <-chan Event,
+	workers int,
+	serialize SerializeFunc,
+) <-chan SerializedEvent {
+	workers = max(workers, 1)
+	jobs := make(chan serializeJob)
+	pending := make(chan chan SerializedEvent, workers)
+	output := make(chan SerializedEvent)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				data, err := serialize(job.evt)
+				job.result <- SerializedEvent{Event: job.evt, Data: data, Err: err}
+			}
+		}()
+	}
+	// Dispatch events to the workers, queueing a result channel for each so the results can be collected in order
+	go func() {
+		for evt := range input {
+			result := make(chan SerializedEvent, 1)
+			pending <- result
+			jobs <- serializeJob{evt: evt, result: result}
+		}
+		close(jobs)
+		close(pending)
+	}()
+	go func() {
+		for result := range pending {
+			output <- <-result
+		}
+		wg.Wait()
+		close(output)
+	}()
+	return output
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSerializeTestEvents(count int, payloadSize int) []event.Event {
+	ret := make([]event.Event, 0, count)
+	for i := 0; i < count; i++ {
+		ret = append(
+			ret,
+			event.New(
+				"chainsync.transaction",
+				time.Unix(int64(i), 0),
+				map[string]any{"index": i},
+				map[string]any{
+					"datum": strings.Repeat(fmt.Sprintf("%02x", i%256), payloadSize),
+					"fee":   uint64(i) * 1_000_000_000_000_000,
+				},
+			),
+		)
+	}
+	return ret
+}
+
+func serializeTestEvents(
+	events []event.Event,
+	workers int,
+	serialize event.SerializeFunc,
+) []event.SerializedEvent {
+	input := make(chan event.Event)
+	go func() {
+		for _, evt := range events {
+			input <- evt
+		}
+		close(input)
+	}()
+	ret := []event.SerializedEvent{}
+	for result := range event.SerializeOrdered(input, workers, serialize) {
+		ret = append(ret, result)
+	}
+	return ret
+}
+
+func TestSerializeOrdered(t *testing.T) {
+	events := newSerializeTestEvents(200, 64)
+	opts := event.JSONOptions{LargeIntsAsStrings: true, SortKeys: true}
+	serialize := func(evt event.Event) ([]byte, error) {
+		// Make earlier events take longer so that workers finish out of order
+		idx := evt.Context.(map[string]any)["index"].(int)
+		time.Sleep(time.Duration(idx%5) * 100 * time.Microsecond)
+		return event.MarshalJSON(evt, opts)
+	}
+	sequential := serializeTestEvents(events, 1, serialize)
+	parallel := serializeTestEvents(events, 8, serialize)
+	assert.Len(t, sequential, len(events))
+	assert.Len(t, parallel, len(events))
+	for idx, evt := range events {
+		expected, err := event.MarshalJSON(evt, opts)
+		assert.NoError(t, err)
+		assert.Equal(t, evt, parallel[idx].Event)
+		assert.NoError(t, parallel[idx].Err)
+		assert.Equal(t, expected, parallel[idx].Data)
+		assert.Equal(t, sequential[idx].Data, parallel[idx].Data)
+	}
+}
+
+func BenchmarkSerializeOrdered(b *testing.B) {
+	events := newSerializeTestEvents(100, 4096)
+	opts := event.JSONOptions{SortKeys: true}
+	serialize := func(evt event.Event) ([]byte, error) {
+		return event.MarshalJSON(evt, opts)
+	}
+	for _, workers := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				serializeTestEvents(events, workers, serialize)
+			}
+		})
+	}
+}
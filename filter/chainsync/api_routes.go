@@ -0,0 +1,197 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blinklabs-io/adder/api"
+
+	"github.com/blinklabs-io/gouroboros/bech32"
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+var routesRegistered = false
+
+func (c *ChainSync) RegisterRoutes() {
+	if routesRegistered {
+		return
+	}
+
+	apiInstance := api.GetInstance()
+	apiInstance.AddRoute("GET", "/filters", c.handleGetFilters)
+	apiInstance.AddRoute("POST", "/filters/addresses", c.handleAddAddress)
+	apiInstance.AddRoute("DELETE", "/filters/addresses/:address", c.handleRemoveAddress)
+	apiInstance.AddRoute("POST", "/filters/policies", c.handleAddPolicy)
+	apiInstance.AddRoute("DELETE", "/filters/policies/:policyId", c.handleRemovePolicy)
+
+	routesRegistered = true
+}
+
+// FiltersResponse is the current set of addresses and policy IDs being filtered on
+type FiltersResponse struct {
+	Addresses []string `json:"addresses"`
+	Policies  []string `json:"policies"`
+}
+
+type AddressRequest struct {
+	Address string `json:"address" binding:"required"`
+}
+
+type PolicyRequest struct {
+	PolicyId string `json:"policyId" binding:"required"`
+}
+
+// validateAddress checks that an address is a bech32-encoded payment or stake address
+func validateAddress(address string) error {
+	hrp, _, err := bech32.DecodeNoLimit(address)
+	if err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+	if !strings.HasPrefix(hrp, "addr") && !strings.HasPrefix(hrp, "stake") {
+		return fmt.Errorf("invalid address prefix '%s'", hrp)
+	}
+	return nil
+}
+
+// validatePolicyId checks that a policy ID is a hex-encoded 28-byte hash
+func validatePolicyId(policyId string) error {
+	policyIdBytes, err := hex.DecodeString(policyId)
+	if err != nil || len(policyIdBytes) != ledger.AddressHashSize {
+		return errors.New("invalid policy ID, expected 56 hex characters")
+	}
+	return nil
+}
+
+func (c *ChainSync) filtersResponse() FiltersResponse {
+	// Return empty lists rather than null in the JSON
+	return FiltersResponse{
+		Addresses: append([]string{}, c.Addresses()...),
+		Policies:  append([]string{}, c.Policies()...),
+	}
+}
+
+// @Summary		Current filters
+// @Description	Get the addresses and policy IDs currently being filtered on
+// @Produce		json
+// @Success		200	{object}	FiltersResponse	"Current filters"
+// @Router			/filters [get]
+func (c *ChainSync) handleGetFilters(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, c.filtersResponse())
+}
+
+// @Summary		Add address filter
+// @Description	Start filtering on an address without restarting
+// @Accept			json
+// @Produce		json
+// @Param			body	body		AddressRequest		true	"Address"
+// @Success		200		{object}	FiltersResponse		"Current filters"
+// @Failure		400		{object}	map[string]string	"Invalid request"
+// @Router			/filters/addresses [post]
+func (c *ChainSync) handleAddAddress(ctx *gin.Context) {
+	var req AddressRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	if err := validateAddress(req.Address); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if c.AddAddress(req.Address) && c.logger != nil {
+		c.logger.Infof("added address filter %s", req.Address)
+	}
+	ctx.JSON(http.StatusOK, c.filtersResponse())
+}
+
+// @Summary		Remove address filter
+// @Description	Stop filtering on an address without restarting. The last address can't be removed, since that would match every transaction
+// @Produce		json
+// @Param			address	path		string				true	"Address"
+// @Success		200		{object}	FiltersResponse		"Current filters"
+// @Failure		404		{object}	map[string]string	"Not found"
+// @Failure		409		{object}	map[string]string	"Last address in filter"
+// @Router			/filters/addresses/{address} [delete]
+func (c *ChainSync) handleRemoveAddress(ctx *gin.Context) {
+	address := ctx.Param("address")
+	ok, err := c.removeAddress(address, true)
+	if err != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if !ok {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "address not in filter"})
+		return
+	}
+	if c.logger != nil {
+		c.logger.Infof("removed address filter %s", address)
+	}
+	ctx.JSON(http.StatusOK, c.filtersResponse())
+}
+
+// @Summary		Add policy filter
+// @Description	Start filtering on an asset policy ID without restarting
+// @Accept			json
+// @Produce		json
+// @Param			body	body		PolicyRequest		true	"Policy ID"
+// @Success		200		{object}	FiltersResponse		"Current filters"
+// @Failure		400		{object}	map[string]string	"Invalid request"
+// @Router			/filters/policies [post]
+func (c *ChainSync) handleAddPolicy(ctx *gin.Context) {
+	var req PolicyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	if err := validatePolicyId(req.PolicyId); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if c.AddPolicy(req.PolicyId) && c.logger != nil {
+		c.logger.Infof("added policy filter %s", req.PolicyId)
+	}
+	ctx.JSON(http.StatusOK, c.filtersResponse())
+}
+
+// @Summary		Remove policy filter
+// @Description	Stop filtering on an asset policy ID without restarting. The last policy ID can't be removed, since that would match every transaction
+// @Produce		json
+// @Param			policyId	path		string				true	"Policy ID"
+// @Success		200			{object}	FiltersResponse		"Current filters"
+// @Failure		404			{object}	map[string]string	"Not found"
+// @Failure		409			{object}	map[string]string	"Last policy ID in filter"
+// @Router			/filters/policies/{policyId} [delete]
+func (c *ChainSync) handleRemovePolicy(ctx *gin.Context) {
+	policyId := ctx.Param("policyId")
+	ok, err := c.removePolicy(policyId, true)
+	if err != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if !ok {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "policy not in filter"})
+		return
+	}
+	if c.logger != nil {
+		c.logger.Infof("removed policy filter %s", policyId)
+	}
+	ctx.JSON(http.StatusOK, c.filtersResponse())
+}
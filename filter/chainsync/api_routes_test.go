@@ -0,0 +1,141 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blinklabs-io/adder/api"
+	"github.com/blinklabs-io/adder/event"
+	filter_chainsync "github.com/blinklabs-io/adder/filter/chainsync"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+func TestDynamicAddressFilter(t *testing.T) {
+	apiInstance := api.New(false)
+	addrA := newBaseAddress(t, 0x01, 0x02)
+	addrB := newBaseAddress(t, 0x03, 0x04)
+	c := filter_chainsync.New(
+		filter_chainsync.WithAddresses([]string{addrA.String()}),
+	)
+	c.RegisterRoutes()
+	router := apiInstance.Engine()
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+
+	request := func(method string, path string, body any) (int, filter_chainsync.FiltersResponse) {
+		var reqBody bytes.Buffer
+		if body != nil {
+			_ = json.NewEncoder(&reqBody).Encode(body)
+		}
+		req, _ := http.NewRequest(method, path, &reqBody)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var resp filter_chainsync.FiltersResponse
+		_ = json.Unmarshal(w.Body.Bytes(), &resp)
+		return w.Code, resp
+	}
+	matches := func(addr ledger.Address) bool {
+		c.InputChan() <- event.New(
+			"chainsync.transaction",
+			time.Now(),
+			chainsync.TransactionContext{},
+			chainsync.TransactionEvent{
+				Outputs: []ledger.TransactionOutput{mockOutput{address: addr}},
+			},
+		)
+		return receiveEvent(c) != nil
+	}
+
+	assert.True(t, matches(addrA))
+	assert.False(t, matches(addrB))
+
+	// A newly added address matches subsequent events
+	code, resp := request("POST", "/filters/addresses", map[string]string{"address": addrB.String()})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{addrA.String(), addrB.String()}, resp.Addresses)
+	assert.True(t, matches(addrB))
+
+	// A removed address stops matching
+	code, resp = request("DELETE", "/filters/addresses/"+url.PathEscape(addrB.String()), nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{addrA.String()}, resp.Addresses)
+	assert.False(t, matches(addrB))
+	assert.True(t, matches(addrA))
+
+	code, _ = request("DELETE", "/filters/addresses/"+url.PathEscape(addrB.String()), nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = request("POST", "/filters/addresses", map[string]string{})
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = request("POST", "/filters/addresses", map[string]string{"address": "not-an-address"})
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Removing the last address via the API is rejected, since it would match every transaction
+	code, _ = request("DELETE", "/filters/addresses/"+url.PathEscape(addrA.String()), nil)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.False(t, matches(addrB))
+	// Policy IDs must be 28-byte hex hashes
+	policyId := strings.Repeat("ab", 28)
+	code, _ = request("POST", "/filters/policies", map[string]string{"policyId": "abcd"})
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, resp = request("POST", "/filters/policies", map[string]string{"policyId": policyId})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{policyId}, resp.Policies)
+	code, _ = request("DELETE", "/filters/policies/"+policyId, nil)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.True(t, c.RemovePolicy(policyId))
+	code, resp = request("GET", "/filters", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(
+		t,
+		filter_chainsync.FiltersResponse{Addresses: []string{addrA.String()}, Policies: []string{}},
+		resp,
+	)
+}
+
+func TestRemoveLastAddress(t *testing.T) {
+	addrA := newBaseAddress(t, 0x01, 0x02)
+	addrB := newBaseAddress(t, 0x03, 0x04)
+	c := filter_chainsync.New(
+		filter_chainsync.WithAddresses([]string{addrA.String()}),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	// Removing the last address directly reverts to not filtering by address
+	assert.True(t, c.RemoveAddress(addrA.String()))
+	assert.Empty(t, c.Addresses())
+	c.InputChan() <- event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{},
+		chainsync.TransactionEvent{
+			Outputs: []ledger.TransactionOutput{mockOutput{address: addrB}},
+		},
+	)
+	assert.NotNil(t, receiveEvent(c))
+}
@@ -17,8 +17,11 @@ package chainsync
 import (
 	"encoding/hex"
 	"strings"
+	"sync"
 
+	ouroboros "github.com/blinklabs-io/gouroboros"
 	"github.com/blinklabs-io/gouroboros/bech32"
+	"github.com/blinklabs-io/gouroboros/cbor"
 	"github.com/blinklabs-io/gouroboros/ledger"
 
 	"github.com/blinklabs-io/adder/event"
@@ -31,10 +34,69 @@ type ChainSync struct {
 	inputChan               chan event.Event
 	outputChan              chan event.Event
 	logger                  plugin.Logger
+	network                 string
 	filterAddresses         []string
 	filterAssetFingerprints []string
 	filterPolicyIds         []string
 	filterPoolIds           []string
+	filterMinTxSize         uint
+	filterMaxTxSize         uint
+	filterSet               filterSet
+	addressStakeMatch       bool
+	// Protects filterAddresses and filterPolicyIds, which can be updated via the API while the filter is running.
+	// Updates replace the slices rather than modifying them
+	filterMutex sync.RWMutex
+	// Whether we've warned about a transaction that couldn't be sized
+	txSizeWarned bool
+}
+
+type filterSet struct {
+	hasFeeFilter          bool
+	feeFilter             feeFilter
+	hasMetadataFilter     bool
+	metadataFilter        []uint64
+	hasEraFilter          bool
+	eraFilter             map[string]bool
+	assetMinQuantity      map[string]uint64
+	hasDatumFilter        bool
+	datumFilter           datumFilter
+	hasScriptFilter       bool
+	scriptFilter          scriptFilter
+	hasOutputAmountFilter bool
+	outputAmountFilter    outputAmountFilter
+	stakeCredentialFilter map[string]bool
+	rewardAccountFilter   map[string]bool
+	paymentKeyHashFilter  map[ledger.Blake2b224]bool
+	hasSlotFilter         bool
+	slotFilter            slotFilter
+}
+
+type datumFilter struct {
+	datumHashes        map[string]bool
+	requireInlineDatum bool
+}
+
+type scriptFilter struct {
+	scriptHashes     map[string]bool
+	requireScriptRef bool
+}
+
+type feeFilter struct {
+	minFee uint64
+	// A maxFee of 0 means there is no upper bound
+	maxFee uint64
+}
+
+type slotFilter struct {
+	minSlot uint64
+	// A maxSlot of 0 means there is no upper bound
+	maxSlot uint64
+}
+
+type outputAmountFilter struct {
+	minAmount uint64
+	// A maxAmount of 0 means there is no upper bound
+	maxAmount uint64
 }
 
 // New returns a new ChainSync object with the specified options applied
@@ -52,6 +114,8 @@ func New(options ...ChainSyncOptionFunc) *ChainSync {
 
 // Start the chain sync filter
 func (c *ChainSync) Start() error {
+	c.logFilterSummary()
+	c.checkAddressNetworks()
 	go func() {
 		// TODO: pre-process filter params to be more useful for direct comparison
 		for {
@@ -60,237 +124,351 @@ func (c *ChainSync) Start() error {
 			if !ok {
 				return
 			}
-			switch v := evt.Payload.(type) {
-			case chainsync.BlockEvent:
-				// Check pool filter
-				if len(c.filterPoolIds) > 0 {
-					filterMatched := false
-					for _, filterPoolId := range c.filterPoolIds {
-						isPoolBech32 := strings.HasPrefix(filterPoolId, "pool")
-						foundMatch := false
-						be := evt.Payload.(chainsync.BlockEvent)
-						if be.IssuerVkey == filterPoolId {
+			if !c.filterEvent(evt) {
+				continue
+			}
+			c.outputChan <- evt
+		}
+	}()
+	return nil
+}
+
+// logFilterSummary logs the active filter configuration to make it easier to spot config mistakes
+func (c *ChainSync) logFilterSummary() {
+	if c.logger == nil {
+		return
+	}
+	c.logger.Infof(
+		"active filters: addresses=%d, policies=%d, assets=%d, pools=%d, eras=%d, metadataLabels=%d, datumHashes=%d, inlineDatum=%t, scriptHashes=%d, scriptRef=%t, stakeCredentials=%d, rewardAccounts=%d, paymentKeyHashes=%d, feeRange=%t, outputAmountRange=%t, txSizeRange=%t, slotRange=%t, addressStakeMatch=%t",
+		len(c.filterAddresses),
+		len(c.filterPolicyIds),
+		len(c.filterAssetFingerprints),
+		len(c.filterPoolIds),
+		len(c.filterSet.eraFilter),
+		len(c.filterSet.metadataFilter),
+		len(c.filterSet.datumFilter.datumHashes),
+		c.filterSet.datumFilter.requireInlineDatum,
+		len(c.filterSet.scriptFilter.scriptHashes),
+		c.filterSet.scriptFilter.requireScriptRef,
+		len(c.filterSet.stakeCredentialFilter),
+		len(c.filterSet.rewardAccountFilter),
+		len(c.filterSet.paymentKeyHashFilter),
+		c.filterSet.hasFeeFilter,
+		c.filterSet.hasOutputAmountFilter,
+		c.filterMinTxSize > 0 || c.filterMaxTxSize > 0,
+		c.filterSet.hasSlotFilter,
+		c.addressStakeMatch,
+	)
+}
+
+// checkAddressNetworks warns about filter addresses with a bech32 prefix for a different network than the one
+// configured, since they'll never match
+func (c *ChainSync) checkAddressNetworks() {
+	if c.logger == nil || c.network == "" {
+		return
+	}
+	network := ouroboros.NetworkByName(c.network)
+	if network == ouroboros.NetworkInvalid {
+		c.logger.Warnf("unknown network %s, not checking filter addresses", c.network)
+		return
+	}
+	for _, address := range c.filterAddresses {
+		hrp, _, err := bech32.DecodeNoLimit(address)
+		if err != nil {
+			continue
+		}
+		// Testnet addresses use prefixes like 'addr_test' and 'stake_test'
+		if !strings.HasPrefix(hrp, "addr") && !strings.HasPrefix(hrp, "stake") {
+			continue
+		}
+		isTestnet := strings.HasSuffix(hrp, "_test")
+		if isTestnet != (network.Id == ledger.AddressNetworkTestnet) {
+			c.logger.Warnf(
+				"filter address %s has prefix '%s', which doesn't match network %s and will never match",
+				address,
+				hrp,
+				c.network,
+			)
+		}
+	}
+}
+
+// filterEvent returns true if the event matches all configured filters. Events other than blocks, transactions
+// and stake registrations always match, except for certificate events outside of the slot range
+func (c *ChainSync) filterEvent(evt event.Event) bool {
+	c.filterMutex.RLock()
+	defer c.filterMutex.RUnlock()
+	// Check slot filter, using the slot from the event context
+	if c.filterSet.hasSlotFilter {
+		if slot, ok := eventSlot(evt); ok && !c.matchSlotFilter(slot) {
+			return false
+		}
+	}
+	switch v := evt.Payload.(type) {
+	case chainsync.BlockEvent:
+		blockCtx, _ := evt.Context.(chainsync.BlockContext)
+		return c.filterBlockEvent(v, blockCtx)
+	case chainsync.TransactionEvent:
+		txCtx, _ := evt.Context.(chainsync.TransactionContext)
+		return c.filterTransactionEvent(v, txCtx)
+	case chainsync.StakeRegistrationEvent:
+		return c.matchStakeCredentialFilter(v)
+	}
+	return true
+}
+
+// filterBlockEvent returns true if the block event matches all configured block filters
+func (c *ChainSync) filterBlockEvent(
+	be chainsync.BlockEvent,
+	blockCtx chainsync.BlockContext,
+) bool {
+	// Check era filter
+	if c.filterSet.hasEraFilter {
+		if !c.matchEraFilter(blockCtx.Era) {
+			return false
+		}
+	}
+	// Check pool filter
+	if len(c.filterPoolIds) > 0 {
+		filterMatched := false
+		for _, filterPoolId := range c.filterPoolIds {
+			isPoolBech32 := strings.HasPrefix(filterPoolId, "pool")
+			foundMatch := false
+			if be.IssuerVkey == filterPoolId {
+				foundMatch = true
+			} else if isPoolBech32 {
+				issuerBytes, err := hex.DecodeString(be.IssuerVkey)
+				if err != nil {
+					// eat this error... nom nom nom
+					continue
+				}
+				// lifted from gouroboros/ledger
+				convData, err := bech32.ConvertBits(issuerBytes, 8, 5, true)
+				if err != nil {
+					continue
+				}
+				encoded, err := bech32.Encode("pool", convData)
+				if err != nil {
+					continue
+				}
+				if encoded == filterPoolId {
+					foundMatch = true
+				}
+			}
+			if foundMatch {
+				filterMatched = true
+				break
+			}
+		}
+		// Skip the event if none of the filter values matched
+		if !filterMatched {
+			return false
+		}
+	}
+	return true
+}
+
+// filterTransactionEvent returns true if the transaction event matches all configured transaction filters
+func (c *ChainSync) filterTransactionEvent(
+	te chainsync.TransactionEvent,
+	txCtx chainsync.TransactionContext,
+) bool {
+	// Check era filter, using the era of the containing block
+	if c.filterSet.hasEraFilter {
+		if !c.matchEraFilter(txCtx.Era) {
+			return false
+		}
+	}
+	// Check address and payment key hash filters. A transaction matching either is accepted
+	if len(c.filterAddresses) > 0 || len(c.filterSet.paymentKeyHashFilter) > 0 {
+		if !c.matchAddressFilter(te) && !c.matchPaymentKeyHashFilter(te) {
+			return false
+		}
+	}
+	// Check policy ID filter
+	if len(c.filterPolicyIds) > 0 {
+		filterMatched := false
+		for _, filterPolicyId := range c.filterPolicyIds {
+			foundMatch := false
+			for _, output := range te.Outputs {
+				if output.Assets() != nil {
+					for _, policyId := range output.Assets().Policies() {
+						if policyId.String() == filterPolicyId {
 							foundMatch = true
-						} else if isPoolBech32 {
-							issuerBytes, err := hex.DecodeString(be.IssuerVkey)
-							if err != nil {
-								// eat this error... nom nom nom
-								continue
-							}
-							// lifted from gouroboros/ledger
-							convData, err := bech32.ConvertBits(issuerBytes, 8, 5, true)
-							if err != nil {
-								continue
-							}
-							encoded, err := bech32.Encode("pool", convData)
-							if err != nil {
-								continue
-							}
-							if encoded == filterPoolId {
-								foundMatch = true
-							}
-						}
-						if foundMatch {
-							filterMatched = true
 							break
 						}
 					}
-					// Skip the event if none of the filter values matched
-					if !filterMatched {
-						continue
-					}
 				}
-			case chainsync.TransactionEvent:
-				// Check address filter
-				if len(c.filterAddresses) > 0 {
-					filterMatched := false
-					for _, filterAddress := range c.filterAddresses {
-						isStakeAddress := strings.HasPrefix(filterAddress, "stake")
-						foundMatch := false
-						for _, output := range v.Outputs {
-							if output.Address().String() == filterAddress {
-								foundMatch = true
-								break
-							}
-							if isStakeAddress {
-								stakeAddr := output.Address().StakeAddress()
-								if stakeAddr == nil {
-									continue
-								}
-								if stakeAddr.String() == filterAddress {
-									foundMatch = true
-									break
-								}
-							}
-						}
-						if foundMatch {
-							filterMatched = true
-							break
-						}
-					}
-					// Skip the event if none of the filter values matched
-					if !filterMatched {
-						continue
-					}
+				if foundMatch {
+					break
 				}
-				// Check policy ID filter
-				if len(c.filterPolicyIds) > 0 {
-					filterMatched := false
-					for _, filterPolicyId := range c.filterPolicyIds {
-						foundMatch := false
-						for _, output := range v.Outputs {
-							if output.Assets() != nil {
-								for _, policyId := range output.Assets().Policies() {
-									if policyId.String() == filterPolicyId {
-										foundMatch = true
-										break
-									}
-								}
-							}
-							if foundMatch {
-								break
-							}
+			}
+			if foundMatch {
+				filterMatched = true
+				break
+			}
+		}
+		// Skip the event if none of the filter values matched
+		if !filterMatched {
+			return false
+		}
+	}
+	// Check asset fingerprint filter
+	if len(c.filterAssetFingerprints) > 0 {
+		if !c.matchAssetFilter(te) {
+			return false
+		}
+	}
+	// Check pool filter
+	if len(c.filterPoolIds) > 0 {
+		filterMatched := false
+		for _, filterPoolId := range c.filterPoolIds {
+			if filterMatched {
+				break
+			}
+			isPoolBech32 := strings.HasPrefix(filterPoolId, "pool")
+			foundMatch := false
+			for _, certificate := range te.Certificates {
+				switch cert := certificate.(type) {
+				case *ledger.StakeDelegationCertificate:
+					b := &ledger.Blake2b224{}
+					copy(b[:], cert.PoolKeyHash[:])
+					if b.String() == filterPoolId {
+						foundMatch = true
+					} else if isPoolBech32 {
+						// lifted from gouroboros/ledger
+						convData, err := bech32.ConvertBits(certificate.Cbor(), 8, 5, true)
+						if err != nil {
+							continue
 						}
-						if foundMatch {
-							filterMatched = true
-							break
+						encoded, err := bech32.Encode("pool", convData)
+						if err != nil {
+							continue
+						}
+						if encoded == filterPoolId {
+							foundMatch = true
 						}
 					}
-					// Skip the event if none of the filter values matched
-					if !filterMatched {
-						continue
+					if foundMatch {
+						filterMatched = true
+						break
 					}
-				}
-				// Check asset fingerprint filter
-				if len(c.filterAssetFingerprints) > 0 {
-					filterMatched := false
-					for _, filterAssetFingerprint := range c.filterAssetFingerprints {
-						foundMatch := false
-						for _, output := range v.Outputs {
-							if output.Assets() != nil {
-								for _, policyId := range output.Assets().Policies() {
-									for _, assetName := range output.Assets().Assets(policyId) {
-										assetFp := ledger.NewAssetFingerprint(policyId.Bytes(), assetName)
-										if assetFp.String() == filterAssetFingerprint {
-											foundMatch = true
-										}
-									}
-									if foundMatch {
-										break
-									}
-								}
-								if foundMatch {
-									break
-								}
-							}
+				case *ledger.PoolRetirementCertificate:
+					b := &ledger.Blake2b224{}
+					copy(b[:], cert.PoolKeyHash[:])
+					if b.String() == filterPoolId {
+						foundMatch = true
+					} else if isPoolBech32 {
+						// lifted from gouroboros/ledger
+						convData, err := bech32.ConvertBits(certificate.Cbor(), 8, 5, true)
+						if err != nil {
+							continue
 						}
-						if foundMatch {
-							filterMatched = true
-							break
+						encoded, err := bech32.Encode("pool", convData)
+						if err != nil {
+							continue
+						}
+						if encoded == filterPoolId {
+							foundMatch = true
 						}
 					}
-					// Skip the event if none of the filter values matched
-					if !filterMatched {
-						continue
+					if foundMatch {
+						filterMatched = true
+						break
 					}
-				}
-				// Check pool filter
-				if len(c.filterPoolIds) > 0 {
-					filterMatched := false
-					for _, filterPoolId := range c.filterPoolIds {
-						if filterMatched {
-							break
+				case *ledger.PoolRegistrationCertificate:
+					b := &ledger.Blake2b224{}
+					copy(b[:], cert.Operator[:])
+					if b.String() == filterPoolId {
+						foundMatch = true
+					} else if isPoolBech32 {
+						// lifted from gouroboros/ledger
+						convData, err := bech32.ConvertBits(certificate.Cbor(), 8, 5, true)
+						if err != nil {
+							continue
 						}
-						isPoolBech32 := strings.HasPrefix(filterPoolId, "pool")
-						foundMatch := false
-						for _, certificate := range v.Certificates {
-							switch cert := certificate.(type) {
-							case *ledger.StakeDelegationCertificate:
-								b := &ledger.Blake2b224{}
-								copy(b[:], cert.PoolKeyHash[:])
-								if b.String() == filterPoolId {
-									foundMatch = true
-								} else if isPoolBech32 {
-									// lifted from gouroboros/ledger
-									convData, err := bech32.ConvertBits(certificate.Cbor(), 8, 5, true)
-									if err != nil {
-										continue
-									}
-									encoded, err := bech32.Encode("pool", convData)
-									if err != nil {
-										continue
-									}
-									if encoded == filterPoolId {
-										foundMatch = true
-									}
-								}
-								if foundMatch {
-									filterMatched = true
-									break
-								}
-							case *ledger.PoolRetirementCertificate:
-								b := &ledger.Blake2b224{}
-								copy(b[:], cert.PoolKeyHash[:])
-								if b.String() == filterPoolId {
-									foundMatch = true
-								} else if isPoolBech32 {
-									// lifted from gouroboros/ledger
-									convData, err := bech32.ConvertBits(certificate.Cbor(), 8, 5, true)
-									if err != nil {
-										continue
-									}
-									encoded, err := bech32.Encode("pool", convData)
-									if err != nil {
-										continue
-									}
-									if encoded == filterPoolId {
-										foundMatch = true
-									}
-								}
-								if foundMatch {
-									filterMatched = true
-									break
-								}
-							case *ledger.PoolRegistrationCertificate:
-								b := &ledger.Blake2b224{}
-								copy(b[:], cert.Operator[:])
-								if b.String() == filterPoolId {
-									foundMatch = true
-								} else if isPoolBech32 {
-									// lifted from gouroboros/ledger
-									convData, err := bech32.ConvertBits(certificate.Cbor(), 8, 5, true)
-									if err != nil {
-										continue
-									}
-									encoded, err := bech32.Encode("pool", convData)
-									if err != nil {
-										continue
-									}
-									if encoded == filterPoolId {
-										foundMatch = true
-									}
-								}
-								if foundMatch {
-									filterMatched = true
-									break
-								}
-							}
+						encoded, err := bech32.Encode("pool", convData)
+						if err != nil {
+							continue
 						}
-						if foundMatch {
-							filterMatched = true
-							break
+						if encoded == filterPoolId {
+							foundMatch = true
 						}
 					}
-					// Skip the event if none of the filter values matched
-					if !filterMatched {
-						continue
+					if foundMatch {
+						filterMatched = true
+						break
 					}
 				}
 			}
-			c.outputChan <- evt
+			if foundMatch {
+				filterMatched = true
+				break
+			}
 		}
-	}()
-	return nil
+		// Skip the event if none of the filter values matched
+		if !filterMatched {
+			return false
+		}
+	}
+	// Check metadata label filter
+	if c.filterSet.hasMetadataFilter {
+		if !c.matchMetadataFilter(te) {
+			return false
+		}
+	}
+	// Check datum filter
+	if c.filterSet.hasDatumFilter {
+		if !c.matchDatumFilter(te) {
+			return false
+		}
+	}
+	// Check script reference filter
+	if c.filterSet.hasScriptFilter {
+		if !c.matchScriptFilter(te) {
+			return false
+		}
+	}
+	// Check reward account filter
+	if len(c.filterSet.rewardAccountFilter) > 0 {
+		if !c.matchRewardAccountFilter(te) {
+			return false
+		}
+	}
+	// Check fee filter
+	if c.filterSet.hasFeeFilter {
+		if !c.matchFeeFilter(te) {
+			return false
+		}
+	}
+	// Check output amount filter
+	if c.filterSet.hasOutputAmountFilter {
+		if !c.matchOutputAmountFilter(te) {
+			return false
+		}
+	}
+	// Check transaction size filter
+	if c.filterMinTxSize > 0 || c.filterMaxTxSize > 0 {
+		txSize, ok := transactionSize(te)
+		if !ok {
+			// Pass the transaction through rather than silently dropping it
+			if !c.txSizeWarned && c.logger != nil {
+				c.logger.Warnf(
+					"transaction %s has no CBOR available to check its size, passing through transactions that can't be sized",
+					txCtx.TransactionHash,
+				)
+			}
+			c.txSizeWarned = true
+			return true
+		}
+		if txSize < c.filterMinTxSize {
+			return false
+		}
+		if c.filterMaxTxSize > 0 && txSize > c.filterMaxTxSize {
+			return false
+		}
+	}
+	return true
 }
 
 // Stop the chain sync filter
@@ -315,3 +493,281 @@ func (c *ChainSync) InputChan() chan<- event.Event {
 func (c *ChainSync) OutputChan() <-chan event.Event {
 	return c.outputChan
 }
+
+// matchAddressFilter returns true if any of the transaction outputs are for one of the configured addresses
+func (c *ChainSync) matchAddressFilter(te chainsync.TransactionEvent) bool {
+	for _, filterAddress := range c.filterAddresses {
+		isStakeAddress := strings.HasPrefix(filterAddress, "stake")
+		// Match on the stake part of a full address if enabled
+		var filterStakeAddress string
+		if isStakeAddress {
+			filterStakeAddress = filterAddress
+		} else if c.addressStakeMatch {
+			filterStakeAddress = stakeAddressString(filterAddress)
+		}
+		for _, output := range te.Outputs {
+			if output.Address().String() == filterAddress {
+				return true
+			}
+			if filterStakeAddress != "" {
+				stakeAddr := output.Address().StakeAddress()
+				if stakeAddr == nil {
+					continue
+				}
+				if stakeAddr.String() == filterStakeAddress {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// matchPaymentKeyHashFilter returns true if any of the transaction outputs have a payment part matching one of the
+// configured payment key hashes, regardless of their stake part. Outputs with no payment key hash, such as those
+// to script addresses, are skipped
+func (c *ChainSync) matchPaymentKeyHashFilter(te chainsync.TransactionEvent) bool {
+	for _, output := range te.Outputs {
+		addr := output.Address()
+		if !hasPaymentKeyHash(addr) {
+			continue
+		}
+		paymentKeyHash := addr.PaymentKeyHash()
+		if c.filterSet.paymentKeyHashFilter[paymentKeyHash] {
+			return true
+		}
+	}
+	return false
+}
+
+// matchStakeCredentialFilter returns true if the stake registration is for one of the configured stake credentials,
+// or if no stake credentials are configured
+func (c *ChainSync) matchStakeCredentialFilter(se chainsync.StakeRegistrationEvent) bool {
+	if len(c.filterSet.stakeCredentialFilter) == 0 {
+		return true
+	}
+	return c.filterSet.stakeCredentialFilter[se.StakeCredential]
+}
+
+// matchRewardAccountFilter returns true if the transaction withdraws rewards from any of the configured reward
+// accounts. Withdrawals are only available from the raw transaction, so events without it never match
+func (c *ChainSync) matchRewardAccountFilter(te chainsync.TransactionEvent) bool {
+	if te.Transaction == nil {
+		return false
+	}
+	for rewardAccount := range te.Transaction.Withdrawals() {
+		if rewardAccount == nil {
+			continue
+		}
+		if c.filterSet.rewardAccountFilter[rewardAccount.String()] {
+			return true
+		}
+	}
+	return false
+}
+
+// eventSlot returns the slot number from the event context, if it has one
+func eventSlot(evt event.Event) (uint64, bool) {
+	switch ctx := evt.Context.(type) {
+	case chainsync.BlockContext:
+		return ctx.SlotNumber, true
+	case chainsync.TransactionContext:
+		return ctx.SlotNumber, true
+	case chainsync.CertificateContext:
+		return ctx.SlotNumber, true
+	}
+	return 0, false
+}
+
+// matchSlotFilter returns true if the slot is in the configured inclusive
+// range, where a max slot of 0 means no upper bound
+func (c *ChainSync) matchSlotFilter(slot uint64) bool {
+	if slot < c.filterSet.slotFilter.minSlot {
+		return false
+	}
+	if c.filterSet.slotFilter.maxSlot > 0 && slot > c.filterSet.slotFilter.maxSlot {
+		return false
+	}
+	return true
+}
+
+// matchEraFilter returns true if the era name matches one of the configured eras, ignoring case
+func (c *ChainSync) matchEraFilter(era string) bool {
+	return c.filterSet.eraFilter[strings.ToLower(era)]
+}
+
+// matchMetadataFilter returns true if the transaction metadata contains any of the configured labels. The raw
+// transaction is checked when available, so that metadata omitted from the event by a size cap still matches
+func (c *ChainSync) matchMetadataFilter(te chainsync.TransactionEvent) bool {
+	metadata := te.Metadata
+	if te.Transaction != nil {
+		metadata = te.Transaction.Metadata()
+	}
+	return hasMetadataLabel(metadata, c.filterSet.metadataFilter)
+}
+
+// hasMetadataLabel returns true if the transaction metadata contains any of the specified labels.
+// Only the top-level map keys are decoded, and the metadata values are left as raw CBOR
+func hasMetadataLabel(metadata *cbor.LazyValue, labels []uint64) bool {
+	if metadata == nil {
+		return false
+	}
+	var tmpMetadata map[uint64]cbor.RawMessage
+	if _, err := cbor.Decode(metadata.Cbor(), &tmpMetadata); err != nil {
+		return false
+	}
+	for _, label := range labels {
+		if _, ok := tmpMetadata[label]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAssetFilter returns true if the transaction outputs contain any of the configured asset fingerprints. When a
+// minimum quantity is configured for a fingerprint, the total quantity of the asset across all outputs must be at
+// least that amount for it to match
+func (c *ChainSync) matchAssetFilter(te chainsync.TransactionEvent) bool {
+	quantities := map[string]uint64{}
+	for _, output := range te.Outputs {
+		if output.Assets() == nil {
+			continue
+		}
+		for _, policyId := range output.Assets().Policies() {
+			for _, assetName := range output.Assets().Assets(policyId) {
+				assetFp := ledger.NewAssetFingerprint(policyId.Bytes(), assetName)
+				quantities[assetFp.String()] += output.Assets().Asset(policyId, assetName)
+			}
+		}
+	}
+	for _, filterAssetFingerprint := range c.filterAssetFingerprints {
+		quantity, ok := quantities[filterAssetFingerprint]
+		if !ok {
+			continue
+		}
+		if minQuantity, ok := c.filterSet.assetMinQuantity[filterAssetFingerprint]; ok && quantity < minQuantity {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// matchDatumFilter returns true if any of the transaction outputs or resolved inputs has a datum hash matching
+// one of the configured hashes, or carries an inline datum when an inline datum is required. The raw transaction
+// outputs are checked when available, so that inline datums omitted from the event by a size cap still match
+func (c *ChainSync) matchDatumFilter(te chainsync.TransactionEvent) bool {
+	outputs := te.Outputs
+	if te.Transaction != nil {
+		outputs = te.Transaction.Outputs()
+	}
+	for _, outputList := range [][]ledger.TransactionOutput{outputs, te.ResolvedInputs} {
+		for _, output := range outputList {
+			if c.filterSet.datumFilter.requireInlineDatum && output.Datum() != nil {
+				return true
+			}
+			datumHash := output.DatumHash()
+			if datumHash == nil {
+				continue
+			}
+			if c.filterSet.datumFilter.datumHashes[datumHash.String()] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchScriptFilter returns true if any of the transaction outputs or resolved inputs carries a reference script
+// with a hash matching one of the configured hashes, or carries any reference script when one is required
+func (c *ChainSync) matchScriptFilter(te chainsync.TransactionEvent) bool {
+	outputs := te.Outputs
+	if te.Transaction != nil {
+		outputs = te.Transaction.Outputs()
+	}
+	for _, outputList := range [][]ledger.TransactionOutput{outputs, te.ResolvedInputs} {
+		for _, output := range outputList {
+			scriptRef, ok := chainsync.OutputScriptRef(output)
+			if !ok {
+				continue
+			}
+			if c.filterSet.scriptFilter.requireScriptRef {
+				return true
+			}
+			if c.filterSet.scriptFilter.scriptHashes[scriptRef.ScriptHash] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stakeAddressString returns the stake address for the specified full address, or an empty string if the
+// address cannot be parsed or has no stake part
+func stakeAddressString(address string) string {
+	addr, err := ledger.NewAddress(address)
+	if err != nil {
+		return ""
+	}
+	stakeAddr := addr.StakeAddress()
+	if stakeAddr == nil {
+		return ""
+	}
+	return stakeAddr.String()
+}
+
+// matchFeeFilter returns true if the transaction fee falls within the configured fee range (inclusive)
+func (c *ChainSync) matchFeeFilter(te chainsync.TransactionEvent) bool {
+	if te.Fee < c.filterSet.feeFilter.minFee {
+		return false
+	}
+	if c.filterSet.feeFilter.maxFee > 0 && te.Fee > c.filterSet.feeFilter.maxFee {
+		return false
+	}
+	return true
+}
+
+// matchOutputAmountFilter returns true if the lovelace amount of any transaction output falls within the configured
+// range (inclusive). The raw transaction outputs are checked when available
+func (c *ChainSync) matchOutputAmountFilter(te chainsync.TransactionEvent) bool {
+	outputs := te.Outputs
+	if te.Transaction != nil {
+		outputs = te.Transaction.Outputs()
+	}
+	for _, output := range outputs {
+		amount := output.Amount()
+		if amount < c.filterSet.outputAmountFilter.minAmount {
+			continue
+		}
+		if c.filterSet.outputAmountFilter.maxAmount > 0 && amount > c.filterSet.outputAmountFilter.maxAmount {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// transactionSize returns the size in bytes of the transaction's original CBOR, preferring the CBOR included in the
+// event. It returns false if neither CBOR source is available
+func transactionSize(te chainsync.TransactionEvent) (uint, bool) {
+	if len(te.TransactionCbor) > 0 {
+		return uint(len(te.TransactionCbor)), true
+	}
+	if te.Transaction != nil && len(te.Transaction.Cbor()) > 0 {
+		return uint(len(te.Transaction.Cbor())), true
+	}
+	return 0, false
+}
+
+// hasPaymentKeyHash checks whether the payment part of an address is a key hash, rather than a script hash or
+// missing entirely
+func hasPaymentKeyHash(addr ledger.Address) bool {
+	switch addr.Bytes()[0] >> 4 {
+	case ledger.AddressTypeKeyKey,
+		ledger.AddressTypeKeyScript,
+		ledger.AddressTypeKeyPointer,
+		ledger.AddressTypeKeyNone:
+		return true
+	}
+	return false
+}
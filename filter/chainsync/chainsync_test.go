@@ -0,0 +1,1063 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	filter_chainsync "github.com/blinklabs-io/adder/filter/chainsync"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+type mockTransaction struct {
+	ledger.Transaction
+	cbor        []byte
+	metadata    *cbor.LazyValue
+	withdrawals map[*ledger.Address]uint64
+}
+
+func (t mockTransaction) Cbor() []byte                            { return t.cbor }
+func (t mockTransaction) Metadata() *cbor.LazyValue               { return t.metadata }
+func (t mockTransaction) Withdrawals() map[*ledger.Address]uint64 { return t.withdrawals }
+
+type mockOutput struct {
+	ledger.TransactionOutput
+	address   ledger.Address
+	assets    *ledger.MultiAsset[ledger.MultiAssetTypeOutput]
+	datum     *cbor.LazyValue
+	datumHash *ledger.Blake2b256
+	amount    uint64
+	cbor      []byte
+}
+
+func (o mockOutput) Address() ledger.Address { return o.address }
+func (o mockOutput) Assets() *ledger.MultiAsset[ledger.MultiAssetTypeOutput] {
+	return o.assets
+}
+func (o mockOutput) Datum() *cbor.LazyValue { return o.datum }
+func (o mockOutput) Amount() uint64         { return o.amount }
+func (o mockOutput) Cbor() []byte           { return o.cbor }
+func (o mockOutput) DatumHash() *ledger.Blake2b256 {
+	return o.datumHash
+}
+
+func newBaseAddress(t *testing.T, paymentByte byte, stakeByte byte) ledger.Address {
+	paymentHash := make([]byte, ledger.AddressHashSize)
+	stakeHash := make([]byte, ledger.AddressHashSize)
+	for i := range paymentHash {
+		paymentHash[i] = paymentByte
+		stakeHash[i] = stakeByte
+	}
+	addr, err := ledger.NewAddressFromParts(
+		ledger.AddressTypeKeyKey,
+		ledger.AddressNetworkMainnet,
+		paymentHash,
+		stakeHash,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating address: %s", err)
+	}
+	return addr
+}
+
+func newMetadata(t *testing.T, value any) *cbor.LazyValue {
+	data, err := cbor.Encode(value)
+	if err != nil {
+		t.Fatalf("unexpected error encoding CBOR: %s", err)
+	}
+	lv := &cbor.LazyValue{}
+	if err := lv.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("unexpected error decoding CBOR: %s", err)
+	}
+	return lv
+}
+
+func newTransactionEvent(metadata *cbor.LazyValue) event.Event {
+	return event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{},
+		chainsync.TransactionEvent{Metadata: metadata},
+	)
+}
+
+// receiveEvent returns the next event from the filter, or nil if none arrives before the timeout
+func receiveEvent(c *filter_chainsync.ChainSync) *event.Event {
+	select {
+	case evt := <-c.OutputChan():
+		return &evt
+	case <-time.After(100 * time.Millisecond):
+		return nil
+	}
+}
+
+func TestMetadataLabelFilter(t *testing.T) {
+	c := filter_chainsync.New(
+		filter_chainsync.WithMetadataLabels([]uint64{721}),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	// Transaction with label 721 passes without its metadata being decoded
+	matchMetadata := newMetadata(
+		t,
+		map[uint64]any{
+			674: map[string]any{"msg": []string{"hello"}},
+			721: map[string]any{"policy": map[string]any{"name": "nft"}},
+		},
+	)
+	c.InputChan() <- newTransactionEvent(matchMetadata)
+	evt := receiveEvent(c)
+	if assert.NotNil(t, evt) {
+		te := evt.Payload.(chainsync.TransactionEvent)
+		assert.Equal(t, matchMetadata, te.Metadata)
+	}
+	assert.Nil(t, matchMetadata.Value())
+	// Transaction without label 721 is dropped
+	c.InputChan() <- newTransactionEvent(
+		newMetadata(t, map[uint64]any{674: "hello"}),
+	)
+	assert.Nil(t, receiveEvent(c))
+	// Transaction without metadata is dropped
+	c.InputChan() <- newTransactionEvent(nil)
+	assert.Nil(t, receiveEvent(c))
+}
+
+func TestMetadataLabelFilterRawTransaction(t *testing.T) {
+	c := filter_chainsync.New(
+		filter_chainsync.WithMetadataLabels([]uint64{674}),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	// Metadata omitted from the event by a size cap is still matched from the raw transaction
+	c.InputChan() <- event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{},
+		chainsync.TransactionEvent{
+			Transaction: mockTransaction{
+				metadata: newMetadata(t, map[uint64]any{674: "hello"}),
+			},
+			MetadataTruncated: true,
+		},
+	)
+	assert.NotNil(t, receiveEvent(c))
+	// Raw transaction without metadata is dropped
+	c.InputChan() <- event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{},
+		chainsync.TransactionEvent{Transaction: mockTransaction{}},
+	)
+	assert.Nil(t, receiveEvent(c))
+}
+
+func TestMetadataLabelFilterEmpty(t *testing.T) {
+	c := filter_chainsync.New(
+		filter_chainsync.WithMetadataLabels([]uint64{}),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	// An empty label set doesn't filter anything
+	c.InputChan() <- newTransactionEvent(nil)
+	assert.NotNil(t, receiveEvent(c))
+}
+
+func TestTxSizeFilter(t *testing.T) {
+	c := filter_chainsync.New(
+		filter_chainsync.WithMinTxSize(8192),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	// Large transaction passes
+	largeTx := event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{},
+		chainsync.TransactionEvent{
+			Transaction: mockTransaction{cbor: make([]byte, 16000)},
+		},
+	)
+	c.InputChan() <- largeTx
+	assert.NotNil(t, receiveEvent(c))
+	// Small transaction is dropped
+	smallTx := event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{},
+		chainsync.TransactionEvent{
+			Transaction: mockTransaction{cbor: make([]byte, 300)},
+		},
+	)
+	c.InputChan() <- smallTx
+	assert.Nil(t, receiveEvent(c))
+}
+
+func TestTxSizeRangeFilter(t *testing.T) {
+	logger := &mockLogger{}
+	c := filter_chainsync.New(
+		filter_chainsync.WithLogger(logger),
+		filter_chainsync.WithTxSizeRange(1000, 5000),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	testDefs := []struct {
+		txEvent     chainsync.TransactionEvent
+		expectMatch bool
+	}{
+		// Included CBOR is preferred over the transaction CBOR
+		{txEvent: chainsync.TransactionEvent{TransactionCbor: make([]byte, 2000)}, expectMatch: true},
+		{txEvent: chainsync.TransactionEvent{TransactionCbor: make([]byte, 6000), Transaction: mockTransaction{cbor: make([]byte, 2000)}}},
+		{txEvent: chainsync.TransactionEvent{Transaction: mockTransaction{cbor: make([]byte, 500)}}},
+		{txEvent: chainsync.TransactionEvent{Transaction: mockTransaction{cbor: make([]byte, 5000)}}, expectMatch: true},
+		// Transactions without CBOR pass through
+		{txEvent: chainsync.TransactionEvent{}, expectMatch: true},
+		{txEvent: chainsync.TransactionEvent{Transaction: mockTransaction{}}, expectMatch: true},
+	}
+	for idx, testDef := range testDefs {
+		c.InputChan() <- event.New("chainsync.transaction", time.Now(), chainsync.TransactionContext{}, testDef.txEvent)
+		if testDef.expectMatch {
+			assert.NotNil(t, receiveEvent(c), "test %d", idx)
+		} else {
+			assert.Nil(t, receiveEvent(c), "test %d", idx)
+		}
+	}
+	// Only the first transaction without CBOR is logged
+	assert.Len(t, logger.warnMessages, 1)
+}
+
+func TestFeeRangeFilter(t *testing.T) {
+	testDefs := []struct {
+		name    string
+		minFee  uint64
+		maxFee  uint64
+		fee     uint64
+		matches bool
+	}{
+		{name: "below range", minFee: 200_000, maxFee: 500_000, fee: 199_999, matches: false},
+		{name: "lower bound", minFee: 200_000, maxFee: 500_000, fee: 200_000, matches: true},
+		{name: "upper bound", minFee: 200_000, maxFee: 500_000, fee: 500_000, matches: true},
+		{name: "above range", minFee: 200_000, maxFee: 500_000, fee: 500_001, matches: false},
+		{name: "no upper bound", minFee: 200_000, maxFee: 0, fee: 50_000_000, matches: true},
+	}
+	for _, testDef := range testDefs {
+		t.Run(testDef.name, func(t *testing.T) {
+			c := filter_chainsync.New(
+				filter_chainsync.WithFeeRange(testDef.minFee, testDef.maxFee),
+			)
+			assert.NoError(t, c.Start())
+			defer func() {
+				_ = c.Stop()
+			}()
+			c.InputChan() <- event.New(
+				"chainsync.transaction",
+				time.Now(),
+				chainsync.TransactionContext{},
+				chainsync.TransactionEvent{Fee: testDef.fee},
+			)
+			evt := receiveEvent(c)
+			if testDef.matches {
+				assert.NotNil(t, evt)
+			} else {
+				assert.Nil(t, evt)
+			}
+		})
+	}
+}
+
+func TestFeeRangeFilterCombined(t *testing.T) {
+	c := filter_chainsync.New(
+		filter_chainsync.WithFeeRange(200_000, 0),
+		filter_chainsync.WithMetadataLabels([]uint64{721}),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	// Fee matches but metadata label doesn't
+	c.InputChan() <- event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{},
+		chainsync.TransactionEvent{
+			Fee:      300_000,
+			Metadata: newMetadata(t, map[uint64]any{674: "hello"}),
+		},
+	)
+	assert.Nil(t, receiveEvent(c))
+	// Both fee and metadata label match
+	c.InputChan() <- event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{},
+		chainsync.TransactionEvent{
+			Fee:      300_000,
+			Metadata: newMetadata(t, map[uint64]any{721: "nft"}),
+		},
+	)
+	assert.NotNil(t, receiveEvent(c))
+}
+
+func TestSlotRangeFilter(t *testing.T) {
+	c := filter_chainsync.New(
+		filter_chainsync.WithSlotRange(1000, 2000),
+		filter_chainsync.WithEras([]string{"conway"}),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	testDefs := []struct {
+		evt     event.Event
+		matched bool
+	}{
+		{
+			evt: event.New(
+				"chainsync.block",
+				time.Now(),
+				chainsync.BlockContext{SlotNumber: 1000, Era: "Conway"},
+				chainsync.BlockEvent{},
+			),
+			matched: true,
+		},
+		{
+			evt: event.New(
+				"chainsync.block",
+				time.Now(),
+				chainsync.BlockContext{SlotNumber: 999, Era: "Conway"},
+				chainsync.BlockEvent{},
+			),
+		},
+		{
+			evt: event.New(
+				"chainsync.transaction",
+				time.Now(),
+				chainsync.TransactionContext{SlotNumber: 2000, Era: "Conway"},
+				chainsync.TransactionEvent{},
+			),
+			matched: true,
+		},
+		{
+			// In range, but not matching the era filter
+			evt: event.New(
+				"chainsync.transaction",
+				time.Now(),
+				chainsync.TransactionContext{SlotNumber: 1500, Era: "Babbage"},
+				chainsync.TransactionEvent{},
+			),
+		},
+		{
+			evt: event.New(
+				"chainsync.certificate",
+				time.Now(),
+				chainsync.CertificateContext{SlotNumber: 2001},
+				nil,
+			),
+		},
+		{
+			// Events without a slot in their context aren't filtered
+			evt: event.New(
+				"chainsync.rollback",
+				time.Now(),
+				nil,
+				chainsync.RollbackEvent{SlotNumber: 5000},
+			),
+			matched: true,
+		},
+	}
+	for _, testDef := range testDefs {
+		c.InputChan() <- testDef.evt
+		evt := receiveEvent(c)
+		if testDef.matched {
+			assert.NotNil(t, evt, "expected %s event to match", testDef.evt.Type)
+		} else {
+			assert.Nil(t, evt, "expected %s event to be dropped", testDef.evt.Type)
+		}
+	}
+}
+
+func TestOutputAmountRangeFilter(t *testing.T) {
+	testDefs := []struct {
+		name      string
+		minAmount uint64
+		maxAmount uint64
+		amounts   []uint64
+		matches   bool
+	}{
+		{name: "below range", minAmount: 1_000_000_000, maxAmount: 5_000_000_000, amounts: []uint64{2_000_000, 999_999_999}, matches: false},
+		{name: "one output in range", minAmount: 1_000_000_000, maxAmount: 5_000_000_000, amounts: []uint64{2_000_000, 1_000_000_000}, matches: true},
+		{name: "upper bound", minAmount: 1_000_000_000, maxAmount: 5_000_000_000, amounts: []uint64{5_000_000_000}, matches: true},
+		{name: "above range", minAmount: 1_000_000_000, maxAmount: 5_000_000_000, amounts: []uint64{5_000_000_001}, matches: false},
+		{name: "no upper bound", minAmount: 1_000_000_000, maxAmount: 0, amounts: []uint64{45_000_000_000_000_000}, matches: true},
+		{name: "no outputs", minAmount: 0, maxAmount: 0, amounts: nil, matches: false},
+	}
+	for _, testDef := range testDefs {
+		t.Run(testDef.name, func(t *testing.T) {
+			c := filter_chainsync.New(
+				filter_chainsync.WithOutputAmountRange(testDef.minAmount, testDef.maxAmount),
+			)
+			assert.NoError(t, c.Start())
+			defer func() {
+				_ = c.Stop()
+			}()
+			outputs := []ledger.TransactionOutput{}
+			for _, amount := range testDef.amounts {
+				outputs = append(outputs, mockOutput{amount: amount})
+			}
+			c.InputChan() <- event.New(
+				"chainsync.transaction",
+				time.Now(),
+				chainsync.TransactionContext{},
+				chainsync.TransactionEvent{Outputs: outputs},
+			)
+			evt := receiveEvent(c)
+			if testDef.matches {
+				assert.NotNil(t, evt)
+			} else {
+				assert.Nil(t, evt)
+			}
+		})
+	}
+}
+
+func TestOutputAmountRangeFilterCombined(t *testing.T) {
+	c := filter_chainsync.New(
+		filter_chainsync.WithOutputAmountRange(1_000_000_000, 0),
+		filter_chainsync.WithFeeRange(200_000, 0),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	outputs := []ledger.TransactionOutput{mockOutput{amount: 2_000_000_000}}
+	// Output amount matches but fee doesn't
+	c.InputChan() <- event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{},
+		chainsync.TransactionEvent{Fee: 170_000, Outputs: outputs},
+	)
+	assert.Nil(t, receiveEvent(c))
+	// Both output amount and fee match
+	c.InputChan() <- event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{},
+		chainsync.TransactionEvent{Fee: 300_000, Outputs: outputs},
+	)
+	assert.NotNil(t, receiveEvent(c))
+}
+
+func TestAddressStakeMatch(t *testing.T) {
+	filterAddr := newBaseAddress(t, 0x01, 0xaa)
+	// Different payment part, same stake part
+	sharedStakeAddr := newBaseAddress(t, 0x02, 0xaa)
+	// Different payment and stake parts
+	otherAddr := newBaseAddress(t, 0x03, 0xbb)
+	newOutputEvent := func(addr ledger.Address) event.Event {
+		return event.New(
+			"chainsync.transaction",
+			time.Now(),
+			chainsync.TransactionContext{},
+			chainsync.TransactionEvent{
+				Outputs: []ledger.TransactionOutput{
+					mockOutput{address: addr},
+				},
+			},
+		)
+	}
+	testDefs := []struct {
+		name        string
+		stakeMatch  bool
+		addr        ledger.Address
+		expectMatch bool
+	}{
+		{name: "exact match without mode", addr: filterAddr, expectMatch: true},
+		{name: "shared stake without mode", addr: sharedStakeAddr, expectMatch: false},
+		{name: "exact match with mode", stakeMatch: true, addr: filterAddr, expectMatch: true},
+		{name: "shared stake with mode", stakeMatch: true, addr: sharedStakeAddr, expectMatch: true},
+		{name: "different stake with mode", stakeMatch: true, addr: otherAddr, expectMatch: false},
+	}
+	for _, testDef := range testDefs {
+		t.Run(testDef.name, func(t *testing.T) {
+			c := filter_chainsync.New(
+				filter_chainsync.WithAddresses([]string{filterAddr.String()}),
+				filter_chainsync.WithAddressStakeMatch(testDef.stakeMatch),
+			)
+			assert.NoError(t, c.Start())
+			defer func() {
+				_ = c.Stop()
+			}()
+			c.InputChan() <- newOutputEvent(testDef.addr)
+			evt := receiveEvent(c)
+			if testDef.expectMatch {
+				assert.NotNil(t, evt)
+			} else {
+				assert.Nil(t, evt)
+			}
+		})
+	}
+}
+
+// mockLogger records the messages logged at info and warning level
+type mockLogger struct {
+	infoMessages []string
+	warnMessages []string
+}
+
+func (l *mockLogger) Infof(msg string, args ...any) {
+	l.infoMessages = append(l.infoMessages, fmt.Sprintf(msg, args...))
+}
+func (l *mockLogger) Warnf(msg string, args ...any) {
+	l.warnMessages = append(l.warnMessages, fmt.Sprintf(msg, args...))
+}
+func (l *mockLogger) Debugf(string, ...any) {}
+func (l *mockLogger) Errorf(string, ...any) {}
+func (l *mockLogger) Fatalf(string, ...any) {}
+
+func TestFilterSummaryLog(t *testing.T) {
+	logger := &mockLogger{}
+	c := filter_chainsync.New(
+		filter_chainsync.WithLogger(logger),
+		filter_chainsync.WithAddresses([]string{"addr1", "addr2"}),
+		filter_chainsync.WithPolicies([]string{"policy1"}),
+		filter_chainsync.WithPoolIds([]string{"pool1", "pool2", "pool3"}),
+		filter_chainsync.WithAddressStakeMatch(true),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	assert.Equal(
+		t,
+		[]string{
+			"active filters: addresses=2, policies=1, assets=0, pools=3, eras=0, metadataLabels=0, datumHashes=0, inlineDatum=false, scriptHashes=0, scriptRef=false, stakeCredentials=0, rewardAccounts=0, paymentKeyHashes=0, feeRange=false, outputAmountRange=false, txSizeRange=false, slotRange=false, addressStakeMatch=true",
+		},
+		logger.infoMessages,
+	)
+}
+
+func TestEraFilter(t *testing.T) {
+	c := filter_chainsync.New(
+		filter_chainsync.WithEras([]string{"conway"}),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	testDefs := []struct {
+		evt     event.Event
+		matched bool
+	}{
+		{
+			evt: event.New(
+				"chainsync.block",
+				time.Now(),
+				chainsync.BlockContext{Era: "Conway"},
+				chainsync.BlockEvent{},
+			),
+			matched: true,
+		},
+		{
+			evt: event.New(
+				"chainsync.block",
+				time.Now(),
+				chainsync.BlockContext{Era: "Babbage"},
+				chainsync.BlockEvent{},
+			),
+		},
+		{
+			evt: event.New(
+				"chainsync.transaction",
+				time.Now(),
+				chainsync.TransactionContext{Era: "Conway"},
+				chainsync.TransactionEvent{},
+			),
+			matched: true,
+		},
+		{
+			evt: event.New(
+				"chainsync.transaction",
+				time.Now(),
+				chainsync.TransactionContext{Era: "Shelley"},
+				chainsync.TransactionEvent{},
+			),
+		},
+		{
+			// Other event types aren't filtered
+			evt: event.New(
+				"chainsync.rollback",
+				time.Now(),
+				nil,
+				chainsync.RollbackEvent{},
+			),
+			matched: true,
+		},
+	}
+	for _, testDef := range testDefs {
+		c.InputChan() <- testDef.evt
+		evt := receiveEvent(c)
+		if testDef.matched {
+			assert.NotNil(t, evt, "expected %s event to match", testDef.evt.Type)
+		} else {
+			assert.Nil(t, evt, "expected %s event to be dropped", testDef.evt.Type)
+		}
+	}
+}
+
+func TestDatumFilter(t *testing.T) {
+	datumHash := ledger.Blake2b256{0x01, 0x02, 0x03}
+	otherDatumHash := ledger.Blake2b256{0x04, 0x05, 0x06}
+	inlineDatum := newMetadata(t, 42)
+	newDatumEvent := func(outputs []ledger.TransactionOutput, resolvedInputs []ledger.TransactionOutput) event.Event {
+		return event.New(
+			"chainsync.transaction",
+			time.Now(),
+			chainsync.TransactionContext{},
+			chainsync.TransactionEvent{
+				Outputs:        outputs,
+				ResolvedInputs: resolvedInputs,
+			},
+		)
+	}
+	testDefs := []struct {
+		name           string
+		options        []filter_chainsync.ChainSyncOptionFunc
+		outputs        []ledger.TransactionOutput
+		resolvedInputs []ledger.TransactionOutput
+		expectMatch    bool
+	}{
+		{
+			name:        "datum hash output match",
+			options:     []filter_chainsync.ChainSyncOptionFunc{filter_chainsync.WithDatumHashes([]string{datumHash.String()})},
+			outputs:     []ledger.TransactionOutput{mockOutput{}, mockOutput{datumHash: &datumHash}},
+			expectMatch: true,
+		},
+		{
+			name:           "datum hash resolved input match",
+			options:        []filter_chainsync.ChainSyncOptionFunc{filter_chainsync.WithDatumHashes([]string{datumHash.String()})},
+			outputs:        []ledger.TransactionOutput{mockOutput{}},
+			resolvedInputs: []ledger.TransactionOutput{mockOutput{datumHash: &datumHash}},
+			expectMatch:    true,
+		},
+		{
+			name:    "datum hash mismatch",
+			options: []filter_chainsync.ChainSyncOptionFunc{filter_chainsync.WithDatumHashes([]string{datumHash.String()})},
+			outputs: []ledger.TransactionOutput{mockOutput{datumHash: &otherDatumHash}, mockOutput{datum: inlineDatum}},
+		},
+		{
+			name:        "inline datum match",
+			options:     []filter_chainsync.ChainSyncOptionFunc{filter_chainsync.WithRequireInlineDatum(true)},
+			outputs:     []ledger.TransactionOutput{mockOutput{}, mockOutput{datum: inlineDatum}},
+			expectMatch: true,
+		},
+		{
+			name:    "inline datum missing",
+			options: []filter_chainsync.ChainSyncOptionFunc{filter_chainsync.WithRequireInlineDatum(true)},
+			outputs: []ledger.TransactionOutput{mockOutput{datumHash: &datumHash}},
+		},
+	}
+	for _, testDef := range testDefs {
+		t.Run(testDef.name, func(t *testing.T) {
+			c := filter_chainsync.New(testDef.options...)
+			assert.NoError(t, c.Start())
+			defer func() {
+				_ = c.Stop()
+			}()
+			c.InputChan() <- newDatumEvent(testDef.outputs, testDef.resolvedInputs)
+			evt := receiveEvent(c)
+			if testDef.expectMatch {
+				assert.NotNil(t, evt)
+			} else {
+				assert.Nil(t, evt)
+			}
+		})
+	}
+}
+
+// newScriptRefOutput returns an output with a reference script of the specified type, along with the script hash
+func newScriptRefOutput(t *testing.T, scriptType uint64, script any) (mockOutput, string) {
+	scriptCbor, err := cbor.Encode([]any{scriptType, script})
+	if err != nil {
+		t.Fatalf("unexpected error encoding CBOR: %s", err)
+	}
+	outputCbor, err := cbor.Encode(
+		map[uint64]any{
+			0: newBaseAddress(t, 0x01, 0x02).Bytes(),
+			1: uint64(1000000),
+			3: cbor.Tag{Number: 24, Content: scriptCbor},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error encoding CBOR: %s", err)
+	}
+	// Native scripts are hashed from their CBOR encoding, and Plutus scripts from their raw bytes
+	scriptData, ok := script.([]byte)
+	if !ok {
+		scriptData, err = cbor.Encode(script)
+		if err != nil {
+			t.Fatalf("unexpected error encoding CBOR: %s", err)
+		}
+	}
+	hash, err := blake2b.New(28, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating hash: %s", err)
+	}
+	hash.Write(append([]byte{byte(scriptType)}, scriptData...))
+	return mockOutput{cbor: outputCbor}, hex.EncodeToString(hash.Sum(nil))
+}
+
+func TestScriptFilter(t *testing.T) {
+	// A native script requiring all of an empty list of scripts, and an arbitrary Plutus V2 script
+	nativeOutput, nativeHash := newScriptRefOutput(t, 0, []any{1, []any{}})
+	plutusOutput, plutusHash := newScriptRefOutput(t, 2, []byte{0x46, 0x01, 0x00, 0x00, 0x22, 0x49, 0x01})
+	legacyOutputCbor, err := cbor.Encode([]any{newBaseAddress(t, 0x01, 0x02).Bytes(), uint64(1000000)})
+	if err != nil {
+		t.Fatalf("unexpected error encoding CBOR: %s", err)
+	}
+	// Outputs without a reference script, including an output without any CBOR
+	plainOutputs := []ledger.TransactionOutput{mockOutput{}, mockOutput{cbor: legacyOutputCbor}}
+	testDefs := []struct {
+		name           string
+		options        []filter_chainsync.ChainSyncOptionFunc
+		outputs        []ledger.TransactionOutput
+		resolvedInputs []ledger.TransactionOutput
+		expectMatch    bool
+	}{
+		{
+			name:        "native script hash match",
+			options:     []filter_chainsync.ChainSyncOptionFunc{filter_chainsync.WithScriptHashes([]string{nativeHash})},
+			outputs:     append(plainOutputs, nativeOutput),
+			expectMatch: true,
+		},
+		{
+			name:           "plutus script hash resolved input match",
+			options:        []filter_chainsync.ChainSyncOptionFunc{filter_chainsync.WithScriptHashes([]string{strings.ToUpper(plutusHash)})},
+			outputs:        plainOutputs,
+			resolvedInputs: []ledger.TransactionOutput{plutusOutput},
+			expectMatch:    true,
+		},
+		{
+			name:    "script hash mismatch",
+			options: []filter_chainsync.ChainSyncOptionFunc{filter_chainsync.WithScriptHashes([]string{plutusHash})},
+			outputs: append(plainOutputs, nativeOutput),
+		},
+		{
+			name:        "any script ref match",
+			options:     []filter_chainsync.ChainSyncOptionFunc{filter_chainsync.WithRequireScriptRef(true)},
+			outputs:     append(plainOutputs, plutusOutput),
+			expectMatch: true,
+		},
+		{
+			name:    "script ref missing",
+			options: []filter_chainsync.ChainSyncOptionFunc{filter_chainsync.WithRequireScriptRef(true)},
+			outputs: plainOutputs,
+		},
+	}
+	for _, testDef := range testDefs {
+		t.Run(testDef.name, func(t *testing.T) {
+			c := filter_chainsync.New(testDef.options...)
+			assert.NoError(t, c.Start())
+			defer func() {
+				_ = c.Stop()
+			}()
+			c.InputChan() <- event.New(
+				"chainsync.transaction",
+				time.Now(),
+				chainsync.TransactionContext{},
+				chainsync.TransactionEvent{
+					Outputs:        testDef.outputs,
+					ResolvedInputs: testDef.resolvedInputs,
+				},
+			)
+			evt := receiveEvent(c)
+			if testDef.expectMatch {
+				assert.NotNil(t, evt)
+			} else {
+				assert.Nil(t, evt)
+			}
+		})
+	}
+}
+
+func newAssets(
+	t *testing.T,
+	policyId ledger.Blake2b224,
+	assetName string,
+	quantity uint64,
+) *ledger.MultiAsset[ledger.MultiAssetTypeOutput] {
+	data, err := cbor.Encode(
+		map[ledger.Blake2b224]map[cbor.ByteString]uint64{
+			policyId: {cbor.NewByteString([]byte(assetName)): quantity},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error encoding CBOR: %s", err)
+	}
+	assets := &ledger.MultiAsset[ledger.MultiAssetTypeOutput]{}
+	if err := assets.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("unexpected error decoding CBOR: %s", err)
+	}
+	return assets
+}
+
+func TestAssetMinQuantityFilter(t *testing.T) {
+	policyId := ledger.Blake2b224{0x01}
+	otherPolicyId := ledger.Blake2b224{0x02}
+	fingerprint := ledger.NewAssetFingerprint(policyId.Bytes(), []byte("token")).String()
+	otherFingerprint := ledger.NewAssetFingerprint(otherPolicyId.Bytes(), []byte("token")).String()
+	c := filter_chainsync.New(
+		filter_chainsync.WithAssetFingerprints([]string{otherFingerprint}),
+		filter_chainsync.WithAssetMinQuantity(fingerprint, 100),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	testDefs := []struct {
+		name    string
+		outputs []ledger.TransactionOutput
+		matched bool
+	}{
+		{
+			name:    "below minimum",
+			outputs: []ledger.TransactionOutput{mockOutput{assets: newAssets(t, policyId, "token", 99)}},
+		},
+		{
+			name:    "at minimum",
+			outputs: []ledger.TransactionOutput{mockOutput{assets: newAssets(t, policyId, "token", 100)}},
+			matched: true,
+		},
+		{
+			name: "minimum across outputs",
+			outputs: []ledger.TransactionOutput{
+				mockOutput{assets: newAssets(t, policyId, "token", 60)},
+				mockOutput{assets: newAssets(t, policyId, "token", 40)},
+			},
+			matched: true,
+		},
+		{
+			// Assets without a minimum match any quantity
+			name:    "no minimum",
+			outputs: []ledger.TransactionOutput{mockOutput{assets: newAssets(t, otherPolicyId, "token", 1)}},
+			matched: true,
+		},
+	}
+	for _, testDef := range testDefs {
+		c.InputChan() <- event.New(
+			"chainsync.transaction",
+			time.Now(),
+			chainsync.TransactionContext{},
+			chainsync.TransactionEvent{Outputs: testDef.outputs},
+		)
+		evt := receiveEvent(c)
+		if testDef.matched {
+			assert.NotNil(t, evt, testDef.name)
+		} else {
+			assert.Nil(t, evt, testDef.name)
+		}
+	}
+}
+
+func TestAddressNetworkMismatch(t *testing.T) {
+	mainnetAddr := "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x"
+	testnetAddr := "addr_test1qz2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgs68faae"
+	testDefs := []struct {
+		network       string
+		expectedWarns []string
+	}{
+		{network: "preview", expectedWarns: []string{mainnetAddr}},
+		{network: "mainnet", expectedWarns: []string{testnetAddr}},
+		// No network configured disables the check
+		{network: ""},
+	}
+	for _, testDef := range testDefs {
+		logger := &mockLogger{}
+		c := filter_chainsync.New(
+			filter_chainsync.WithLogger(logger),
+			filter_chainsync.WithNetwork(testDef.network),
+			filter_chainsync.WithAddresses([]string{mainnetAddr, testnetAddr}),
+		)
+		assert.NoError(t, c.Start())
+		assert.Len(t, logger.warnMessages, len(testDef.expectedWarns), "network: %s", testDef.network)
+		for idx, msg := range logger.warnMessages {
+			assert.Contains(t, msg, testDef.expectedWarns[idx])
+			assert.Contains(t, msg, "doesn't match network "+testDef.network)
+		}
+		_ = c.Stop()
+	}
+}
+
+func TestStakeCredentialFilter(t *testing.T) {
+	c := filter_chainsync.New(
+		filter_chainsync.WithStakeCredentials([]string{"0102"}),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	for _, stakeCredential := range []string{"0102", "0304"} {
+		c.InputChan() <- event.New(
+			"chainsync.stake_registration",
+			time.Now(),
+			chainsync.CertificateContext{},
+			chainsync.StakeRegistrationEvent{StakeCredential: stakeCredential},
+		)
+	}
+	evt := receiveEvent(c)
+	if assert.NotNil(t, evt) {
+		assert.Equal(t, "0102", evt.Payload.(chainsync.StakeRegistrationEvent).StakeCredential)
+	}
+	assert.Nil(t, receiveEvent(c))
+}
+
+func TestPoolRewardActivityFilter(t *testing.T) {
+	watchedAccount := "stake1uyehkck0lajq8gr28t9uxnuvgcqrc6070x3k9r8048z8y5gh6ffgw"
+	otherAccount := "stake_test1uqevw2xnsc0pvn9t9r9c7qryfqfeerchgrlm3ea2nefr9hqp8n5xl"
+	c := filter_chainsync.New(
+		filter_chainsync.WithPoolRewardActivity([]string{watchedAccount}),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	for _, rewardAccount := range []string{otherAccount, watchedAccount} {
+		addr, err := ledger.NewAddress(rewardAccount)
+		if !assert.NoError(t, err) {
+			return
+		}
+		c.InputChan() <- event.New(
+			"chainsync.transaction",
+			time.Now(),
+			chainsync.TransactionContext{TransactionHash: rewardAccount},
+			chainsync.TransactionEvent{
+				Transaction: mockTransaction{
+					withdrawals: map[*ledger.Address]uint64{&addr: 1000000},
+				},
+			},
+		)
+	}
+	// Transactions without withdrawals don't match
+	c.InputChan() <- event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{},
+		chainsync.TransactionEvent{Transaction: mockTransaction{}},
+	)
+	evt := receiveEvent(c)
+	if assert.NotNil(t, evt) {
+		assert.Equal(t, watchedAccount, evt.Context.(chainsync.TransactionContext).TransactionHash)
+	}
+	assert.Nil(t, receiveEvent(c))
+}
+
+func TestPaymentKeyHashFilter(t *testing.T) {
+	filterAddr := newBaseAddress(t, 0x01, 0xaa)
+	// Same payment part, different stake part
+	otherStakeAddr := newBaseAddress(t, 0x01, 0xbb)
+	// Different payment part
+	otherPaymentAddr := newBaseAddress(t, 0x02, 0xaa)
+	otherPaymentAddr2 := newBaseAddress(t, 0x03, 0xaa)
+	// Script address with the same hash as the payment part
+	scriptHash := make([]byte, ledger.AddressHashSize)
+	for i := range scriptHash {
+		scriptHash[i] = 0x01
+	}
+	scriptAddr, err := ledger.NewAddressFromParts(
+		ledger.AddressTypeScriptNone,
+		ledger.AddressNetworkMainnet,
+		scriptHash,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating address: %s", err)
+	}
+	paymentKeyHash := filterAddr.PaymentKeyHash()
+	newOutputEvent := func(addr ledger.Address) event.Event {
+		return event.New(
+			"chainsync.transaction",
+			time.Now(),
+			chainsync.TransactionContext{},
+			chainsync.TransactionEvent{
+				Outputs: []ledger.TransactionOutput{
+					mockOutput{address: addr},
+				},
+			},
+		)
+	}
+	testDefs := []struct {
+		name        string
+		addresses   []string
+		addr        ledger.Address
+		expectMatch bool
+	}{
+		{name: "same payment part", addr: filterAddr, expectMatch: true},
+		{name: "different stake part", addr: otherStakeAddr, expectMatch: true},
+		{name: "different payment part", addr: otherPaymentAddr, expectMatch: false},
+		{name: "script address", addr: scriptAddr, expectMatch: false},
+		{
+			name:        "full address filter match",
+			addresses:   []string{otherPaymentAddr.String()},
+			addr:        otherPaymentAddr,
+			expectMatch: true,
+		},
+		{
+			name:        "neither filter matches",
+			addresses:   []string{otherPaymentAddr.String()},
+			addr:        otherPaymentAddr2,
+			expectMatch: false,
+		},
+	}
+	for _, testDef := range testDefs {
+		t.Run(testDef.name, func(t *testing.T) {
+			options := []filter_chainsync.ChainSyncOptionFunc{
+				filter_chainsync.WithPaymentKeyHashes([]string{paymentKeyHash.String()}),
+			}
+			if testDef.addresses != nil {
+				options = append(options, filter_chainsync.WithAddresses(testDef.addresses))
+			}
+			c := filter_chainsync.New(options...)
+			assert.NoError(t, c.Start())
+			defer func() {
+				_ = c.Stop()
+			}()
+			c.InputChan() <- newOutputEvent(testDef.addr)
+			evt := receiveEvent(c)
+			if testDef.expectMatch {
+				assert.NotNil(t, evt)
+			} else {
+				assert.Nil(t, evt)
+			}
+		})
+	}
+}
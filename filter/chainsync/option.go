@@ -14,7 +14,13 @@
 
 package chainsync
 
-import "github.com/blinklabs-io/adder/plugin"
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/blinklabs-io/adder/plugin"
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
 
 type ChainSyncOptionFunc func(*ChainSync)
 
@@ -25,6 +31,14 @@ func WithLogger(logger plugin.Logger) ChainSyncOptionFunc {
 	}
 }
 
+// WithNetwork specifies the well-known network name, such as "mainnet" or "preview". When set, filter addresses
+// with a bech32 prefix for a different network cause a warning on startup
+func WithNetwork(network string) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.network = network
+	}
+}
+
 // WithAddresses specfies the address to filter on
 func WithAddresses(addresses []string) ChainSyncOptionFunc {
 	return func(c *ChainSync) {
@@ -32,6 +46,13 @@ func WithAddresses(addresses []string) ChainSyncOptionFunc {
 	}
 }
 
+// WithAddressStakeMatch specifies whether a full address filter also matches any address with the same stake part
+func WithAddressStakeMatch(addressStakeMatch bool) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.addressStakeMatch = addressStakeMatch
+	}
+}
+
 // WithAssetFingerprints specifies the asset fingerprint (asset1xxx) to filter on
 func WithAssetFingerprints(assetFingerprints []string) ChainSyncOptionFunc {
 	return func(c *ChainSync) {
@@ -39,6 +60,24 @@ func WithAssetFingerprints(assetFingerprints []string) ChainSyncOptionFunc {
 	}
 }
 
+// WithAssetMinQuantity specifies the minimum quantity of the asset with the specified fingerprint (asset1xxx) that
+// a transaction's outputs must contain for the asset filter to match. The fingerprint is added to the asset filter
+// if not already present
+func WithAssetMinQuantity(fingerprint string, min uint64) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		if c.filterSet.assetMinQuantity == nil {
+			c.filterSet.assetMinQuantity = make(map[string]uint64)
+		}
+		c.filterSet.assetMinQuantity[fingerprint] = min
+		for _, assetFingerprint := range c.filterAssetFingerprints {
+			if assetFingerprint == fingerprint {
+				return
+			}
+		}
+		c.filterAssetFingerprints = append(c.filterAssetFingerprints, fingerprint)
+	}
+}
+
 // WithPolicies specfies the address to filter on
 func WithPolicies(policyIds []string) ChainSyncOptionFunc {
 	return func(c *ChainSync) {
@@ -52,3 +91,160 @@ func WithPoolIds(poolIds []string) ChainSyncOptionFunc {
 		c.filterPoolIds = poolIds[:]
 	}
 }
+
+// WithMetadataLabels specifies the transaction metadata labels to filter on
+func WithMetadataLabels(metadataLabels []uint64) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterSet.hasMetadataFilter = len(metadataLabels) > 0
+		c.filterSet.metadataFilter = metadataLabels[:]
+	}
+}
+
+// WithEras specifies the era names to filter on, such as "Babbage" or "Conway". Names are matched case-insensitively
+func WithEras(eras []string) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterSet.hasEraFilter = len(eras) > 0
+		c.filterSet.eraFilter = make(map[string]bool, len(eras))
+		for _, era := range eras {
+			c.filterSet.eraFilter[strings.ToLower(era)] = true
+		}
+	}
+}
+
+// WithDatumHashes specifies the hex-encoded datum hashes of transaction outputs to filter on
+func WithDatumHashes(datumHashes []string) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterSet.datumFilter.datumHashes = make(map[string]bool, len(datumHashes))
+		for _, datumHash := range datumHashes {
+			c.filterSet.datumFilter.datumHashes[strings.ToLower(datumHash)] = true
+		}
+		c.filterSet.hasDatumFilter = len(datumHashes) > 0 || c.filterSet.datumFilter.requireInlineDatum
+	}
+}
+
+// WithRequireInlineDatum specifies whether to filter on transactions with an output carrying an inline datum.
+// When combined with WithDatumHashes, an output matching either condition is enough
+func WithRequireInlineDatum(requireInlineDatum bool) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterSet.datumFilter.requireInlineDatum = requireInlineDatum
+		c.filterSet.hasDatumFilter = requireInlineDatum || len(c.filterSet.datumFilter.datumHashes) > 0
+	}
+}
+
+// WithScriptHashes specifies the hex-encoded hashes of reference scripts carried by transaction outputs to filter on
+func WithScriptHashes(scriptHashes []string) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterSet.scriptFilter.scriptHashes = make(map[string]bool, len(scriptHashes))
+		for _, scriptHash := range scriptHashes {
+			c.filterSet.scriptFilter.scriptHashes[strings.ToLower(scriptHash)] = true
+		}
+		c.filterSet.hasScriptFilter = len(scriptHashes) > 0 || c.filterSet.scriptFilter.requireScriptRef
+	}
+}
+
+// WithRequireScriptRef specifies whether to filter on transactions with an output carrying any reference script.
+// When combined with WithScriptHashes, any reference script is enough to match
+func WithRequireScriptRef(requireScriptRef bool) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterSet.scriptFilter.requireScriptRef = requireScriptRef
+		c.filterSet.hasScriptFilter = requireScriptRef || len(c.filterSet.scriptFilter.scriptHashes) > 0
+	}
+}
+
+// WithStakeCredentials specifies the hex-encoded stake credential hashes to filter stake registration and
+// deregistration events on
+func WithStakeCredentials(stakeCredentials []string) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterSet.stakeCredentialFilter = make(map[string]bool, len(stakeCredentials))
+		for _, stakeCredential := range stakeCredentials {
+			c.filterSet.stakeCredentialFilter[strings.ToLower(stakeCredential)] = true
+		}
+	}
+}
+
+// WithPoolRewardActivity specifies the reward accounts (stake addresses) to filter transactions withdrawing rewards
+// on. Delegation isn't resolved from the chain, so this should be the set of reward accounts delegated to the
+// pool(s) of interest, as looked up from an external source such as a chain indexer
+func WithPoolRewardActivity(rewardAccounts []string) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterSet.rewardAccountFilter = make(map[string]bool, len(rewardAccounts))
+		for _, rewardAccount := range rewardAccounts {
+			c.filterSet.rewardAccountFilter[rewardAccount] = true
+		}
+	}
+}
+
+// WithPaymentKeyHashes specifies the hex-encoded payment key hashes to filter transaction outputs on, regardless of
+// the stake part of their address. This can be combined with WithAddresses, in which case a transaction matching
+// either is accepted. Values that aren't a valid 28-byte hash are ignored
+func WithPaymentKeyHashes(hashes []string) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterSet.paymentKeyHashFilter = make(map[ledger.Blake2b224]bool, len(hashes))
+		for _, hash := range hashes {
+			hashBytes, err := hex.DecodeString(strings.TrimSpace(hash))
+			if err != nil || len(hashBytes) != ledger.AddressHashSize {
+				continue
+			}
+			c.filterSet.paymentKeyHashFilter[ledger.NewBlake2b224(hashBytes)] = true
+		}
+	}
+}
+
+// WithMinTxSize specifies the minimum transaction size in bytes to filter on
+func WithMinTxSize(minTxSize uint) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterMinTxSize = minTxSize
+	}
+}
+
+// WithMaxTxSize specifies the maximum transaction size in bytes to filter on
+func WithMaxTxSize(maxTxSize uint) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterMaxTxSize = maxTxSize
+	}
+}
+
+// WithTxSizeRange specifies the transaction size range (inclusive) in bytes to filter on. A max of 0 means there is
+// no upper bound. Transactions without any CBOR available to measure are passed through
+func WithTxSizeRange(minSize int, maxSize int) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterMinTxSize = uint(max(minSize, 0))
+		c.filterMaxTxSize = uint(max(maxSize, 0))
+	}
+}
+
+// WithFeeRange specifies the transaction fee range (inclusive) in lovelace to filter on. A max of 0 means
+// there is no upper bound
+func WithFeeRange(min uint64, max uint64) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterSet.hasFeeFilter = true
+		c.filterSet.feeFilter = feeFilter{
+			minFee: min,
+			maxFee: max,
+		}
+	}
+}
+
+// WithSlotRange specifies the slot range (inclusive) to filter on. Block, transaction and certificate events
+// outside of the range are dropped, based on the slot in their context. A max of 0 means there is no upper bound
+func WithSlotRange(min uint64, max uint64) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterSet.hasSlotFilter = true
+		c.filterSet.slotFilter = slotFilter{
+			minSlot: min,
+			maxSlot: max,
+		}
+	}
+}
+
+// WithOutputAmountRange specifies the lovelace amount range (inclusive) to filter on. A transaction matches if any of
+// its outputs has an amount within the range. A max of 0 means there is no upper bound
+func WithOutputAmountRange(min uint64, max uint64) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.filterSet.hasOutputAmountFilter = true
+		c.filterSet.outputAmountFilter = outputAmountFilter{
+			minAmount: min,
+			maxAmount: max,
+		}
+	}
+}
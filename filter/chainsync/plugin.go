@@ -15,17 +15,40 @@
 package chainsync
 
 import (
+	"encoding/hex"
+	"strconv"
 	"strings"
 
 	"github.com/blinklabs-io/adder/internal/logging"
 	"github.com/blinklabs-io/adder/plugin"
+	"github.com/blinklabs-io/gouroboros/ledger"
 )
 
 var cmdlineOptions struct {
-	address  string
-	asset    string
-	policyId string
-	poolId   string
+	network            string
+	address            string
+	addressStakeMatch  bool
+	asset              string
+	assetMinQuantity   string
+	policyId           string
+	poolId             string
+	era                string
+	metadataLabel      string
+	datumHash          string
+	requireInlineDatum bool
+	scriptHash         string
+	requireScriptRef   bool
+	stakeCredential    string
+	rewardAccount      string
+	paymentKeyHash     string
+	minTxSize          uint
+	maxTxSize          uint
+	minFee             uint
+	maxFee             uint
+	minOutputAmount    uint
+	maxOutputAmount    uint
+	minSlot            uint
+	maxSlot            uint
 }
 
 func init() {
@@ -36,6 +59,15 @@ func init() {
 			Description:        "filters chainsync events",
 			NewFromOptionsFunc: NewFromCmdlineOptions,
 			Options: []plugin.PluginOption{
+				{
+					Name:         "network",
+					Type:         plugin.PluginOptionTypeString,
+					CustomEnvVar: "CARDANO_NETWORK",
+					Description:  "specifies the well-known network name used to check that filter addresses are for the right network",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.network),
+					CustomFlag:   "network",
+				},
 				{
 					Name:         "address",
 					Type:         plugin.PluginOptionTypeString,
@@ -44,6 +76,14 @@ func init() {
 					Dest:         &(cmdlineOptions.address),
 					CustomFlag:   "address",
 				},
+				{
+					Name:         "address-stake-match",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "also match addresses sharing the stake part of a full address filter",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.addressStakeMatch),
+					CustomFlag:   "address-stake-match",
+				},
 				{
 					Name:         "asset",
 					Type:         plugin.PluginOptionTypeString,
@@ -52,6 +92,14 @@ func init() {
 					Dest:         &(cmdlineOptions.asset),
 					CustomFlag:   "asset",
 				},
+				{
+					Name:         "asset-min-quantity",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the minimum quantity of matched assets in '<fingerprint>=<quantity>' format, separated by commas",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.assetMinQuantity),
+					CustomFlag:   "asset-min-quantity",
+				},
 				{
 					Name:         "policy",
 					Type:         plugin.PluginOptionTypeString,
@@ -68,6 +116,142 @@ func init() {
 					Dest:         &(cmdlineOptions.poolId),
 					CustomFlag:   "pool",
 				},
+				{
+					Name:         "era",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the era name(s) of blocks and transactions to filter on",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.era),
+					CustomFlag:   "era",
+				},
+				{
+					Name:         "metadata-label",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies transaction metadata label(s) to filter on",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.metadataLabel),
+					CustomFlag:   "metadata-label",
+				},
+				{
+					Name:         "datum-hash",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies transaction output datum hash(es) to filter on",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.datumHash),
+					CustomFlag:   "datum-hash",
+				},
+				{
+					Name:         "require-inline-datum",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "only match transactions with an output carrying an inline datum",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.requireInlineDatum),
+					CustomFlag:   "require-inline-datum",
+				},
+				{
+					Name:         "script-hash",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies transaction output reference script hash(es) to filter on",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.scriptHash),
+					CustomFlag:   "script-hash",
+				},
+				{
+					Name:         "require-script-ref",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "only match transactions with an output carrying a reference script",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.requireScriptRef),
+					CustomFlag:   "require-script-ref",
+				},
+				{
+					Name:         "stake-credential",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies stake credential hash(es) to filter stake registration events on",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.stakeCredential),
+					CustomFlag:   "stake-credential",
+				},
+				{
+					Name:         "reward-account",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies reward account(s) (stake addresses) to filter reward withdrawals on, such as those delegated to a pool",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.rewardAccount),
+					CustomFlag:   "reward-account",
+				},
+				{
+					Name:         "payment-key-hash",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies payment key hash(es) to filter transaction outputs on, regardless of the stake part of the address",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.paymentKeyHash),
+					CustomFlag:   "payment-key-hash",
+				},
+				{
+					Name:         "min-tx-size",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the minimum transaction size in bytes to filter on, measured from the transaction CBOR (see the chainsync input 'include-cbor' option)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.minTxSize),
+					CustomFlag:   "min-tx-size",
+				},
+				{
+					Name:         "max-tx-size",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the maximum transaction size in bytes to filter on, measured from the transaction CBOR (see the chainsync input 'include-cbor' option)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.maxTxSize),
+					CustomFlag:   "max-tx-size",
+				},
+				{
+					Name:         "min-fee",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the minimum transaction fee in lovelace to filter on",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.minFee),
+					CustomFlag:   "min-fee",
+				},
+				{
+					Name:         "max-fee",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the maximum transaction fee in lovelace to filter on",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.maxFee),
+					CustomFlag:   "max-fee",
+				},
+				{
+					Name:         "min-slot",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the minimum slot number to filter on",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.minSlot),
+					CustomFlag:   "min-slot",
+				},
+				{
+					Name:         "max-slot",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the maximum slot number to filter on",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.maxSlot),
+					CustomFlag:   "max-slot",
+				},
+				{
+					Name:         "min-output-amount",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the minimum transaction output amount in lovelace to filter on",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.minOutputAmount),
+					CustomFlag:   "min-output-amount",
+				},
+				{
+					Name:         "max-output-amount",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the maximum transaction output amount in lovelace to filter on",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.maxOutputAmount),
+					CustomFlag:   "max-output-amount",
+				},
 			},
 		},
 	)
@@ -78,6 +262,7 @@ func NewFromCmdlineOptions() plugin.Plugin {
 		WithLogger(
 			logging.GetLogger().With("plugin", "filter.chainsync"),
 		),
+		WithNetwork(cmdlineOptions.network),
 	}
 	if cmdlineOptions.address != "" {
 		pluginOptions = append(
@@ -87,6 +272,12 @@ func NewFromCmdlineOptions() plugin.Plugin {
 			),
 		)
 	}
+	if cmdlineOptions.addressStakeMatch {
+		pluginOptions = append(
+			pluginOptions,
+			WithAddressStakeMatch(cmdlineOptions.addressStakeMatch),
+		)
+	}
 	if cmdlineOptions.asset != "" {
 		pluginOptions = append(
 			pluginOptions,
@@ -95,6 +286,22 @@ func NewFromCmdlineOptions() plugin.Plugin {
 			),
 		)
 	}
+	if cmdlineOptions.assetMinQuantity != "" {
+		for _, assetMinQuantity := range strings.Split(cmdlineOptions.assetMinQuantity, ",") {
+			fingerprint, quantity, ok := strings.Cut(assetMinQuantity, "=")
+			if !ok {
+				panic("invalid asset minimum quantity format")
+			}
+			minQuantity, err := strconv.ParseUint(strings.TrimSpace(quantity), 10, 64)
+			if err != nil {
+				panic("invalid asset minimum quantity format")
+			}
+			pluginOptions = append(
+				pluginOptions,
+				WithAssetMinQuantity(strings.TrimSpace(fingerprint), minQuantity),
+			)
+		}
+	}
 	if cmdlineOptions.policyId != "" {
 		pluginOptions = append(
 			pluginOptions,
@@ -111,6 +318,124 @@ func NewFromCmdlineOptions() plugin.Plugin {
 			),
 		)
 	}
+	if cmdlineOptions.era != "" {
+		pluginOptions = append(
+			pluginOptions,
+			WithEras(
+				strings.Split(cmdlineOptions.era, ","),
+			),
+		)
+	}
+	if cmdlineOptions.metadataLabel != "" {
+		var metadataLabels []uint64
+		for _, label := range strings.Split(cmdlineOptions.metadataLabel, ",") {
+			tmpLabel, err := strconv.ParseUint(strings.TrimSpace(label), 10, 64)
+			if err != nil {
+				panic("invalid metadata label format")
+			}
+			metadataLabels = append(metadataLabels, tmpLabel)
+		}
+		pluginOptions = append(
+			pluginOptions,
+			WithMetadataLabels(metadataLabels),
+		)
+	}
+	if cmdlineOptions.datumHash != "" {
+		pluginOptions = append(
+			pluginOptions,
+			WithDatumHashes(
+				strings.Split(cmdlineOptions.datumHash, ","),
+			),
+		)
+	}
+	if cmdlineOptions.requireInlineDatum {
+		pluginOptions = append(
+			pluginOptions,
+			WithRequireInlineDatum(cmdlineOptions.requireInlineDatum),
+		)
+	}
+	if cmdlineOptions.scriptHash != "" {
+		pluginOptions = append(
+			pluginOptions,
+			WithScriptHashes(
+				strings.Split(cmdlineOptions.scriptHash, ","),
+			),
+		)
+	}
+	if cmdlineOptions.requireScriptRef {
+		pluginOptions = append(
+			pluginOptions,
+			WithRequireScriptRef(cmdlineOptions.requireScriptRef),
+		)
+	}
+	if cmdlineOptions.stakeCredential != "" {
+		pluginOptions = append(
+			pluginOptions,
+			WithStakeCredentials(
+				strings.Split(cmdlineOptions.stakeCredential, ","),
+			),
+		)
+	}
+	if cmdlineOptions.rewardAccount != "" {
+		pluginOptions = append(
+			pluginOptions,
+			WithPoolRewardActivity(
+				strings.Split(cmdlineOptions.rewardAccount, ","),
+			),
+		)
+	}
+	if cmdlineOptions.paymentKeyHash != "" {
+		hashes := strings.Split(cmdlineOptions.paymentKeyHash, ",")
+		for _, hash := range hashes {
+			hashBytes, err := hex.DecodeString(strings.TrimSpace(hash))
+			if err != nil || len(hashBytes) != ledger.AddressHashSize {
+				panic("invalid payment key hash format")
+			}
+		}
+		pluginOptions = append(
+			pluginOptions,
+			WithPaymentKeyHashes(hashes),
+		)
+	}
+	if cmdlineOptions.minTxSize > 0 {
+		pluginOptions = append(
+			pluginOptions,
+			WithMinTxSize(cmdlineOptions.minTxSize),
+		)
+	}
+	if cmdlineOptions.maxTxSize > 0 {
+		pluginOptions = append(
+			pluginOptions,
+			WithMaxTxSize(cmdlineOptions.maxTxSize),
+		)
+	}
+	if cmdlineOptions.minFee > 0 || cmdlineOptions.maxFee > 0 {
+		pluginOptions = append(
+			pluginOptions,
+			WithFeeRange(
+				uint64(cmdlineOptions.minFee),
+				uint64(cmdlineOptions.maxFee),
+			),
+		)
+	}
+	if cmdlineOptions.minSlot > 0 || cmdlineOptions.maxSlot > 0 {
+		pluginOptions = append(
+			pluginOptions,
+			WithSlotRange(
+				uint64(cmdlineOptions.minSlot),
+				uint64(cmdlineOptions.maxSlot),
+			),
+		)
+	}
+	if cmdlineOptions.minOutputAmount > 0 || cmdlineOptions.maxOutputAmount > 0 {
+		pluginOptions = append(
+			pluginOptions,
+			WithOutputAmountRange(
+				uint64(cmdlineOptions.minOutputAmount),
+				uint64(cmdlineOptions.maxOutputAmount),
+			),
+		)
+	}
 	p := New(pluginOptions...)
 	return p
 }
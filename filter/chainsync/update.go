@@ -0,0 +1,117 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import "errors"
+
+// errLastFilterValue is returned when removing a value via the API would empty the filter, which would match every
+// transaction rather than none
+var errLastFilterValue = errors.New("cannot remove the last value from the filter")
+
+// Addresses returns the addresses currently being filtered on
+func (c *ChainSync) Addresses() []string {
+	c.filterMutex.RLock()
+	defer c.filterMutex.RUnlock()
+	return c.filterAddresses[:len(c.filterAddresses):len(c.filterAddresses)]
+}
+
+// AddAddress adds an address to filter on while the filter is running. It returns false if the address was
+// already being filtered on
+func (c *ChainSync) AddAddress(address string) bool {
+	c.filterMutex.Lock()
+	defer c.filterMutex.Unlock()
+	var ok bool
+	c.filterAddresses, ok = addFilterValue(c.filterAddresses, address)
+	return ok
+}
+
+// RemoveAddress stops filtering on an address while the filter is running. It returns false if the address wasn't
+// being filtered on. Once the last address is removed, transactions are no longer filtered by address
+func (c *ChainSync) RemoveAddress(address string) bool {
+	ok, _ := c.removeAddress(address, false)
+	return ok
+}
+
+// removeAddress is the same as RemoveAddress, but returns errLastFilterValue instead of removing the last address
+// if keepLast is set
+func (c *ChainSync) removeAddress(address string, keepLast bool) (bool, error) {
+	c.filterMutex.Lock()
+	defer c.filterMutex.Unlock()
+	return removeFilterValue(&c.filterAddresses, address, keepLast)
+}
+
+// Policies returns the asset policy IDs currently being filtered on
+func (c *ChainSync) Policies() []string {
+	c.filterMutex.RLock()
+	defer c.filterMutex.RUnlock()
+	return c.filterPolicyIds[:len(c.filterPolicyIds):len(c.filterPolicyIds)]
+}
+
+// AddPolicy adds an asset policy ID to filter on while the filter is running. It returns false if the policy ID was
+// already being filtered on
+func (c *ChainSync) AddPolicy(policyId string) bool {
+	c.filterMutex.Lock()
+	defer c.filterMutex.Unlock()
+	var ok bool
+	c.filterPolicyIds, ok = addFilterValue(c.filterPolicyIds, policyId)
+	return ok
+}
+
+// RemovePolicy stops filtering on an asset policy ID while the filter is running. It returns false if the policy ID
+// wasn't being filtered on. Once the last policy ID is removed, transactions are no longer filtered by policy
+func (c *ChainSync) RemovePolicy(policyId string) bool {
+	ok, _ := c.removePolicy(policyId, false)
+	return ok
+}
+
+// removePolicy is the same as RemovePolicy, but returns errLastFilterValue instead of removing the last policy ID
+// if keepLast is set
+func (c *ChainSync) removePolicy(policyId string, keepLast bool) (bool, error) {
+	c.filterMutex.Lock()
+	defer c.filterMutex.Unlock()
+	return removeFilterValue(&c.filterPolicyIds, policyId, keepLast)
+}
+
+// addFilterValue returns a copy of values with value appended, or the original values if it's already present.
+// The original slice is never modified, so it's safe for callers that are still reading it
+func addFilterValue(values []string, value string) ([]string, bool) {
+	for _, tmpValue := range values {
+		if tmpValue == value {
+			return values, false
+		}
+	}
+	ret := make([]string, 0, len(values)+1)
+	ret = append(ret, values...)
+	return append(ret, value), true
+}
+
+// removeFilterValue replaces values with a copy without value. It returns false and leaves values alone if value
+// isn't present, or if it's the only value and keepLast is set
+func removeFilterValue(values *[]string, value string, keepLast bool) (bool, error) {
+	ret := make([]string, 0, len(*values))
+	for _, tmpValue := range *values {
+		if tmpValue != value {
+			ret = append(ret, tmpValue)
+		}
+	}
+	if len(ret) == len(*values) {
+		return false, nil
+	}
+	if len(ret) == 0 && keepLast {
+		return false, errLastFilterValue
+	}
+	*values = ret
+	return true, nil
+}
@@ -54,7 +54,7 @@ func (e *Event) Start() error {
 			if len(e.filterTypes) > 0 {
 				matched := false
 				for _, filterType := range e.filterTypes {
-					if evt.Type == filterType {
+					if event.BaseType(evt.Type) == filterType {
 						matched = true
 						break
 					}
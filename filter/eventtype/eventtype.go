@@ -0,0 +1,97 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtype
+
+import (
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+// EventType passes or drops events based on their type. An event is passed if its type is in the allow list, when
+// one is configured, and isn't in the deny list
+type EventType struct {
+	errorChan  chan error
+	inputChan  chan event.Event
+	outputChan chan event.Event
+	logger     plugin.Logger
+	allowTypes map[string]bool
+	denyTypes  map[string]bool
+}
+
+// New returns a new EventType object with the specified options applied
+func New(options ...EventTypeOptionFunc) *EventType {
+	e := &EventType{
+		errorChan:  make(chan error),
+		inputChan:  make(chan event.Event, 10),
+		outputChan: make(chan event.Event, 10),
+		allowTypes: map[string]bool{},
+		denyTypes:  map[string]bool{},
+	}
+	for _, option := range options {
+		option(e)
+	}
+	return e
+}
+
+// Start the event type filter
+func (e *EventType) Start() error {
+	go func() {
+		for {
+			evt, ok := <-e.inputChan
+			// Channel has been closed, which means we're shutting down
+			if !ok {
+				return
+			}
+			if !e.filterEvent(evt) {
+				continue
+			}
+			// Send event along
+			e.outputChan <- evt
+		}
+	}()
+	return nil
+}
+
+// filterEvent returns true if the event should be passed on
+func (e *EventType) filterEvent(evt event.Event) bool {
+	eventType := event.BaseType(evt.Type)
+	if len(e.allowTypes) > 0 && !e.allowTypes[eventType] {
+		return false
+	}
+	return !e.denyTypes[eventType]
+}
+
+// Stop the event type filter
+func (e *EventType) Stop() error {
+	close(e.inputChan)
+	close(e.outputChan)
+	close(e.errorChan)
+	return nil
+}
+
+// ErrorChan returns the filter error channel
+func (e *EventType) ErrorChan() chan error {
+	return e.errorChan
+}
+
+// InputChan returns the input event channel
+func (e *EventType) InputChan() chan<- event.Event {
+	return e.inputChan
+}
+
+// OutputChan returns the output event channel
+func (e *EventType) OutputChan() <-chan event.Event {
+	return e.outputChan
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtype_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/filter/eventtype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllowDeny(t *testing.T) {
+	eventTypes := []string{
+		"chainsync.block",
+		"chainsync.transaction",
+		"chainsync.rollback",
+		"chainsync.certificate",
+	}
+	testDefs := []struct {
+		options  []eventtype.EventTypeOptionFunc
+		expected []string
+	}{
+		// Events are passed through when nothing is configured
+		{
+			expected: eventTypes,
+		},
+		{
+			options: []eventtype.EventTypeOptionFunc{
+				eventtype.WithAllow([]string{"chainsync.block", "chainsync.rollback"}),
+			},
+			expected: []string{"chainsync.block", "chainsync.rollback"},
+		},
+		{
+			options: []eventtype.EventTypeOptionFunc{
+				eventtype.WithDeny([]string{"chainsync.transaction"}),
+			},
+			expected: []string{"chainsync.block", "chainsync.rollback", "chainsync.certificate"},
+		},
+		// The deny list takes precedence over the allow list
+		{
+			options: []eventtype.EventTypeOptionFunc{
+				eventtype.WithAllow([]string{"chainsync.block", "chainsync.rollback"}),
+				eventtype.WithDeny([]string{"chainsync.rollback"}),
+			},
+			expected: []string{"chainsync.block"},
+		},
+	}
+	for _, testDef := range testDefs {
+		e := eventtype.New(testDef.options...)
+		assert.NoError(t, e.Start())
+		for _, eventType := range eventTypes {
+			e.InputChan() <- event.New(eventType, time.Now(), nil, nil)
+		}
+		received := []string{}
+		for len(received) < len(testDef.expected) {
+			select {
+			case evt := <-e.OutputChan():
+				received = append(received, evt.Type)
+			case <-time.After(time.Second):
+				t.Fatalf("timeout waiting for events, received %v", received)
+			}
+		}
+		select {
+		case evt := <-e.OutputChan():
+			t.Fatalf("unexpected event: %s", evt.Type)
+		case <-time.After(50 * time.Millisecond):
+		}
+		assert.Equal(t, testDef.expected, received)
+		_ = e.Stop()
+	}
+}
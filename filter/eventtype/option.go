@@ -0,0 +1,44 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtype
+
+import "github.com/blinklabs-io/adder/plugin"
+
+type EventTypeOptionFunc func(*EventType)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) EventTypeOptionFunc {
+	return func(e *EventType) {
+		e.logger = logger
+	}
+}
+
+// WithAllow specifies the event types to pass. When empty, all event types not in the deny list are passed
+func WithAllow(eventTypes []string) EventTypeOptionFunc {
+	return func(e *EventType) {
+		for _, eventType := range eventTypes {
+			e.allowTypes[eventType] = true
+		}
+	}
+}
+
+// WithDeny specifies the event types to drop. This takes precedence over the allow list
+func WithDeny(eventTypes []string) EventTypeOptionFunc {
+	return func(e *EventType) {
+		for _, eventType := range eventTypes {
+			e.denyTypes[eventType] = true
+		}
+	}
+}
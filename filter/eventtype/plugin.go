@@ -0,0 +1,78 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtype
+
+import (
+	"strings"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	allowTypes string
+	denyTypes  string
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeFilter,
+			Name:               "eventtype",
+			Description:        "passes or drops events based on allow and deny lists of event types",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "allow-type",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "only pass events with one of the specified types (comma-separated)",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.allowTypes),
+					CustomFlag:   "allow-type",
+				},
+				{
+					Name:         "deny-type",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "drop events with one of the specified types (comma-separated)",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.denyTypes),
+					CustomFlag:   "deny-type",
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	pluginOptions := []EventTypeOptionFunc{
+		WithLogger(
+			logging.GetLogger().With("plugin", "filter.eventtype"),
+		),
+	}
+	if cmdlineOptions.allowTypes != "" {
+		pluginOptions = append(
+			pluginOptions,
+			WithAllow(strings.Split(cmdlineOptions.allowTypes, ",")),
+		)
+	}
+	if cmdlineOptions.denyTypes != "" {
+		pluginOptions = append(
+			pluginOptions,
+			WithDeny(strings.Split(cmdlineOptions.denyTypes, ",")),
+		)
+	}
+	p := New(pluginOptions...)
+	return p
+}
@@ -18,4 +18,10 @@ package filter
 import (
 	_ "github.com/blinklabs-io/adder/filter/chainsync"
 	_ "github.com/blinklabs-io/adder/filter/event"
+	_ "github.com/blinklabs-io/adder/filter/eventtype"
+	_ "github.com/blinklabs-io/adder/filter/perblock"
+	_ "github.com/blinklabs-io/adder/filter/project"
+	_ "github.com/blinklabs-io/adder/filter/router"
+	_ "github.com/blinklabs-io/adder/filter/sample"
+	_ "github.com/blinklabs-io/adder/filter/wallet"
 )
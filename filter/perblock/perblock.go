@@ -0,0 +1,109 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perblock
+
+import (
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+// PerBlock passes at most one transaction event per block, the first one it sees. Other events are passed through
+type PerBlock struct {
+	errorChan  chan error
+	inputChan  chan event.Event
+	outputChan chan event.Event
+	logger     plugin.Logger
+	enabled    bool
+	// Slot of the block the last transaction was passed for
+	lastSlot uint64
+	txPassed bool
+}
+
+// New returns a new PerBlock object with the specified options applied
+func New(options ...PerBlockOptionFunc) *PerBlock {
+	p := &PerBlock{
+		errorChan:  make(chan error),
+		inputChan:  make(chan event.Event, 10),
+		outputChan: make(chan event.Event, 10),
+		enabled:    true,
+	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// Start the per-block filter
+func (p *PerBlock) Start() error {
+	go func() {
+		for {
+			evt, ok := <-p.inputChan
+			// Channel has been closed, which means we're shutting down
+			if !ok {
+				return
+			}
+			if p.enabled && !p.filterEvent(evt) {
+				continue
+			}
+			// Send event along
+			p.outputChan <- evt
+		}
+	}()
+	return nil
+}
+
+// filterEvent returns true if the event should be passed on. A new block starts at each block event or when the
+// slot of a transaction changes, so that it works whether or not block events make it through earlier filters
+func (p *PerBlock) filterEvent(evt event.Event) bool {
+	switch event.BaseType(evt.Type) {
+	case "chainsync.block":
+		p.txPassed = false
+	case "chainsync.transaction":
+		txCtx, _ := evt.Context.(chainsync.TransactionContext)
+		if txCtx.SlotNumber != p.lastSlot {
+			p.lastSlot = txCtx.SlotNumber
+			p.txPassed = false
+		}
+		if p.txPassed {
+			return false
+		}
+		p.txPassed = true
+	}
+	return true
+}
+
+// Stop the per-block filter
+func (p *PerBlock) Stop() error {
+	close(p.inputChan)
+	close(p.outputChan)
+	close(p.errorChan)
+	return nil
+}
+
+// ErrorChan returns the filter error channel
+func (p *PerBlock) ErrorChan() chan error {
+	return p.errorChan
+}
+
+// InputChan returns the input event channel
+func (p *PerBlock) InputChan() chan<- event.Event {
+	return p.inputChan
+}
+
+// OutputChan returns the output event channel
+func (p *PerBlock) OutputChan() <-chan event.Event {
+	return p.outputChan
+}
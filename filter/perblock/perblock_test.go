@@ -0,0 +1,84 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perblock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/filter/perblock"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTransactionEvent(slot uint64, hash string) event.Event {
+	return event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{SlotNumber: slot, TransactionHash: hash},
+		chainsync.TransactionEvent{},
+	)
+}
+
+// receiveHashes returns the hashes of the transactions passed by the filter, and the types of any other events
+func receiveHashes(p *perblock.PerBlock) []string {
+	ret := []string{}
+	for {
+		select {
+		case evt := <-p.OutputChan():
+			if txCtx, ok := evt.Context.(chainsync.TransactionContext); ok {
+				ret = append(ret, txCtx.TransactionHash)
+			} else {
+				ret = append(ret, evt.Type)
+			}
+		case <-time.After(100 * time.Millisecond):
+			return ret
+		}
+	}
+}
+
+func TestPerBlock(t *testing.T) {
+	p := perblock.New()
+	assert.NoError(t, p.Start())
+	defer func() {
+		_ = p.Stop()
+	}()
+	p.InputChan() <- event.New("chainsync.block", time.Now(), chainsync.BlockContext{SlotNumber: 100}, chainsync.BlockEvent{})
+	for _, hash := range []string{"tx1", "tx2", "tx3"} {
+		p.InputChan() <- newTransactionEvent(100, hash)
+	}
+	// A change of slot starts a new block even without a block event
+	p.InputChan() <- newTransactionEvent(120, "tx4")
+	p.InputChan() <- newTransactionEvent(120, "tx5")
+	p.InputChan() <- event.New("chainsync.rollback", time.Now(), nil, chainsync.RollbackEvent{})
+	assert.Equal(
+		t,
+		[]string{"chainsync.block", "tx1", "tx4", "chainsync.rollback"},
+		receiveHashes(p),
+	)
+}
+
+func TestPerBlockDisabled(t *testing.T) {
+	p := perblock.New(perblock.WithEnabled(false))
+	assert.NoError(t, p.Start())
+	defer func() {
+		_ = p.Stop()
+	}()
+	for _, hash := range []string{"tx1", "tx2", "tx3"} {
+		p.InputChan() <- newTransactionEvent(100, hash)
+	}
+	assert.Equal(t, []string{"tx1", "tx2", "tx3"}, receiveHashes(p))
+}
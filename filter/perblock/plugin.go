@@ -0,0 +1,55 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package perblock
+
+import (
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	enabled bool
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeFilter,
+			Name:               "perblock",
+			Description:        "passes only the first transaction event of each block",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "first-tx-per-block",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "only pass the first transaction of each block matching the other filters",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.enabled),
+					CustomFlag:   "first-tx-per-block",
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "filter.perblock"),
+		),
+		WithEnabled(cmdlineOptions.enabled),
+	)
+	return p
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+type ProjectOptionFunc func(*Project)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) ProjectOptionFunc {
+	return func(p *Project) {
+		p.logger = logger
+	}
+}
+
+// WithIncludeFields specifies the dotted paths of the fields to keep, such as "payload.fee". Only the sections of
+// the event (context or payload) named by a path are pruned, and the others are passed through unchanged. Paths
+// that don't start with a valid section are ignored
+func WithIncludeFields(paths []string) ProjectOptionFunc {
+	return func(p *Project) {
+		p.includeFields = parseFieldPaths(paths)
+	}
+}
+
+// WithExcludeFields specifies the dotted paths of the fields to remove, such as "payload.transactionCbor". Paths
+// into a list are applied to each of its elements, so "payload.outputs.datum" removes the datum from every output.
+// Paths that don't start with a valid section are ignored
+func WithExcludeFields(paths []string) ProjectOptionFunc {
+	return func(p *Project) {
+		p.excludeFields = parseFieldPaths(paths)
+	}
+}
+
+func parseFieldPaths(paths []string) [][]string {
+	var ret [][]string
+	for _, path := range paths {
+		tmpPath, err := parseFieldPath(path)
+		if err != nil {
+			continue
+		}
+		ret = append(ret, tmpPath)
+	}
+	return ret
+}
+
+// parseFieldPath splits a dotted field path into its parts, checking that it refers to the event context or payload
+func parseFieldPath(path string) ([]string, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, errors.New("empty field path")
+	}
+	parts := strings.Split(path, ".")
+	if parts[0] != sectionContext && parts[0] != sectionPayload {
+		return nil, fmt.Errorf(
+			"field path must start with %q or %q: %s",
+			sectionContext,
+			sectionPayload,
+			path,
+		)
+	}
+	for _, part := range parts {
+		if part == "" {
+			return nil, fmt.Errorf("field path has an empty part: %s", path)
+		}
+	}
+	return parts, nil
+}
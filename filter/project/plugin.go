@@ -0,0 +1,90 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	includeFields string
+	excludeFields string
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeFilter,
+			Name:               "project",
+			Description:        "removes fields from event JSON, such as CBOR values",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "include-fields",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "only keep the specified event fields as dotted paths such as 'payload.fee' (comma-separated)",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.includeFields),
+					CustomFlag:   "include-fields",
+				},
+				{
+					Name:         "exclude-fields",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "remove the specified event fields as dotted paths such as 'payload.transactionCbor' (comma-separated)",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.excludeFields),
+					CustomFlag:   "exclude-fields",
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	pluginOptions := []ProjectOptionFunc{
+		WithLogger(
+			logging.GetLogger().With("plugin", "filter.project"),
+		),
+	}
+	if cmdlineOptions.includeFields != "" {
+		pluginOptions = append(
+			pluginOptions,
+			WithIncludeFields(splitFieldPaths(cmdlineOptions.includeFields)),
+		)
+	}
+	if cmdlineOptions.excludeFields != "" {
+		pluginOptions = append(
+			pluginOptions,
+			WithExcludeFields(splitFieldPaths(cmdlineOptions.excludeFields)),
+		)
+	}
+	p := New(pluginOptions...)
+	return p
+}
+
+// splitFieldPaths splits a comma-separated list of field paths, panicking on an invalid path
+func splitFieldPaths(value string) []string {
+	paths := strings.Split(value, ",")
+	for _, path := range paths {
+		if _, err := parseFieldPath(path); err != nil {
+			panic(fmt.Sprintf("invalid field path: %s", err))
+		}
+	}
+	return paths
+}
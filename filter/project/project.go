@@ -0,0 +1,282 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/pipeline"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+// Top-level sections of an event that field paths can refer to
+const (
+	sectionContext = "context"
+	sectionPayload = "payload"
+)
+
+// Project removes fields from events, such as large CBOR values that a downstream consumer doesn't need. Fields are
+// identified by dotted paths into the JSON representation of the event, such as "payload.transactionCbor". An event
+// with a pruned context or payload carries a generic map for it from then on, rather than the original type, so
+// this filter is added to the pipeline after the others
+type Project struct {
+	errorChan     chan error
+	inputChan     chan event.Event
+	outputChan    chan event.Event
+	logger        plugin.Logger
+	includeFields [][]string
+	excludeFields [][]string
+}
+
+// New returns a new Project object with the specified options applied
+func New(options ...ProjectOptionFunc) *Project {
+	p := &Project{
+		errorChan:  make(chan error),
+		inputChan:  make(chan event.Event, 10),
+		outputChan: make(chan event.Event, 10),
+	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// Start the project filter
+func (p *Project) Start() error {
+	go func() {
+		for {
+			evt, ok := <-p.inputChan
+			// Channel has been closed, which means we're shutting down
+			if !ok {
+				return
+			}
+			tmpEvt, err := p.projectEvent(evt)
+			if err != nil {
+				// Pass the event along unmodified rather than dropping it
+				if p.logger != nil {
+					p.logger.Errorf("failed to project event fields: %s", err)
+				}
+				tmpEvt = evt
+			}
+			// Send event along
+			p.outputChan <- tmpEvt
+		}
+	}()
+	return nil
+}
+
+// projectEvent returns the event with the configured fields pruned. Only the sections of the event referred to by a
+// field path are replaced, and everything else is passed through as-is
+func (p *Project) projectEvent(evt event.Event) (event.Event, error) {
+	if len(p.includeFields) == 0 && len(p.excludeFields) == 0 {
+		return evt, nil
+	}
+	for _, section := range []string{sectionContext, sectionPayload} {
+		includes := sectionPaths(p.includeFields, section)
+		excludes := sectionPaths(p.excludeFields, section)
+		if len(includes) == 0 && len(excludes) == 0 {
+			continue
+		}
+		var value any
+		if section == sectionContext {
+			value = evt.Context
+		} else {
+			value = evt.Payload
+		}
+		if value == nil {
+			continue
+		}
+		value, err := toGeneric(value)
+		if err != nil {
+			return evt, err
+		}
+		value = pruneSection(value, includes, excludes)
+		if section == sectionContext {
+			// Keep the block time of the original context for the pipeline max event age check
+			if timer, ok := evt.Context.(pipeline.BlockTimer); ok && value != nil {
+				evt.Context = prunedContext{fields: value, timer: timer}
+			} else {
+				evt.Context = value
+			}
+		} else {
+			evt.Payload = value
+		}
+	}
+	return evt, nil
+}
+
+// Stop the project filter
+func (p *Project) Stop() error {
+	close(p.inputChan)
+	close(p.outputChan)
+	close(p.errorChan)
+	return nil
+}
+
+// ErrorChan returns the filter error channel
+func (p *Project) ErrorChan() chan error {
+	return p.errorChan
+}
+
+// InputChan returns the input event channel
+func (p *Project) InputChan() chan<- event.Event {
+	return p.inputChan
+}
+
+// OutputChan returns the output event channel
+func (p *Project) OutputChan() <-chan event.Event {
+	return p.outputChan
+}
+
+// prunedContext is a pruned event context that still reports the block time of the original context
+type prunedContext struct {
+	fields any
+	timer  pipeline.BlockTimer
+}
+
+func (c prunedContext) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.fields)
+}
+
+func (c prunedContext) BlockTime() (time.Time, error) {
+	return c.timer.BlockTime()
+}
+
+// toGeneric converts a value to its generic JSON representation of maps, slices and scalars. Numbers are kept as
+// json.Number so that large integers such as lovelace amounts don't lose precision
+func toGeneric(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var ret any
+	if err := decoder.Decode(&ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// sectionPaths returns the paths that refer to the specified section, relative to it. A path naming the section
+// itself is returned as an empty path
+func sectionPaths(paths [][]string, section string) [][]string {
+	var ret [][]string
+	for _, path := range paths {
+		if path[0] == section {
+			ret = append(ret, path[1:])
+		}
+	}
+	return ret
+}
+
+// pruneSection applies include and exclude paths to a generic section value. When include paths are given, only
+// those fields are kept. Exclude paths are then removed from what remains. A nil value is returned if nothing is left
+func pruneSection(value any, includes [][]string, excludes [][]string) any {
+	if len(includes) > 0 {
+		tree := fieldTree{}
+		for _, path := range includes {
+			tree.add(path)
+		}
+		var ok bool
+		if value, ok = tree.include(value); !ok {
+			return nil
+		}
+	}
+	for _, path := range excludes {
+		if len(path) == 0 {
+			return nil
+		}
+		excludePath(value, path)
+	}
+	return value
+}
+
+// fieldTree holds include paths with a shared prefix together. A nil tree marks the end of a path, which keeps the
+// whole field
+type fieldTree map[string]fieldTree
+
+func (t fieldTree) add(path []string) {
+	if len(path) == 0 {
+		return
+	}
+	child, ok := t[path[0]]
+	if len(path) == 1 {
+		// A shorter path keeps the whole field, which covers any longer paths under it
+		t[path[0]] = nil
+		return
+	}
+	if ok && child == nil {
+		return
+	}
+	if child == nil {
+		child = fieldTree{}
+		t[path[0]] = child
+	}
+	child.add(path[1:])
+}
+
+// include returns a copy of the value with only the fields in the tree. Paths are applied to each element of a list.
+// It returns false if none of the fields are present
+func (t fieldTree) include(value any) (any, bool) {
+	if t == nil {
+		return value, true
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		ret := make(map[string]any, len(t))
+		for key, child := range t {
+			fieldValue, ok := v[key]
+			if !ok {
+				continue
+			}
+			if fieldValue, ok = child.include(fieldValue); ok {
+				ret[key] = fieldValue
+			}
+		}
+		return ret, len(ret) > 0
+	case []any:
+		ret := make([]any, 0, len(v))
+		for _, item := range v {
+			if item, ok := t.include(item); ok {
+				ret = append(ret, item)
+			}
+		}
+		return ret, true
+	}
+	// The path continues past a value that has no fields
+	return nil, false
+}
+
+// excludePath removes the field at the path from the value in place. Paths are applied to each element of a list
+func excludePath(value any, path []string) {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(path) == 1 {
+			delete(v, path[0])
+			return
+		}
+		if fieldValue, ok := v[path[0]]; ok {
+			excludePath(fieldValue, path[1:])
+		}
+	case []any:
+		for _, item := range v {
+			excludePath(item, path)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package project_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/filter/project"
+	"github.com/blinklabs-io/adder/filter/wallet"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/pipeline"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func projectEvent(t *testing.T, p *project.Project, evt event.Event) event.Event {
+	assert.NoError(t, p.Start())
+	defer func() {
+		_ = p.Stop()
+	}()
+	p.InputChan() <- evt
+	select {
+	case ret := <-p.OutputChan():
+		return ret
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return event.Event{}
+}
+
+func testEvent() event.Event {
+	return event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{
+			BlockNumber:     12,
+			SlotNumber:      34,
+			TransactionHash: "abcd",
+			NetworkMagic:    764824073,
+		},
+		map[string]any{
+			"blockHash":       "ef01",
+			"transactionCbor": "84a400",
+			"fee":             json.Number("18446744073709551615"),
+			"outputs": []any{
+				map[string]any{"address": "addr1", "datum": "d8799f"},
+				map[string]any{"address": "addr2"},
+			},
+		},
+	)
+}
+
+func TestPassThrough(t *testing.T) {
+	evt := testEvent()
+	ret := projectEvent(t, project.New(), evt)
+	assert.Equal(t, evt, ret)
+}
+
+func TestExcludeFields(t *testing.T) {
+	p := project.New(
+		project.WithExcludeFields(
+			[]string{"payload.transactionCbor", "payload.outputs.datum"},
+		),
+	)
+	ret := projectEvent(t, p, testEvent())
+	// The context isn't referred to by a path, so it keeps its type
+	assert.IsType(t, chainsync.TransactionContext{}, ret.Context)
+	data, err := json.Marshal(ret.Payload)
+	assert.NoError(t, err)
+	assert.JSONEq(
+		t,
+		`{"blockHash":"ef01","fee":18446744073709551615,"outputs":[{"address":"addr1"},{"address":"addr2"}]}`,
+		string(data),
+	)
+}
+
+func TestIncludeFields(t *testing.T) {
+	p := project.New(
+		project.WithIncludeFields(
+			[]string{"context.slotNumber", "payload.fee", "payload.outputs.address", "payload.missing"},
+		),
+		project.WithExcludeFields([]string{"payload.outputs"}),
+	)
+	ret := projectEvent(t, p, testEvent())
+	data, err := json.Marshal(ret)
+	assert.NoError(t, err)
+	var tmpEvt struct {
+		Type    string          `json:"type"`
+		Context json.RawMessage `json:"context"`
+		Payload json.RawMessage `json:"payload"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &tmpEvt))
+	assert.Equal(t, "chainsync.transaction", tmpEvt.Type)
+	assert.JSONEq(t, `{"slotNumber":34}`, string(tmpEvt.Context))
+	assert.JSONEq(t, `{"fee":18446744073709551615}`, string(tmpEvt.Payload))
+	// The pruned context still reports the block time for the pipeline max event age check
+	timer, ok := ret.Context.(pipeline.BlockTimer)
+	if assert.True(t, ok) {
+		expectedTime, expectedErr := testEvent().Context.(chainsync.TransactionContext).BlockTime()
+		blockTime, err := timer.BlockTime()
+		assert.Equal(t, expectedTime, blockTime)
+		assert.Equal(t, expectedErr, err)
+	}
+}
+
+func TestExcludeSection(t *testing.T) {
+	p := project.New(
+		project.WithExcludeFields([]string{"context", "type", "payload."}),
+	)
+	evt := testEvent()
+	ret := projectEvent(t, p, evt)
+	assert.Nil(t, ret.Context)
+	// Invalid paths are ignored
+	assert.Equal(t, evt.Payload, ret.Payload)
+}
+
+type mockOutput struct {
+	ledger.TransactionOutput
+	address ledger.Address
+	amount  uint64
+}
+
+func (o mockOutput) Address() ledger.Address { return o.address }
+func (o mockOutput) Amount() uint64          { return o.amount }
+
+func TestAfterWallet(t *testing.T) {
+	paymentHash := make([]byte, ledger.AddressHashSize)
+	addr, err := ledger.NewAddressFromParts(
+		ledger.AddressTypeKeyNone,
+		ledger.AddressNetworkMainnet,
+		paymentHash,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating address: %s", err)
+	}
+	// The project filter is added after the other filters, so they see the original payload types
+	w := wallet.New(wallet.WithAddresses([]string{addr.String()}))
+	p := project.New(
+		project.WithExcludeFields([]string{"payload.transactionCbor"}),
+	)
+	assert.NoError(t, w.Start())
+	assert.NoError(t, p.Start())
+	defer func() {
+		_ = w.Stop()
+		_ = p.Stop()
+	}()
+	go func() {
+		for evt := range w.OutputChan() {
+			p.InputChan() <- evt
+		}
+	}()
+	w.InputChan() <- event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{TransactionHash: "abcd"},
+		chainsync.TransactionEvent{
+			BlockHash:       "1234",
+			TransactionCbor: []byte{0x84},
+			Outputs: []ledger.TransactionOutput{
+				mockOutput{address: addr, amount: 1_000_000},
+			},
+		},
+	)
+	var types []string
+	for len(types) < 2 {
+		select {
+		case evt := <-p.OutputChan():
+			types = append(types, evt.Type)
+			payload, ok := evt.Payload.(map[string]any)
+			if assert.True(t, ok) {
+				assert.NotContains(t, payload, "transactionCbor")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+	}
+	assert.Equal(t, []string{"chainsync.transaction", "wallet.activity"}, types)
+}
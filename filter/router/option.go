@@ -0,0 +1,34 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import "github.com/blinklabs-io/adder/plugin"
+
+type RouterOptionFunc func(*Router)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) RouterOptionFunc {
+	return func(r *Router) {
+		r.logger = logger
+	}
+}
+
+// WithRoute specifies that events of the given type should only be delivered to the named output(s). This can be
+// specified multiple times for the same event type to deliver to additional outputs
+func WithRoute(eventType string, outputs ...string) RouterOptionFunc {
+	return func(r *Router) {
+		r.routes[eventType] = append(r.routes[eventType], outputs...)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"strings"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	routes string
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeFilter,
+			Name:               "router",
+			Description:        "routes events to specific outputs based on event type",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "routes",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies routes in '<event type>=<output>' format, separated by commas",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.routes),
+					CustomFlag:   "routes",
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	pluginOptions := []RouterOptionFunc{
+		WithLogger(
+			logging.GetLogger().With("plugin", "filter.router"),
+		),
+	}
+	if cmdlineOptions.routes != "" {
+		for _, route := range strings.Split(cmdlineOptions.routes, ",") {
+			routeParts := strings.Split(route, "=")
+			if len(routeParts) != 2 || routeParts[0] == "" ||
+				routeParts[1] == "" {
+				panic("invalid route format")
+			}
+			pluginOptions = append(
+				pluginOptions,
+				WithRoute(routeParts[0], routeParts[1]),
+			)
+		}
+	}
+	p := New(pluginOptions...)
+	return p
+}
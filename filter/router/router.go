@@ -0,0 +1,86 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+type Router struct {
+	errorChan  chan error
+	inputChan  chan event.Event
+	outputChan chan event.Event
+	logger     plugin.Logger
+	routes     map[string][]string
+}
+
+// New returns a new Router object with the specified options applied
+func New(options ...RouterOptionFunc) *Router {
+	r := &Router{
+		errorChan:  make(chan error),
+		inputChan:  make(chan event.Event, 10),
+		outputChan: make(chan event.Event, 10),
+		routes:     make(map[string][]string),
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Start the router filter
+func (r *Router) Start() error {
+	go func() {
+		for {
+			evt, ok := <-r.inputChan
+			// Channel has been closed, which means we're shutting down
+			if !ok {
+				return
+			}
+			// Tag the event with the outputs it's routed to. Events without a matching route are left
+			// untouched and delivered to all outputs
+			if destinations, ok := r.routes[event.BaseType(evt.Type)]; ok {
+				evt.Destinations = append(evt.Destinations, destinations...)
+			}
+			// Send event along
+			r.outputChan <- evt
+		}
+	}()
+	return nil
+}
+
+// Stop the router filter
+func (r *Router) Stop() error {
+	close(r.inputChan)
+	close(r.outputChan)
+	close(r.errorChan)
+	return nil
+}
+
+// ErrorChan returns the filter error channel
+func (r *Router) ErrorChan() chan error {
+	return r.errorChan
+}
+
+// InputChan returns the input event channel
+func (r *Router) InputChan() chan<- event.Event {
+	return r.inputChan
+}
+
+// OutputChan returns the output event channel
+func (r *Router) OutputChan() <-chan event.Event {
+	return r.outputChan
+}
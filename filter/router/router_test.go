@@ -0,0 +1,62 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/filter/router"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter(t *testing.T) {
+	r := router.New(
+		router.WithRoute("chainsync.transaction", "webhook"),
+		router.WithRoute("chainsync.block", "log"),
+		router.WithRoute("chainsync.block", "webhook"),
+	)
+	if err := r.Start(); err != nil {
+		t.Fatalf("unexpected error starting router: %s", err)
+	}
+	defer r.Stop()
+
+	testDefs := []struct {
+		eventType    string
+		destinations []string
+	}{
+		{
+			eventType:    "chainsync.transaction",
+			destinations: []string{"webhook"},
+		},
+		{
+			eventType:    "chainsync.block",
+			destinations: []string{"log", "webhook"},
+		},
+		{
+			eventType: "chainsync.rollback",
+		},
+	}
+	for _, testDef := range testDefs {
+		r.InputChan() <- event.New(testDef.eventType, time.Now(), nil, nil)
+		select {
+		case evt := <-r.OutputChan():
+			assert.Equal(t, testDef.destinations, evt.Destinations, testDef.eventType)
+		case <-time.After(100 * time.Millisecond):
+			t.Fatalf("timeout waiting for %s event", testDef.eventType)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sample
+
+import "github.com/blinklabs-io/adder/plugin"
+
+type SampleOptionFunc func(*Sample)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) SampleOptionFunc {
+	return func(s *Sample) {
+		s.logger = logger
+	}
+}
+
+// WithBlockInterval specifies that only block events with a block number that's a multiple of the interval should
+// be passed. A value of 0 or 1 passes all block events
+func WithBlockInterval(blockInterval uint64) SampleOptionFunc {
+	return func(s *Sample) {
+		s.blockInterval = blockInterval
+	}
+}
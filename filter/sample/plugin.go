@@ -0,0 +1,55 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sample
+
+import (
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	blockInterval uint
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeFilter,
+			Name:               "sample",
+			Description:        "passes only every Nth block event, for visualization rather than indexing",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "block-interval",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "only pass block events with a block number that's a multiple of the interval (lossy, 0 to disable)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.blockInterval),
+					CustomFlag:   "block-interval",
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "filter.sample"),
+		),
+		WithBlockInterval(uint64(cmdlineOptions.blockInterval)),
+	)
+	return p
+}
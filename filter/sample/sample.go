@@ -0,0 +1,98 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sample
+
+import (
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+// Sample passes only every Nth block event, based on the block number. All other events are passed through. This
+// is a lossy filter intended for visualizing long ranges of the chain, and shouldn't be used for indexing
+type Sample struct {
+	errorChan     chan error
+	inputChan     chan event.Event
+	outputChan    chan event.Event
+	logger        plugin.Logger
+	blockInterval uint64
+}
+
+// New returns a new Sample object with the specified options applied
+func New(options ...SampleOptionFunc) *Sample {
+	s := &Sample{
+		errorChan:  make(chan error),
+		inputChan:  make(chan event.Event, 10),
+		outputChan: make(chan event.Event, 10),
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// Start the sample filter
+func (s *Sample) Start() error {
+	go func() {
+		for {
+			evt, ok := <-s.inputChan
+			// Channel has been closed, which means we're shutting down
+			if !ok {
+				return
+			}
+			if !s.filterEvent(evt) {
+				continue
+			}
+			// Send event along
+			s.outputChan <- evt
+		}
+	}()
+	return nil
+}
+
+// filterEvent returns true if the event should be passed on
+func (s *Sample) filterEvent(evt event.Event) bool {
+	if s.blockInterval <= 1 {
+		return true
+	}
+	if _, ok := evt.Payload.(chainsync.BlockEvent); !ok {
+		return true
+	}
+	blockCtx, _ := evt.Context.(chainsync.BlockContext)
+	return blockCtx.BlockNumber%s.blockInterval == 0
+}
+
+// Stop the sample filter
+func (s *Sample) Stop() error {
+	close(s.inputChan)
+	close(s.outputChan)
+	close(s.errorChan)
+	return nil
+}
+
+// ErrorChan returns the filter error channel
+func (s *Sample) ErrorChan() chan error {
+	return s.errorChan
+}
+
+// InputChan returns the input event channel
+func (s *Sample) InputChan() chan<- event.Event {
+	return s.inputChan
+}
+
+// OutputChan returns the output event channel
+func (s *Sample) OutputChan() <-chan event.Event {
+	return s.outputChan
+}
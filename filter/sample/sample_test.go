@@ -0,0 +1,66 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sample_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/filter/sample"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockInterval(t *testing.T) {
+	s := sample.New(sample.WithBlockInterval(10))
+	assert.NoError(t, s.Start())
+	defer func() {
+		_ = s.Stop()
+	}()
+	go func() {
+		for blockNumber := uint64(1); blockNumber <= 25; blockNumber++ {
+			s.InputChan() <- event.New(
+				"chainsync.block",
+				time.Now(),
+				chainsync.BlockContext{BlockNumber: blockNumber},
+				chainsync.BlockEvent{},
+			)
+			// Transactions are always passed through
+			s.InputChan() <- event.New(
+				"chainsync.transaction",
+				time.Now(),
+				chainsync.TransactionContext{BlockNumber: blockNumber},
+				chainsync.TransactionEvent{},
+			)
+		}
+	}()
+	var blocks []uint64
+	var txCount int
+	for txCount < 25 {
+		select {
+		case evt := <-s.OutputChan():
+			if blockCtx, ok := evt.Context.(chainsync.BlockContext); ok {
+				blocks = append(blocks, blockCtx.BlockNumber)
+			} else {
+				txCount++
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for events, received %d transactions", txCount)
+		}
+	}
+	assert.Equal(t, []uint64{10, 20}, blocks)
+	assert.Equal(t, 25, txCount)
+}
@@ -0,0 +1,33 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wallet
+
+import "github.com/blinklabs-io/adder/plugin"
+
+type WalletOptionFunc func(*Wallet)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) WalletOptionFunc {
+	return func(w *Wallet) {
+		w.logger = logger
+	}
+}
+
+// WithAddresses specifies the addresses to produce wallet activity events for
+func WithAddresses(addresses []string) WalletOptionFunc {
+	return func(w *Wallet) {
+		w.addresses = addresses
+	}
+}
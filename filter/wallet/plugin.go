@@ -0,0 +1,63 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wallet
+
+import (
+	"strings"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	addresses string
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeFilter,
+			Name:               "wallet",
+			Description:        "adds wallet activity events for transactions touching watched addresses",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "wallet-address",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies address(es) to produce wallet activity events for",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.addresses),
+					CustomFlag:   "wallet-address",
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	pluginOptions := []WalletOptionFunc{
+		WithLogger(
+			logging.GetLogger().With("plugin", "filter.wallet"),
+		),
+	}
+	if cmdlineOptions.addresses != "" {
+		pluginOptions = append(
+			pluginOptions,
+			WithAddresses(strings.Split(cmdlineOptions.addresses, ",")),
+		)
+	}
+	p := New(pluginOptions...)
+	return p
+}
@@ -0,0 +1,157 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wallet
+
+import (
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+// WalletActivityEvent summarizes the effect of a transaction on the watched addresses it touched
+type WalletActivityEvent struct {
+	BlockHash       string            `json:"blockHash"`
+	TransactionHash string            `json:"transactionHash"`
+	Addresses       []AddressActivity `json:"addresses"`
+}
+
+// AddressActivity is the lovelace received and sent by a watched address in a transaction. Sent amounts are
+// calculated from the resolved inputs of the transaction, and are 0 if the inputs haven't been resolved
+type AddressActivity struct {
+	Address   string `json:"address"`
+	Received  uint64 `json:"received"`
+	Sent      uint64 `json:"sent"`
+	NetChange int64  `json:"netChange"`
+}
+
+type Wallet struct {
+	errorChan  chan error
+	inputChan  chan event.Event
+	outputChan chan event.Event
+	logger     plugin.Logger
+	addresses  []string
+}
+
+func New(options ...WalletOptionFunc) *Wallet {
+	w := &Wallet{
+		errorChan:  make(chan error),
+		inputChan:  make(chan event.Event, 10),
+		outputChan: make(chan event.Event, 10),
+	}
+	for _, option := range options {
+		option(w)
+	}
+	return w
+}
+
+// Start the wallet filter. All events are passed through, and a "wallet.activity" event follows each transaction
+// event that touches a watched address
+func (w *Wallet) Start() error {
+	go func() {
+		for {
+			evt, ok := <-w.inputChan
+			// Channel has been closed, which means we're shutting down
+			if !ok {
+				return
+			}
+			// Send event along
+			w.outputChan <- evt
+			if activityEvt, ok := w.activityEvent(evt); ok {
+				w.outputChan <- activityEvt
+			}
+		}
+	}()
+	return nil
+}
+
+// activityEvent returns a wallet activity event for a transaction event touching any watched addresses
+func (w *Wallet) activityEvent(evt event.Event) (event.Event, bool) {
+	if len(w.addresses) == 0 || event.BaseType(evt.Type) != "chainsync.transaction" {
+		return event.Event{}, false
+	}
+	te, ok := evt.Payload.(chainsync.TransactionEvent)
+	if !ok {
+		return event.Event{}, false
+	}
+	activityEvt := NewWalletActivityEvent(te, evt.Context, w.addresses)
+	if len(activityEvt.Addresses) == 0 {
+		return event.Event{}, false
+	}
+	return event.New("wallet.activity", evt.Timestamp, evt.Context, activityEvt), true
+}
+
+// NewWalletActivityEvent returns the activity of the specified addresses in a transaction, in the order the
+// addresses are specified. Addresses that the transaction doesn't touch are omitted
+func NewWalletActivityEvent(
+	te chainsync.TransactionEvent,
+	context interface{},
+	addresses []string,
+) WalletActivityEvent {
+	ret := WalletActivityEvent{
+		BlockHash: te.BlockHash,
+		Addresses: []AddressActivity{},
+	}
+	if txCtx, ok := context.(chainsync.TransactionContext); ok {
+		ret.TransactionHash = txCtx.TransactionHash
+	}
+	received := map[string]uint64{}
+	sent := map[string]uint64{}
+	for _, output := range te.Outputs {
+		received[output.Address().String()] += output.Amount()
+	}
+	for _, input := range te.ResolvedInputs {
+		sent[input.Address().String()] += input.Amount()
+	}
+	for _, address := range addresses {
+		addrReceived, isReceived := received[address]
+		addrSent, isSent := sent[address]
+		if !isReceived && !isSent {
+			continue
+		}
+		ret.Addresses = append(
+			ret.Addresses,
+			AddressActivity{
+				Address:   address,
+				Received:  addrReceived,
+				Sent:      addrSent,
+				NetChange: int64(addrReceived) - int64(addrSent),
+			},
+		)
+	}
+	return ret
+}
+
+// Stop the wallet filter
+func (w *Wallet) Stop() error {
+	close(w.inputChan)
+	close(w.outputChan)
+	close(w.errorChan)
+	return nil
+}
+
+// ErrorChan returns the filter error channel
+func (w *Wallet) ErrorChan() chan error {
+	return w.errorChan
+}
+
+// InputChan returns the input event channel
+func (w *Wallet) InputChan() chan<- event.Event {
+	return w.inputChan
+}
+
+// OutputChan returns the output event channel
+func (w *Wallet) OutputChan() <-chan event.Event {
+	return w.outputChan
+}
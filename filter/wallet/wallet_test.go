@@ -0,0 +1,118 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wallet_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/filter/wallet"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockOutput struct {
+	ledger.TransactionOutput
+	address ledger.Address
+	amount  uint64
+}
+
+func (o mockOutput) Address() ledger.Address { return o.address }
+func (o mockOutput) Amount() uint64          { return o.amount }
+
+func newAddress(t *testing.T, paymentByte byte) ledger.Address {
+	paymentHash := make([]byte, ledger.AddressHashSize)
+	for i := range paymentHash {
+		paymentHash[i] = paymentByte
+	}
+	addr, err := ledger.NewAddressFromParts(
+		ledger.AddressTypeKeyNone,
+		ledger.AddressNetworkMainnet,
+		paymentHash,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating address: %s", err)
+	}
+	return addr
+}
+
+func TestWalletActivity(t *testing.T) {
+	watchedAddr := newAddress(t, 0x01)
+	otherAddr := newAddress(t, 0x02)
+	w := wallet.New(wallet.WithAddresses([]string{watchedAddr.String()}))
+	assert.NoError(t, w.Start())
+	defer func() {
+		_ = w.Stop()
+	}()
+
+	// The watched address sends 10 ADA, with 4 ADA returned as change
+	txEvt := event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{TransactionHash: "abcd"},
+		chainsync.TransactionEvent{
+			BlockHash: "1234",
+			ResolvedInputs: []ledger.TransactionOutput{
+				mockOutput{address: watchedAddr, amount: 10_000_000},
+			},
+			Outputs: []ledger.TransactionOutput{
+				mockOutput{address: otherAddr, amount: 5_800_000},
+				mockOutput{address: watchedAddr, amount: 4_000_000},
+			},
+		},
+	)
+	w.InputChan() <- txEvt
+	assert.Equal(t, txEvt, <-w.OutputChan())
+	activityEvt := <-w.OutputChan()
+	assert.Equal(t, "wallet.activity", activityEvt.Type)
+	assert.Equal(
+		t,
+		wallet.WalletActivityEvent{
+			BlockHash:       "1234",
+			TransactionHash: "abcd",
+			Addresses: []wallet.AddressActivity{
+				{
+					Address:   watchedAddr.String(),
+					Received:  4_000_000,
+					Sent:      10_000_000,
+					NetChange: -6_000_000,
+				},
+			},
+		},
+		activityEvt.Payload,
+	)
+
+	// Transactions that don't touch a watched address are passed through without an activity event
+	otherEvt := event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{TransactionHash: "ef01"},
+		chainsync.TransactionEvent{
+			Outputs: []ledger.TransactionOutput{
+				mockOutput{address: otherAddr, amount: 1_000_000},
+			},
+		},
+	)
+	w.InputChan() <- otherEvt
+	assert.Equal(t, otherEvt, <-w.OutputChan())
+	select {
+	case evt := <-w.OutputChan():
+		t.Fatalf("unexpected event: %v", evt)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blinklabs-io/adder/api"
+	ouroboros "github.com/blinklabs-io/gouroboros"
+)
+
+var routesRegistered = false
+
+func (c *ChainSync) RegisterRoutes() {
+	if routesRegistered {
+		return
+	}
+
+	apiInstance := api.GetInstance()
+	apiInstance.AddRoute("GET", "/ready", c.handleReady)
+	apiInstance.AddRoute("GET", "/tx/:hash", c.handleTransaction)
+	apiInstance.AddRoute("GET", "/epoch", c.handleEpoch)
+
+	routesRegistered = true
+}
+
+// @Summary		Readiness
+// @Description	Report whether the initial sync has reached the chain tip
+// @Produce		json
+// @Success		200	{object}	map[string]bool	"Ready"
+// @Failure		503	{object}	map[string]bool	"Not ready"
+// @Router			/ready [get]
+func (c *ChainSync) handleReady(ctx *gin.Context) {
+	if !c.Ready() {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"ready": false})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"ready": true})
+}
+
+// @Summary		Transaction lookup
+// @Description	Get a recently seen transaction event by transaction hash
+// @Produce		json
+// @Param			hash	path		string				true	"Transaction hash"
+// @Success		200		{object}	map[string]any		"Transaction event"
+// @Failure		404		{object}	map[string]string	"Not found"
+// @Router			/tx/{hash} [get]
+func (c *ChainSync) handleTransaction(ctx *gin.Context) {
+	if c.txBuffer != nil {
+		if evt, ok := c.txBuffer.get(ctx.Param("hash")); ok {
+			ctx.JSON(http.StatusOK, evt)
+			return
+		}
+	}
+	ctx.JSON(
+		http.StatusNotFound,
+		gin.H{"error": "transaction not found in recent buffer"},
+	)
+}
+
+// @Summary		Epoch lookup
+// @Description	Get the epoch containing a slot on the configured network
+// @Produce		json
+// @Param			slot	query		int					true	"Slot number"
+// @Success		200		{object}	map[string]uint64	"Epoch"
+// @Failure		400		{object}	map[string]string	"Invalid slot or network"
+// @Router			/epoch [get]
+func (c *ChainSync) handleEpoch(ctx *gin.Context) {
+	slot, err := strconv.ParseUint(ctx.Query("slot"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid slot"})
+		return
+	}
+	networkMagic := c.networkMagic
+	// The network magic is only looked up from the network name when connecting
+	if networkMagic == 0 && c.network != "" {
+		networkMagic = ouroboros.NetworkByName(c.network).NetworkMagic
+	}
+	epoch, err := EpochFromSlot(networkMagic, slot)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"slot": slot, "epoch": epoch})
+}
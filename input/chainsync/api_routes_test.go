@@ -0,0 +1,139 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blinklabs-io/adder/api"
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+)
+
+func TestReady(t *testing.T) {
+	apiInstance := api.New(false)
+	c := chainsync.New()
+	c.RegisterRoutes()
+	router := apiInstance.Engine()
+
+	checkReady := func() int {
+		req, _ := http.NewRequest("GET", "/ready", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Not ready before any blocks have been processed
+	assert.Equal(t, http.StatusServiceUnavailable, checkReady())
+
+	// Still not ready while behind the tip
+	c.UpdateStatus(100, 10, "abcd", 200, "ef01")
+	assert.False(t, c.Ready())
+	assert.Equal(t, http.StatusServiceUnavailable, checkReady())
+
+	// Ready once the tip has been reached
+	c.UpdateStatus(200, 20, "ef01", 200, "ef01")
+	assert.True(t, c.Ready())
+	select {
+	case <-c.TipReachedChan():
+	default:
+		t.Fatal("tip reached channel was not closed")
+	}
+	assert.Equal(t, http.StatusOK, checkReady())
+}
+
+func TestTransactionLookup(t *testing.T) {
+	c := chainsync.New(chainsync.WithTxBufferSize(2))
+	router := gin.New()
+	router.GET("/tx/:hash", c.HandleTransaction)
+
+	lookup := func(hash string) (int, map[string]any) {
+		req, _ := http.NewRequest("GET", "/tx/"+hash, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body map[string]any
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body
+	}
+	newTxEvent := func(hash string, fee uint64) event.Event {
+		return event.New(
+			"chainsync.transaction",
+			time.Now(),
+			chainsync.TransactionContext{TransactionHash: hash},
+			chainsync.TransactionEvent{Fee: fee},
+		)
+	}
+
+	c.BufferTransaction("aa", newTxEvent("aa", 100))
+	code, body := lookup("aa")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "chainsync.transaction", body["type"])
+	assert.Equal(t, float64(100), body["payload"].(map[string]any)["fee"])
+
+	code, _ = lookup("ff")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// The oldest transaction is evicted once the buffer is full
+	c.BufferTransaction("bb", newTxEvent("bb", 200))
+	c.BufferTransaction("cc", newTxEvent("cc", 300))
+	code, _ = lookup("aa")
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = lookup("cc")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestEpochLookup(t *testing.T) {
+	lookup := func(c *chainsync.ChainSync, slot string) (int, map[string]any) {
+		router := gin.New()
+		router.GET("/epoch", c.HandleEpoch)
+		req, _ := http.NewRequest("GET", "/epoch?slot="+slot, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var body map[string]any
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		return w.Code, body
+	}
+
+	mainnet := chainsync.New(chainsync.WithNetwork("mainnet"))
+	// Byron
+	code, body := lookup(mainnet, "4492799")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(207), body["epoch"])
+	// Shelley
+	code, body = lookup(mainnet, "4492800")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(208), body["epoch"])
+
+	// The network magic takes precedence over the network name
+	preprod := chainsync.New(
+		chainsync.WithNetwork("mainnet"),
+		chainsync.WithNetworkMagic(1),
+	)
+	code, body = lookup(preprod, "518400")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(5), body["epoch"])
+
+	code, _ = lookup(mainnet, "abc")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = lookup(chainsync.New(chainsync.WithNetworkMagic(12345)), "0")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
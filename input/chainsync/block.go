@@ -22,15 +22,22 @@ type BlockContext struct {
 	BlockNumber  uint64 `json:"blockNumber"`
 	SlotNumber   uint64 `json:"slotNumber"`
 	NetworkMagic uint32 `json:"networkMagic"`
+	Era          string `json:"era"`
 }
 
 type BlockEvent struct {
-	Block            ledger.Block     `json:"-"`
-	BlockBodySize    uint64           `json:"blockBodySize"`
-	IssuerVkey       string           `json:"issuerVkey"`
-	BlockHash        string           `json:"blockHash"`
-	BlockCbor        byteSliceJsonHex `json:"blockCbor,omitempty"`
-	TransactionCount uint64           `json:"transactionCount"`
+	Block                      ledger.Block     `json:"-"`
+	BlockBodySize              uint64           `json:"blockBodySize"`
+	IssuerVkey                 string           `json:"issuerVkey"`
+	BlockHash                  string           `json:"blockHash"`
+	PrevHash                   string           `json:"prevHash,omitempty"`
+	BlockCbor                  byteSliceJsonHex `json:"blockCbor,omitempty"`
+	TransactionCount           uint64           `json:"transactionCount"`
+	TotalFees                  uint64           `json:"totalFees"`
+	TotalOutput                uint64           `json:"totalOutput"`
+	ScriptTransactionCount     uint64           `json:"scriptTransactionCount"`
+	GovernanceTransactionCount uint64           `json:"governanceTransactionCount"`
+	HeaderDetails              *HeaderDetails   `json:"headerDetails,omitempty"`
 }
 
 func NewBlockContext(block ledger.Block, networkMagic uint32) BlockContext {
@@ -38,6 +45,7 @@ func NewBlockContext(block ledger.Block, networkMagic uint32) BlockContext {
 		BlockNumber:  block.BlockNumber(),
 		SlotNumber:   block.SlotNumber(),
 		NetworkMagic: networkMagic,
+		Era:          block.Era().Name,
 	}
 	return ctx
 }
@@ -46,11 +54,19 @@ func NewBlockHeaderContext(block ledger.BlockHeader) BlockContext {
 	ctx := BlockContext{
 		BlockNumber: block.BlockNumber(),
 		SlotNumber:  block.SlotNumber(),
+		Era:         block.Era().Name,
 	}
 	return ctx
 }
 
-func NewBlockEvent(block ledger.Block, includeCbor bool) BlockEvent {
+// NewBlockEvent returns a new BlockEvent for the specified block, including totals for the block's transactions.
+// Header details such as the VRF output and operational certificate are only included when includeHeaderDetails
+// is set
+func NewBlockEvent(
+	block ledger.Block,
+	includeCbor bool,
+	includeHeaderDetails bool,
+) BlockEvent {
 	evt := BlockEvent{
 		Block:            block,
 		BlockBodySize:    block.BlockBodySize(),
@@ -58,8 +74,45 @@ func NewBlockEvent(block ledger.Block, includeCbor bool) BlockEvent {
 		IssuerVkey:       block.IssuerVkey().Hash().String(),
 		TransactionCount: uint64(len(block.Transactions())),
 	}
+	// Summarize the block's transactions so consumers don't need to aggregate them from transaction events
+	for _, tx := range block.Transactions() {
+		evt.TotalFees += tx.Fee()
+		for _, output := range tx.Outputs() {
+			evt.TotalOutput += output.Amount()
+		}
+		if tx.ScriptDataHash() != nil {
+			evt.ScriptTransactionCount++
+		}
+		if len(tx.VotingProcedures()) > 0 || len(tx.ProposalProcedures()) > 0 {
+			evt.GovernanceTransactionCount++
+		}
+	}
 	if includeCbor {
 		evt.BlockCbor = block.Cbor()
 	}
+	if header := blockHeader(block); header != nil {
+		evt.PrevHash = prevHash(header)
+		if includeHeaderDetails {
+			evt.HeaderDetails = NewHeaderDetails(header)
+		}
+	}
+	return evt
+}
+
+// NewBlockEventFromHeader returns a new BlockEvent for the specified block header, for when the block body isn't
+// available. The transaction totals are left empty. Header details are only included when includeHeaderDetails is set
+func NewBlockEventFromHeader(
+	header ledger.BlockHeader,
+	includeHeaderDetails bool,
+) BlockEvent {
+	evt := BlockEvent{
+		BlockBodySize: header.BlockBodySize(),
+		BlockHash:     header.Hash(),
+		PrevHash:      prevHash(header),
+		IssuerVkey:    header.IssuerVkey().Hash().String(),
+	}
+	if includeHeaderDetails {
+		evt.HeaderDetails = NewHeaderDetails(header)
+	}
 	return evt
 }
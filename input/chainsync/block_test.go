@@ -0,0 +1,129 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"testing"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBlockEventTransactionSummary(t *testing.T) {
+	block := mockBlock{
+		transactions: []ledger.Transaction{
+			mockTransaction{
+				fee: 170000,
+				outputs: []ledger.TransactionOutput{
+					mockOutput{amount: 1000000},
+					mockOutput{amount: 2500000},
+				},
+			},
+			mockTransaction{
+				fee:            250000,
+				scriptDataHash: &ledger.Blake2b256{0x01},
+				outputs: []ledger.TransactionOutput{
+					mockOutput{amount: 5000000},
+				},
+			},
+			mockTransaction{
+				fee:                200000,
+				proposalProcedures: []ledger.ProposalProcedure{{}},
+			},
+		},
+	}
+	evt := chainsync.NewBlockEvent(block, false, false)
+	assert.Equal(t, uint64(3), evt.TransactionCount)
+	assert.Equal(t, uint64(620000), evt.TotalFees)
+	assert.Equal(t, uint64(8500000), evt.TotalOutput)
+	assert.Equal(t, uint64(1), evt.ScriptTransactionCount)
+	assert.Equal(t, uint64(1), evt.GovernanceTransactionCount)
+}
+
+type mockBlockHeader struct {
+	ledger.BlockHeader
+}
+
+func (h mockBlockHeader) Hash() string                  { return "beef" }
+func (h mockBlockHeader) BlockNumber() uint64           { return 34 }
+func (h mockBlockHeader) SlotNumber() uint64            { return 5678 }
+func (h mockBlockHeader) Era() ledger.Era               { return ledger.Era{Name: "Conway"} }
+func (h mockBlockHeader) BlockBodySize() uint64         { return 1024 }
+func (h mockBlockHeader) IssuerVkey() ledger.IssuerVkey { return ledger.IssuerVkey{} }
+
+func TestHeadersOnly(t *testing.T) {
+	c := chainsync.New(chainsync.WithHeadersOnly(true))
+	// The block event is built from the header, without fetching the block
+	assert.NoError(t, c.HandleRollForward(mockBlockHeader{}))
+	var evt event.Event
+	select {
+	case evt = <-c.OutputChan():
+	default:
+		t.Fatal("expected a block event")
+	}
+	assert.Equal(t, "chainsync.block", evt.Type)
+	ctx := evt.Context.(chainsync.BlockContext)
+	assert.Equal(t, uint64(34), ctx.BlockNumber)
+	assert.Equal(t, uint64(5678), ctx.SlotNumber)
+	assert.Equal(t, "Conway", ctx.Era)
+	payload := evt.Payload.(chainsync.BlockEvent)
+	assert.Equal(t, "beef", payload.BlockHash)
+	assert.Equal(t, uint64(1024), payload.BlockBodySize)
+	assert.Equal(t, ledger.IssuerVkey{}.Hash().String(), payload.IssuerVkey)
+	assert.Equal(t, uint64(0), payload.TransactionCount)
+	// No transaction events are emitted, even for full blocks
+	block := mockBlock{transactions: []ledger.Transaction{mockTransaction{}}}
+	assert.NoError(t, c.HandleRollForward(block))
+	evt = <-c.OutputChan()
+	assert.Equal(t, "chainsync.block", evt.Type)
+	select {
+	case evt = <-c.OutputChan():
+		t.Fatalf("unexpected event: %s", evt.Type)
+	default:
+	}
+}
+
+func TestSkipEmptyBlocks(t *testing.T) {
+	testDefs := []struct {
+		skipEmptyBlocks bool
+		transactions    []ledger.Transaction
+		expectedEvents  []string
+	}{
+		{
+			skipEmptyBlocks: false,
+			expectedEvents:  []string{"chainsync.block"},
+		},
+		{
+			skipEmptyBlocks: true,
+			expectedEvents:  []string{},
+		},
+		{
+			skipEmptyBlocks: true,
+			transactions:    []ledger.Transaction{mockTransaction{}},
+			expectedEvents:  []string{"chainsync.block", "chainsync.transaction"},
+		},
+	}
+	for _, testDef := range testDefs {
+		c := chainsync.New(chainsync.WithSkipEmptyBlocks(testDef.skipEmptyBlocks))
+		c.EmitBlockEvents(mockBlock{transactions: testDef.transactions})
+		events := []string{}
+		for len(c.OutputChan()) > 0 {
+			events = append(events, (<-c.OutputChan()).Type)
+		}
+		assert.Equal(t, testDef.expectedEvents, events)
+	}
+}
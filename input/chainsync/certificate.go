@@ -0,0 +1,155 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"encoding/hex"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+type CertificateContext struct {
+	BlockNumber     uint64 `json:"blockNumber"`
+	SlotNumber      uint64 `json:"slotNumber"`
+	TransactionHash string `json:"transactionHash"`
+	TransactionIdx  uint32 `json:"transactionIdx"`
+	CertificateIdx  uint32 `json:"certificateIdx"`
+	NetworkMagic    uint32 `json:"networkMagic"`
+}
+
+type CertificateEvent struct {
+	BlockHash       string             `json:"blockHash"`
+	TransactionHash string             `json:"transactionHash"`
+	CertificateType string             `json:"certificateType"`
+	PoolId          string             `json:"poolId,omitempty"`
+	StakeCredential string             `json:"stakeCredential,omitempty"`
+	Certificate     ledger.Certificate `json:"certificate"`
+}
+
+func NewCertificateContext(
+	block ledger.Block,
+	tx ledger.Transaction,
+	txIndex uint32,
+	certIndex uint32,
+	networkMagic uint32,
+) CertificateContext {
+	ctx := CertificateContext{
+		BlockNumber:     block.BlockNumber(),
+		SlotNumber:      block.SlotNumber(),
+		TransactionHash: tx.Hash(),
+		TransactionIdx:  txIndex,
+		CertificateIdx:  certIndex,
+		NetworkMagic:    networkMagic,
+	}
+	return ctx
+}
+
+// NewCertificateEvent returns a new CertificateEvent for the specified certificate. The pool ID and stake
+// credential are populated for stake and pool certificates that reference them
+func NewCertificateEvent(
+	block ledger.Block,
+	tx ledger.Transaction,
+	cert ledger.Certificate,
+) CertificateEvent {
+	evt := CertificateEvent{
+		BlockHash:       block.Hash(),
+		TransactionHash: tx.Hash(),
+		CertificateType: CertificateTypeName(cert),
+		Certificate:     cert,
+	}
+	poolKeyHash, stakeCredential := certificateHashes(cert)
+	if poolKeyHash != nil {
+		evt.PoolId = hex.EncodeToString(poolKeyHash)
+	}
+	if stakeCredential != nil {
+		evt.StakeCredential = hex.EncodeToString(stakeCredential.Credential)
+	}
+	return evt
+}
+
+// certificateHashes returns the pool key hash and stake credential referenced by a stake or pool certificate, if any
+func certificateHashes(
+	cert ledger.Certificate,
+) ([]byte, *ledger.StakeCredential) {
+	switch c := cert.(type) {
+	case *ledger.StakeRegistrationCertificate:
+		return nil, &c.StakeRegistration
+	case *ledger.StakeDeregistrationCertificate:
+		return nil, &c.StakeDeregistration
+	case *ledger.StakeDelegationCertificate:
+		return c.PoolKeyHash[:], c.StakeCredential
+	case *ledger.PoolRegistrationCertificate:
+		return c.Operator[:], nil
+	case *ledger.PoolRetirementCertificate:
+		return c.PoolKeyHash[:], nil
+	case *ledger.RegistrationCertificate:
+		return nil, &c.StakeCredential
+	case *ledger.DeregistrationCertificate:
+		return nil, &c.StakeCredential
+	case *ledger.StakeVoteDelegationCertificate:
+		return c.PoolKeyHash, &c.StakeCredential
+	case *ledger.StakeRegistrationDelegationCertificate:
+		return c.PoolKeyHash, &c.StakeCredential
+	case *ledger.StakeVoteRegistrationDelegationCertificate:
+		return c.PoolKeyHash, &c.StakeCredential
+	}
+	return nil, nil
+}
+
+// CertificateTypeName returns a descriptive name for the type of the specified certificate
+func CertificateTypeName(cert ledger.Certificate) string {
+	switch cert.(type) {
+	case *ledger.StakeRegistrationCertificate:
+		return "StakeRegistration"
+	case *ledger.StakeDeregistrationCertificate:
+		return "StakeDeregistration"
+	case *ledger.StakeDelegationCertificate:
+		return "StakeDelegation"
+	case *ledger.PoolRegistrationCertificate:
+		return "PoolRegistration"
+	case *ledger.PoolRetirementCertificate:
+		return "PoolRetirement"
+	case *ledger.GenesisKeyDelegationCertificate:
+		return "GenesisKeyDelegation"
+	case *ledger.MoveInstantaneousRewardsCertificate:
+		return "MoveInstantaneousRewards"
+	case *ledger.RegistrationCertificate:
+		return "Registration"
+	case *ledger.DeregistrationCertificate:
+		return "Deregistration"
+	case *ledger.VoteDelegationCertificate:
+		return "VoteDelegation"
+	case *ledger.StakeVoteDelegationCertificate:
+		return "StakeVoteDelegation"
+	case *ledger.StakeRegistrationDelegationCertificate:
+		return "StakeRegistrationDelegation"
+	case *ledger.VoteRegistrationDelegationCertificate:
+		return "VoteRegistrationDelegation"
+	case *ledger.StakeVoteRegistrationDelegationCertificate:
+		return "StakeVoteRegistrationDelegation"
+	case *ledger.AuthCommitteeHotCertificate:
+		return "AuthCommitteeHot"
+	case *ledger.ResignCommitteeColdCertificate:
+		return "ResignCommitteeCold"
+	case *ledger.RegistrationDrepCertificate:
+		return "RegistrationDrep"
+	case *ledger.DeregistrationDrepCertificate:
+		return "DeregistrationDrep"
+	case *ledger.UpdateDrepCertificate:
+		return "UpdateDrep"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"testing"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCertificateEventMixedTypes(t *testing.T) {
+	tx := mockTransaction{
+		certificates: []ledger.Certificate{
+			&ledger.StakeRegistrationCertificate{},
+			&ledger.PoolRetirementCertificate{},
+			&ledger.RegistrationDrepCertificate{},
+		},
+	}
+	block := mockBlock{transactions: []ledger.Transaction{tx}}
+	expectedTypes := []string{
+		"StakeRegistration",
+		"PoolRetirement",
+		"RegistrationDrep",
+	}
+	for idx, cert := range tx.Certificates() {
+		ctx := chainsync.NewCertificateContext(block, tx, 0, uint32(idx), 764824073)
+		assert.Equal(t, uint64(12), ctx.BlockNumber)
+		assert.Equal(t, uint64(3456), ctx.SlotNumber)
+		assert.Equal(t, "deadbeef", ctx.TransactionHash)
+		assert.Equal(t, uint32(idx), ctx.CertificateIdx)
+		evt := chainsync.NewCertificateEvent(block, tx, cert)
+		assert.Equal(t, "abcd", evt.BlockHash)
+		assert.Equal(t, "deadbeef", evt.TransactionHash)
+		assert.Equal(t, expectedTypes[idx], evt.CertificateType)
+		assert.Equal(t, cert, evt.Certificate)
+	}
+}
+
+func TestNewCertificateEventHashes(t *testing.T) {
+	stakeCredential := ledger.StakeCredential{Credential: []byte{0x01, 0x02}}
+	poolKeyHash := ledger.PoolKeyHash{0xaa}
+	tx := mockTransaction{}
+	block := mockBlock{}
+	testDefs := []struct {
+		cert            ledger.Certificate
+		poolId          string
+		stakeCredential string
+	}{
+		{
+			cert: &ledger.StakeDelegationCertificate{
+				StakeCredential: &stakeCredential,
+				PoolKeyHash:     poolKeyHash,
+			},
+			poolId:          "aa000000000000000000000000000000000000000000000000000000",
+			stakeCredential: "0102",
+		},
+		{
+			cert:            &ledger.StakeRegistrationCertificate{StakeRegistration: stakeCredential},
+			stakeCredential: "0102",
+		},
+		{
+			cert:   &ledger.PoolRegistrationCertificate{Operator: poolKeyHash},
+			poolId: "aa000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			cert: &ledger.RegistrationDrepCertificate{DrepCredential: stakeCredential},
+		},
+	}
+	for _, testDef := range testDefs {
+		evt := chainsync.NewCertificateEvent(block, tx, testDef.cert)
+		assert.Equal(t, testDef.poolId, evt.PoolId, evt.CertificateType)
+		assert.Equal(t, testDef.stakeCredential, evt.StakeCredential, evt.CertificateType)
+	}
+}
@@ -15,8 +15,14 @@
 package chainsync
 
 import (
+	"crypto/tls"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/blinklabs-io/adder/event"
@@ -27,35 +33,81 @@ import (
 	"github.com/blinklabs-io/gouroboros/protocol/blockfetch"
 	ochainsync "github.com/blinklabs-io/gouroboros/protocol/chainsync"
 	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+	"github.com/blinklabs-io/gouroboros/protocol/keepalive"
 )
 
 const (
 	// Size of cache for recent chainsync cursors
 	cursorCacheSize = 20
+
+	// Delay bounds between connection attempts when retrying on startup
+	startupRetryMinDelay = 250 * time.Millisecond
+	startupRetryMaxDelay = 30 * time.Second
+
+	// Delay bounds between connection attempts when auto-reconnecting
+	minAutoReconnectDelay = 250 * time.Millisecond
+	maxAutoReconnectDelay = 30 * time.Second
 )
 
 type ChainSync struct {
-	oConn            *ouroboros.Connection
-	logger           plugin.Logger
-	network          string
-	networkMagic     uint32
-	address          string
-	socketPath       string
-	ntcTcp           bool
-	bulkMode         bool
-	intersectTip     bool
-	intersectPoints  []ocommon.Point
-	includeCbor      bool
-	autoReconnect    bool
-	statusUpdateFunc StatusUpdateFunc
-	status           *ChainSyncStatus
-	errorChan        chan error
-	eventChan        chan event.Event
-	bulkRangeStart   ocommon.Point
-	bulkRangeEnd     ocommon.Point
-	cursorCache      []ocommon.Point
-	dialAddress      string
-	dialFamily       string
+	oConn                  *ouroboros.Connection
+	logger                 plugin.Logger
+	network                string
+	networkMagic           uint32
+	address                string
+	peers                  []string
+	peerIdx                int
+	socketPath             string
+	ntcTcp                 bool
+	bulkMode               bool
+	intersectTip           bool
+	intersectPoints        []ocommon.Point
+	intersectSlot          uint64
+	includeCbor            bool
+	includeHeaderDetails   bool
+	maxDatumBytes          int
+	maxMetadataBytes       int
+	emitCertificates       bool
+	emitStakeRegistrations bool
+	emitPoolEvents         bool
+	emitReferenceScripts   bool
+	headersOnly            bool
+	skipEmptyBlocks        bool
+	autoReconnect          bool
+	startupRetry           bool
+	startupTimeout         time.Duration
+	connectTimeout         time.Duration
+	keepAliveInterval      time.Duration
+	dialFunc               DialFunc
+	tlsClientCert          string
+	tlsClientKey           string
+	tlsCaCert              string
+	statusUpdateFunc       StatusUpdateFunc
+	status                 *ChainSyncStatus
+	ready                  atomic.Bool
+	lastSlot               atomic.Uint64
+	tipReachedChan         chan struct{}
+	metrics                *chainSyncMetrics
+	errorChan              chan error
+	eventChan              chan event.Event
+	bulkRangeStart         ocommon.Point
+	bulkRangeEnd           ocommon.Point
+	cursorCache            []ocommon.Point
+	cursorMutex            sync.Mutex
+	cursorFile             string
+	cursorFileLastWrite    time.Time
+	txBufferSize           int
+	txBuffer               *txBuffer
+	dialAddress            string
+	dialFamily             string
+	rollbackStormThreshold int
+	rollbackStormWindow    time.Duration
+	rollbackStormMaxEvents int
+	rollbackStorm          rollbackStorm
+	resolveInputsSocket    string
+	resolveInputsFunc      ResolveInputsFunc
+	resolveInputsWarned    bool
+	inputResolver          *ledgerStateResolver
 }
 
 type ChainSyncStatus struct {
@@ -69,37 +121,76 @@ type ChainSyncStatus struct {
 
 type StatusUpdateFunc func(ChainSyncStatus)
 
+// DialFunc establishes the network connection to the node
+type DialFunc func(network string, address string) (net.Conn, error)
+
 // New returns a new ChainSync object with the specified options applied
 func New(options ...ChainSyncOptionFunc) *ChainSync {
 	c := &ChainSync{
-		errorChan:       make(chan error),
-		eventChan:       make(chan event.Event, 10),
-		intersectPoints: []ocommon.Point{},
-		status:          &ChainSyncStatus{},
+		errorChan:              make(chan error),
+		eventChan:              make(chan event.Event, 10),
+		intersectPoints:        []ocommon.Point{},
+		status:                 &ChainSyncStatus{},
+		tipReachedChan:         make(chan struct{}),
+		metrics:                newChainSyncMetrics(),
+		txBufferSize:           1000,
+		rollbackStormWindow:    1 * time.Minute,
+		rollbackStormMaxEvents: 10000,
+		connectTimeout:         ouroboros.DefaultConnectTimeout,
 	}
 	for _, option := range options {
 		option(c)
 	}
+	if c.txBufferSize > 0 {
+		c.txBuffer = newTxBuffer(c.txBufferSize)
+	}
 	return c
 }
 
 // Start the chain sync input
 func (c *ChainSync) Start() error {
+	// Load intersect points from cursor file on initial startup
+	if c.oConn == nil {
+		if err := c.loadCursorFile(); err != nil {
+			return err
+		}
+	}
+	c.intersectPoints = c.cleanIntersectPoints(c.intersectPoints)
+	// Look up the intersect point for the configured slot on initial startup, unless we're resuming from a cursor
+	if c.oConn == nil && c.intersectSlot > 0 && len(c.cursorCache) == 0 {
+		point, err := c.findIntersectSlot(c.intersectSlot)
+		if err != nil {
+			return err
+		}
+		c.intersectPoints = []ocommon.Point{point}
+		c.intersectTip = false
+	}
 	if err := c.setupConnection(); err != nil {
 		return err
 	}
+	// Setup input resolution now that the network magic is known
+	if c.resolveInputsSocket != "" && c.inputResolver == nil {
+		c.inputResolver = &ledgerStateResolver{
+			socketPath:   c.resolveInputsSocket,
+			networkMagic: c.networkMagic,
+		}
+		c.resolveInputsFunc = c.inputResolver.resolveInputs
+	}
 	// Start chainsync client
 	c.oConn.ChainSync().Client.Start()
 	if c.oConn.BlockFetch() != nil {
 		c.oConn.BlockFetch().Client.Start()
 	}
-	if c.bulkMode && !c.intersectTip && c.oConn.BlockFetch() != nil {
+	if c.bulkMode && !c.headersOnly && !c.intersectTip && c.oConn.BlockFetch() != nil {
 		// Get available block range between our intersect point(s) and the chain tip
 		var err error
 		c.bulkRangeStart, c.bulkRangeEnd, err = c.oConn.ChainSync().Client.GetAvailableBlockRange(
 			c.intersectPoints,
 		)
 		if err != nil {
+			if errors.Is(err, ochainsync.IntersectNotFoundError) {
+				return c.resetToTip()
+			}
 			return err
 		}
 		if c.bulkRangeStart.Slot == 0 || c.bulkRangeEnd.Slot == 0 {
@@ -122,14 +213,49 @@ func (c *ChainSync) Start() error {
 			c.intersectPoints = []ocommon.Point{tip.Point}
 		}
 		if err := c.oConn.ChainSync().Client.Sync(c.intersectPoints); err != nil {
+			if errors.Is(err, ochainsync.IntersectNotFoundError) {
+				return c.resetToTip()
+			}
 			return err
 		}
 	}
 	return nil
 }
 
+// resetToTip is used when none of our intersect points could be found on the chain. It emits a reset
+// event so that consumers can purge their state and resumes syncing from the current chain tip
+func (c *ChainSync) resetToTip() error {
+	tip, err := c.oConn.ChainSync().Client.GetCurrentTip()
+	if err != nil {
+		return err
+	}
+	if c.logger != nil {
+		c.logger.Warnf(
+			"none of the intersect points were found, resetting to chain tip at slot %d",
+			tip.Point.Slot,
+		)
+	}
+	evt := event.New(
+		"chainsync.reset",
+		time.Now(),
+		nil,
+		NewResetEvent(ResetReasonIntersectNotFound, tip.Point),
+	)
+	c.eventChan <- evt
+	// Our cached cursor points are no longer valid
+	c.cursorMutex.Lock()
+	c.cursorCache = nil
+	c.cursorMutex.Unlock()
+	c.intersectPoints = []ocommon.Point{tip.Point}
+	return c.oConn.ChainSync().Client.Sync(c.intersectPoints)
+}
+
 // Stop the chain sync input
 func (c *ChainSync) Stop() error {
+	c.saveCursorFile(true)
+	if c.inputResolver != nil {
+		c.inputResolver.close()
+	}
 	err := c.oConn.Close()
 	close(c.eventChan)
 	close(c.errorChan)
@@ -151,14 +277,112 @@ func (c *ChainSync) OutputChan() <-chan event.Event {
 	return c.eventChan
 }
 
+// Ready returns true once the chain tip has been reached after the initial sync
+func (c *ChainSync) Ready() bool {
+	return c.ready.Load()
+}
+
+// TipReachedChan returns a channel that is closed once the chain tip has been reached after the initial sync
+func (c *ChainSync) TipReachedChan() <-chan struct{} {
+	return c.tipReachedChan
+}
+
+// LastSlot returns the slot of the last block processed, for the pipeline stop report
+func (c *ChainSync) LastSlot() uint64 {
+	return c.lastSlot.Load()
+}
+
 func (c *ChainSync) setupConnection() error {
+	// The previous connection is kept on failure, since it's used to determine whether this is the initial startup
+	oConn, err := c.newConnection(
+		ochainsync.NewConfig(
+			ochainsync.WithRollForwardFunc(c.handleRollForward),
+			ochainsync.WithRollBackwardFunc(c.handleRollBackward),
+		),
+		blockfetch.NewConfig(
+			blockfetch.WithBlockFunc(c.handleBlockFetchBlock),
+		),
+	)
+	if err != nil {
+		return err
+	}
+	c.oConn = oConn
+	if c.logger != nil {
+		c.logger.Infof("connected to node at %s", c.dialAddress)
+	}
+	// Start async error handler
+	go func() {
+		err, ok := <-oConn.ErrorChan()
+		if ok {
+			if c.autoReconnect {
+				c.reconnect(err)
+			} else {
+				// Pass error through our own error channel
+				c.errorChan <- err
+			}
+		}
+	}()
+	return nil
+}
+
+// reconnect restarts the connection after an error, resuming from the cursor cache. When multiple peers are
+// configured, each attempt rotates to the next peer. Failed attempts are retried with a backoff that carries
+// across peers, so that we don't hammer the network when all of them are unavailable
+func (c *ChainSync) reconnect(err error) {
+	retryDelay := minAutoReconnectDelay
+	for {
+		// Shutdown current connection
+		if err := c.oConn.Close(); err != nil {
+			if c.logger != nil {
+				c.logger.Warnf("failed to properly close connection: %s", err)
+			}
+		}
+		c.nextPeer()
+		if c.logger != nil {
+			c.logger.Infof("reconnecting to %s due to error: %s", c.peerAddress(), err)
+		}
+		// Set the intersect points from the cursor cache
+		c.cursorMutex.Lock()
+		if len(c.cursorCache) > 0 {
+			c.intersectPoints = slices.Clone(c.cursorCache)
+		}
+		c.cursorMutex.Unlock()
+		// Restart the connection
+		if err = c.Start(); err == nil {
+			return
+		}
+		time.Sleep(retryDelay)
+		retryDelay = min(retryDelay*2, maxAutoReconnectDelay)
+	}
+}
+
+// nextPeer rotates to the next configured peer in round-robin order
+func (c *ChainSync) nextPeer() {
+	if len(c.peers) > 0 {
+		c.peerIdx = (c.peerIdx + 1) % len(c.peers)
+	}
+}
+
+// peerAddress returns the address that the next connection attempt will be made to
+func (c *ChainSync) peerAddress() string {
+	if len(c.peers) > 0 {
+		return c.peers[c.peerIdx]
+	}
+	return c.dialAddress
+}
+
+// newConnection dials the node and returns an Ouroboros connection using the provided protocol configs
+func (c *ChainSync) newConnection(
+	chainSyncConfig ochainsync.Config,
+	blockFetchConfig blockfetch.Config,
+) (*ouroboros.Connection, error) {
 	// Determine connection parameters
 	var useNtn bool
 	// Lookup network by name, if provided
 	if c.network != "" {
 		network := ouroboros.NetworkByName(c.network)
 		if network == ouroboros.NetworkInvalid {
-			return fmt.Errorf("unknown network: %s", c.network)
+			return nil, fmt.Errorf("unknown network: %s", c.network)
 		}
 		c.networkMagic = network.NetworkMagic
 		// If network has well-known public root address/port, use those as our dial default
@@ -172,8 +396,12 @@ func (c *ChainSync) setupConnection() error {
 			useNtn = true
 		}
 	}
-	// Use user-provided address or socket path, if provided
-	if c.address != "" {
+	// Use user-provided peers, address or socket path, if provided
+	if len(c.peers) > 0 {
+		c.dialFamily = "tcp"
+		c.dialAddress = c.peers[c.peerIdx]
+		useNtn = !c.ntcTcp
+	} else if c.address != "" {
 		c.dialFamily = "tcp"
 		c.dialAddress = c.address
 		if c.ntcTcp {
@@ -186,70 +414,89 @@ func (c *ChainSync) setupConnection() error {
 		c.dialAddress = c.socketPath
 		useNtn = false
 	} else if c.dialFamily == "" || c.dialAddress == "" {
-		return fmt.Errorf("you must specify a host/port, UNIX socket path, or well-known network name")
+		return nil, fmt.Errorf("you must specify a host/port, UNIX socket path, or well-known network name")
+	}
+	// Load TLS config for TCP connections. UNIX socket connections don't use TLS
+	var tlsConfig *tls.Config
+	if c.dialFamily == "tcp" {
+		var err error
+		tlsConfig, err = c.tlsClientConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Connect to node
+	conn, err := c.dialNode(tlsConfig)
+	if err != nil {
+		return nil, err
 	}
 	// Create connection
-	var err error
-	c.oConn, err = ouroboros.NewConnection(
+	return ouroboros.NewConnection(
+		ouroboros.WithConnection(conn),
 		ouroboros.WithNetworkMagic(c.networkMagic),
 		ouroboros.WithNodeToNode(useNtn),
 		ouroboros.WithKeepAlive(true),
-		ouroboros.WithChainSyncConfig(
-			ochainsync.NewConfig(
-				ochainsync.WithRollForwardFunc(c.handleRollForward),
-				ochainsync.WithRollBackwardFunc(c.handleRollBackward),
-			),
-		),
-		ouroboros.WithBlockFetchConfig(
-			blockfetch.NewConfig(
-				blockfetch.WithBlockFunc(c.handleBlockFetchBlock),
-			),
-		),
+		ouroboros.WithKeepAliveConfig(c.keepAliveConfig()),
+		ouroboros.WithChainSyncConfig(chainSyncConfig),
+		ouroboros.WithBlockFetchConfig(blockFetchConfig),
 	)
-	if err != nil {
-		return err
+}
+
+// dialNode connects to the node. When startup retry is enabled, failed connection attempts on
+// initial startup are retried with backoff until the node becomes available or the startup
+// timeout is reached
+func (c *ChainSync) dialNode(tlsConfig *tls.Config) (net.Conn, error) {
+	dialFunc := c.dialFunc
+	if dialFunc == nil {
+		dialFunc = func(network string, address string) (net.Conn, error) {
+			return net.DialTimeout(network, address, c.connectTimeout)
+		}
 	}
-	if err := c.oConn.Dial(c.dialFamily, c.dialAddress); err != nil {
-		return err
+	if tlsConfig != nil {
+		dialFunc = tlsDialFunc(dialFunc, tlsConfig)
 	}
-	if c.logger != nil {
-		c.logger.Infof("connected to node at %s", c.dialAddress)
+	// Only retry on initial startup
+	if !c.startupRetry || c.oConn != nil {
+		return dialFunc(c.dialFamily, c.dialAddress)
 	}
-	// Start async error handler
-	go func() {
-		err, ok := <-c.oConn.ErrorChan()
-		if ok {
-			if c.autoReconnect {
-				if c.logger != nil {
-					c.logger.Infof("reconnecting to %s due to error: %s", c.dialAddress, err)
-				}
-				for {
-					// Shutdown current connection
-					if err := c.oConn.Close(); err != nil {
-						if c.logger != nil {
-							c.logger.Warnf("failed to properly close connection: %s", err)
-						}
-					}
-					// Set the intersect points from the cursor cache
-					if len(c.cursorCache) > 0 {
-						c.intersectPoints = c.cursorCache[:]
-					}
-					// Restart the connection
-					if err := c.Start(); err != nil {
-						if c.logger != nil {
-							c.logger.Infof("reconnecting to %s due to error: %s", c.dialAddress, err)
-						}
-						continue
-					}
-					break
-				}
-			} else {
-				// Pass error through our own error channel
-				c.errorChan <- err
-			}
+	var deadline time.Time
+	if c.startupTimeout > 0 {
+		deadline = time.Now().Add(c.startupTimeout)
+	}
+	retryDelay := startupRetryMinDelay
+	for {
+		conn, err := dialFunc(c.dialFamily, c.dialAddress)
+		if err == nil {
+			return conn, nil
 		}
-	}()
-	return nil
+		if !deadline.IsZero() && time.Now().Add(retryDelay).After(deadline) {
+			return nil, fmt.Errorf(
+				"failed to connect to node at %s within %s: %w",
+				c.dialAddress,
+				c.startupTimeout,
+				err,
+			)
+		}
+		if c.logger != nil {
+			c.logger.Warnf(
+				"failed to connect to node at %s, retrying in %s: %s",
+				c.dialAddress,
+				retryDelay,
+				err,
+			)
+		}
+		time.Sleep(retryDelay)
+		retryDelay = min(retryDelay*2, startupRetryMaxDelay)
+	}
+}
+
+// keepAliveConfig returns the keep-alive protocol config for the connection to the node
+func (c *ChainSync) keepAliveConfig() keepalive.Config {
+	opts := []keepalive.KeepAliveOptionFunc{}
+	if c.keepAliveInterval > 0 {
+		opts = append(opts, keepalive.WithPeriod(c.keepAliveInterval))
+	}
+	return keepalive.NewConfig(opts...)
 }
 
 func (c *ChainSync) handleRollBackward(
@@ -263,6 +510,9 @@ func (c *ChainSync) handleRollBackward(
 		nil,
 		NewRollbackEvent(point),
 	)
+	if c.handleRollbackStorm(point) {
+		return nil
+	}
 	c.eventChan <- evt
 	return nil
 }
@@ -275,36 +525,91 @@ func (c *ChainSync) handleRollForward(
 ) error {
 	switch v := blockData.(type) {
 	case ledger.Block:
-		evt := event.New("chainsync.block", time.Now(), NewBlockContext(v, c.networkMagic), NewBlockEvent(v, c.includeCbor))
-		c.eventChan <- evt
+		var blockEvt BlockEvent
+		if c.headersOnly {
+			// The header details and previous hash are read from the era-specific header type
+			header := blockHeader(v)
+			if header == nil {
+				header = v
+			}
+			blockEvt = NewBlockEventFromHeader(header, c.includeHeaderDetails)
+		} else {
+			blockEvt = NewBlockEvent(v, c.includeCbor, c.includeHeaderDetails)
+		}
+		evt := event.New("chainsync.block", time.Now(), NewBlockContext(v, c.networkMagic), blockEvt)
+		if c.headersOnly || !c.skipEmptyBlocks || len(v.Transactions()) > 0 {
+			c.sendEvent(evt, v.SlotNumber())
+		}
 		c.updateStatus(v.SlotNumber(), v.BlockNumber(), v.Hash(), tip.Point.Slot, hex.EncodeToString(tip.Point.Hash))
 	case ledger.BlockHeader:
+		if c.headersOnly {
+			evt := event.New(
+				"chainsync.block",
+				time.Now(),
+				NewBlockHeaderContext(v),
+				NewBlockEventFromHeader(v, c.includeHeaderDetails),
+			)
+			c.sendEvent(evt, v.SlotNumber())
+			c.updateStatus(v.SlotNumber(), v.BlockNumber(), v.Hash(), tip.Point.Slot, hex.EncodeToString(tip.Point.Hash))
+			return nil
+		}
 		blockSlot := v.SlotNumber()
 		blockHash, _ := hex.DecodeString(v.Hash())
 		block, err := c.oConn.BlockFetch().Client.GetBlock(ocommon.Point{Slot: blockSlot, Hash: blockHash})
 		if err != nil {
 			return err
 		}
-		blockEvt := event.New("chainsync.block", time.Now(), NewBlockHeaderContext(v), NewBlockEvent(block, c.includeCbor))
-		c.eventChan <- blockEvt
-		for t, transaction := range block.Transactions() {
-			txEvt := event.New("chainsync.transaction", time.Now(), NewTransactionContext(block, transaction, uint32(t), c.networkMagic), NewTransactionEvent(block, transaction, c.includeCbor))
-			c.eventChan <- txEvt
-		}
+		c.emitBlockEvents(block, NewBlockHeaderContext(v))
 		c.updateStatus(v.SlotNumber(), v.BlockNumber(), v.Hash(), tip.Point.Slot, hex.EncodeToString(tip.Point.Hash))
 	}
 	return nil
 }
 
 func (c *ChainSync) handleBlockFetchBlock(ctx blockfetch.CallbackContext, block ledger.Block) error {
+	c.emitBlockEvents(block, NewBlockContext(block, c.networkMagic))
+	c.updateStatus(
+		block.SlotNumber(),
+		block.BlockNumber(),
+		block.Hash(),
+		c.bulkRangeEnd.Slot,
+		hex.EncodeToString(c.bulkRangeEnd.Hash),
+	)
+	// Start normal chain-sync if we've reached the last block of our bulk range
+	if block.SlotNumber() == c.bulkRangeEnd.Slot {
+		if err := c.oConn.ChainSync().Client.Sync([]ocommon.Point{c.bulkRangeEnd}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// emitBlockEvents sends the events for a block and its transactions
+func (c *ChainSync) emitBlockEvents(block ledger.Block, blockCtx BlockContext) {
 	blockEvt := event.New(
 		"chainsync.block",
 		time.Now(),
-		NewBlockContext(block, c.networkMagic),
-		NewBlockEvent(block, c.includeCbor),
+		blockCtx,
+		NewBlockEvent(block, c.includeCbor, c.includeHeaderDetails),
 	)
-	c.eventChan <- blockEvt
+	if !c.skipEmptyBlocks || len(block.Transactions()) > 0 {
+		c.sendEvent(blockEvt, block.SlotNumber())
+	}
+	// Inputs are resolved against the ledger state before this block, since the block spends them
+	var prevPoint *ocommon.Point
+	if c.resolveInputsFunc != nil {
+		prevPoint = c.previousPoint()
+	}
 	for t, transaction := range block.Transactions() {
+		txPayload := NewTransactionEvent(
+			block,
+			transaction,
+			c.includeCbor,
+			WithTxMaxDatumBytes(c.maxDatumBytes),
+			WithTxMaxMetadataBytes(c.maxMetadataBytes),
+		)
+		if c.resolveInputsFunc != nil {
+			c.resolveInputs(&txPayload, transaction, prevPoint)
+		}
 		txEvt := event.New(
 			"chainsync.transaction",
 			time.Now(),
@@ -314,24 +619,94 @@ func (c *ChainSync) handleBlockFetchBlock(ctx blockfetch.CallbackContext, block
 				uint32(t),
 				c.networkMagic,
 			),
-			NewTransactionEvent(block, transaction, c.includeCbor),
+			txPayload,
 		)
-		c.eventChan <- txEvt
-	}
-	c.updateStatus(
-		block.SlotNumber(),
-		block.BlockNumber(),
-		block.Hash(),
-		c.bulkRangeEnd.Slot,
-		hex.EncodeToString(c.bulkRangeEnd.Hash),
-	)
-	// Start normal chain-sync if we've reached the last block of our bulk range
-	if block.SlotNumber() == c.bulkRangeEnd.Slot {
-		if err := c.oConn.ChainSync().Client.Sync([]ocommon.Point{c.bulkRangeEnd}); err != nil {
-			return err
+		if c.txBuffer != nil {
+			c.txBuffer.add(transaction.Hash(), txEvt)
+		}
+		c.sendEvent(txEvt, block.SlotNumber())
+		if c.emitCertificates {
+			for i, certificate := range transaction.Certificates() {
+				certEvt := event.New(
+					"chainsync.certificate",
+					time.Now(),
+					NewCertificateContext(
+						block,
+						transaction,
+						uint32(t),
+						uint32(i),
+						c.networkMagic,
+					),
+					NewCertificateEvent(block, transaction, certificate),
+				)
+				c.sendEvent(certEvt, block.SlotNumber())
+			}
+		}
+		if c.emitStakeRegistrations {
+			for i, certificate := range transaction.Certificates() {
+				stakeEvt, ok := NewStakeRegistrationEvent(block, transaction, certificate)
+				if !ok {
+					continue
+				}
+				c.sendEvent(
+					event.New(
+						stakeEvt.eventType(),
+						time.Now(),
+						NewCertificateContext(
+							block,
+							transaction,
+							uint32(t),
+							uint32(i),
+							c.networkMagic,
+						),
+						stakeEvt,
+					),
+					block.SlotNumber(),
+				)
+			}
+		}
+		if c.emitReferenceScripts {
+			for _, scriptEvt := range NewReferenceScriptEvents(block, transaction) {
+				c.sendEvent(
+					event.New(
+						"chainsync.reference_script",
+						time.Now(),
+						NewTransactionContext(
+							block,
+							transaction,
+							uint32(t),
+							c.networkMagic,
+						),
+						scriptEvt,
+					),
+					block.SlotNumber(),
+				)
+			}
+		}
+		if c.emitPoolEvents {
+			for i, certificate := range transaction.Certificates() {
+				poolEvt, ok := NewPoolRegistrationEvent(block, transaction, certificate)
+				if !ok {
+					continue
+				}
+				c.sendEvent(
+					event.New(
+						"chainsync.pool",
+						time.Now(),
+						NewCertificateContext(
+							block,
+							transaction,
+							uint32(t),
+							uint32(i),
+							c.networkMagic,
+						),
+						poolEvt,
+					),
+					block.SlotNumber(),
+				)
+			}
 		}
 	}
-	return nil
 }
 
 func (c *ChainSync) updateStatus(
@@ -343,10 +718,13 @@ func (c *ChainSync) updateStatus(
 ) {
 	// Update cursor cache
 	blockHashBytes, _ := hex.DecodeString(blockHash)
+	c.cursorMutex.Lock()
 	c.cursorCache = append(c.cursorCache, ocommon.Point{Slot: slotNumber, Hash: blockHashBytes})
 	if len(c.cursorCache) > cursorCacheSize {
 		c.cursorCache = c.cursorCache[len(c.cursorCache)-cursorCacheSize:]
 	}
+	c.cursorMutex.Unlock()
+	c.saveCursorFile(false)
 	// Determine if we've reached the chain tip
 	if !c.status.TipReached {
 		// Make sure we're past the end slot in any bulk range, since we don't update the tip during bulk sync
@@ -354,15 +732,42 @@ func (c *ChainSync) updateStatus(
 			// Make sure our current slot is equal/higher than our last known tip slot
 			if c.status.SlotNumber > 0 && slotNumber >= c.status.TipSlotNumber {
 				c.status.TipReached = true
+				c.ready.Store(true)
+				close(c.tipReachedChan)
 			}
 		}
 	}
 	c.status.SlotNumber = slotNumber
+	c.lastSlot.Store(slotNumber)
 	c.status.BlockNumber = blockNumber
 	c.status.BlockHash = blockHash
 	c.status.TipSlotNumber = tipSlotNumber
 	c.status.TipBlockHash = tipBlockHash
+	c.metrics.update(*(c.status))
 	if c.statusUpdateFunc != nil {
 		c.statusUpdateFunc(*(c.status))
 	}
 }
+
+// HasTypedPayload returns whether a block, transaction, rollback or reset event carries the context and payload
+// types produced by this input. Events from the replay input or passed through the project filter may carry
+// generic JSON values instead, which outputs that format specific event types should fall back to handling generically
+func HasTypedPayload(evt event.Event) bool {
+	switch event.BaseType(evt.Type) {
+	case "chainsync.block":
+		_, payloadOk := evt.Payload.(BlockEvent)
+		_, contextOk := evt.Context.(BlockContext)
+		return payloadOk && contextOk
+	case "chainsync.transaction":
+		_, payloadOk := evt.Payload.(TransactionEvent)
+		_, contextOk := evt.Context.(TransactionContext)
+		return payloadOk && contextOk
+	case "chainsync.rollback":
+		_, ok := evt.Payload.(RollbackEvent)
+		return ok
+	case "chainsync.reset":
+		_, ok := evt.Payload.(ResetEvent)
+		return ok
+	}
+	return false
+}
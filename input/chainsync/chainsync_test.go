@@ -0,0 +1,254 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/protocol"
+	ochainsync "github.com/blinklabs-io/gouroboros/protocol/chainsync"
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+	ouroboros_mock "github.com/blinklabs-io/ouroboros-mock"
+	"github.com/stretchr/testify/assert"
+)
+
+var errNodeUnavailable = errors.New("connection refused")
+
+func TestStartupRetry(t *testing.T) {
+	var dialAttempts atomic.Int32
+	handshakeChan := make(chan struct{})
+	dialFunc := func(network string, address string) (net.Conn, error) {
+		if dialAttempts.Add(1) < 3 {
+			return nil, errNodeUnavailable
+		}
+		client, server := net.Pipe()
+		go func() {
+			// Wait for the handshake from the client to show the connection is in use, then
+			// hang up since there isn't a real node on the other end
+			buf := make([]byte, 1)
+			if _, err := server.Read(buf); err == nil {
+				close(handshakeChan)
+			}
+			server.Close()
+		}()
+		return client, nil
+	}
+	c := chainsync.New(
+		chainsync.WithAddress("localhost:3001"),
+		chainsync.WithNetworkMagic(764824073),
+		chainsync.WithStartupRetry(true),
+		chainsync.WithDialFunc(dialFunc),
+	)
+	err := c.Start()
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, errNodeUnavailable)
+	assert.Equal(t, int32(3), dialAttempts.Load())
+	select {
+	case <-handshakeChan:
+	case <-time.After(time.Second):
+		t.Fatal("connection was not used after dialing succeeded")
+	}
+}
+
+func TestStartupRetryTimeout(t *testing.T) {
+	var dialAttempts atomic.Int32
+	dialFunc := func(network string, address string) (net.Conn, error) {
+		dialAttempts.Add(1)
+		return nil, errNodeUnavailable
+	}
+	c := chainsync.New(
+		chainsync.WithAddress("localhost:3001"),
+		chainsync.WithNetworkMagic(764824073),
+		chainsync.WithStartupRetry(true),
+		chainsync.WithStartupTimeout(time.Second),
+		chainsync.WithDialFunc(dialFunc),
+	)
+	err := c.Start()
+	assert.ErrorIs(t, err, errNodeUnavailable)
+	assert.Greater(t, dialAttempts.Load(), int32(1))
+}
+
+func TestStartupNoRetry(t *testing.T) {
+	var dialAttempts atomic.Int32
+	dialFunc := func(network string, address string) (net.Conn, error) {
+		dialAttempts.Add(1)
+		return nil, errNodeUnavailable
+	}
+	c := chainsync.New(
+		chainsync.WithAddress("localhost:3001"),
+		chainsync.WithNetworkMagic(764824073),
+		chainsync.WithDialFunc(dialFunc),
+	)
+	err := c.Start()
+	assert.ErrorIs(t, err, errNodeUnavailable)
+	assert.Equal(t, int32(1), dialAttempts.Load())
+}
+
+func TestIntersectNotFoundReset(t *testing.T) {
+	tip := ochainsync.Tip{
+		BlockNumber: 12345,
+		Point: ocommon.NewPoint(
+			23456,
+			[]byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef},
+		),
+	}
+	findIntersect := ouroboros_mock.ConversationEntryInput{
+		ProtocolId:  ochainsync.ProtocolIdNtC,
+		MessageType: ochainsync.MessageTypeFindIntersect,
+	}
+	conversation := []ouroboros_mock.ConversationEntry{
+		ouroboros_mock.ConversationEntryHandshakeRequestGeneric,
+		ouroboros_mock.ConversationEntryHandshakeNtCResponse,
+		// Our intersect point is unknown to the node
+		findIntersect,
+		ouroboros_mock.ConversationEntryOutput{
+			ProtocolId: ochainsync.ProtocolIdNtC,
+			IsResponse: true,
+			Messages: []protocol.Message{
+				ochainsync.NewMsgIntersectNotFound(tip),
+			},
+		},
+		// Lookup of current tip
+		findIntersect,
+		ouroboros_mock.ConversationEntryOutput{
+			ProtocolId: ochainsync.ProtocolIdNtC,
+			IsResponse: true,
+			Messages: []protocol.Message{
+				ochainsync.NewMsgIntersectNotFound(tip),
+			},
+		},
+		// Sync from current tip
+		findIntersect,
+		ouroboros_mock.ConversationEntryOutput{
+			ProtocolId: ochainsync.ProtocolIdNtC,
+			IsResponse: true,
+			Messages: []protocol.Message{
+				ochainsync.NewMsgIntersectFound(tip.Point, tip),
+			},
+		},
+	}
+	dialFunc := func(network string, address string) (net.Conn, error) {
+		return ouroboros_mock.NewConnection(
+			ouroboros_mock.ProtocolRoleClient,
+			conversation,
+		), nil
+	}
+	c := chainsync.New(
+		chainsync.WithSocketPath("/mock/node.socket"),
+		chainsync.WithNetworkMagic(ouroboros_mock.MockNetworkMagic),
+		chainsync.WithIntersectPoints(
+			[]ocommon.Point{ocommon.NewPoint(1, bytes.Repeat([]byte{0xaa}, 32))},
+		),
+		chainsync.WithAutoReconnect(false),
+		chainsync.WithDialFunc(dialFunc),
+	)
+	assert.NoError(t, c.Start())
+	defer func() {
+		_ = c.Stop()
+	}()
+	select {
+	case evt := <-c.OutputChan():
+		assert.Equal(t, "chainsync.reset", evt.Type)
+		assert.Equal(
+			t,
+			chainsync.ResetEvent{
+				Reason:     chainsync.ResetReasonIntersectNotFound,
+				BlockHash:  "0123456789abcdef",
+				SlotNumber: 23456,
+			},
+			evt.Payload,
+		)
+	case <-time.After(time.Second):
+		t.Fatal("did not receive reset event")
+	}
+}
+
+func TestIntersectPointsCleanup(t *testing.T) {
+	hashA := bytes.Repeat([]byte{0xaa}, 32)
+	hashB := bytes.Repeat([]byte{0xbb}, 32)
+	// Duplicate, out of order and invalid points are cleaned up before being sent to the node
+	expectedPoints := []ocommon.Point{
+		ocommon.NewPoint(300, hashB),
+		ocommon.NewPoint(200, hashA),
+		ocommon.NewPoint(100, hashA),
+	}
+	c := chainsync.New(
+		chainsync.WithSocketPath("/mock/node.socket"),
+		chainsync.WithNetworkMagic(ouroboros_mock.MockNetworkMagic),
+		chainsync.WithIntersectPoints(
+			[]ocommon.Point{
+				ocommon.NewPoint(100, hashA),
+				ocommon.NewPoint(300, hashB),
+				ocommon.NewPoint(250, []byte{0xab, 0xcd}),
+				ocommon.NewPoint(200, hashA),
+				ocommon.NewPoint(100, hashA),
+				ocommon.NewPoint(400, nil),
+			},
+		),
+		chainsync.WithAutoReconnect(false),
+		chainsync.WithDialFunc(newMockDialFunc(t, expectedPoints)),
+	)
+	assert.NoError(t, c.Start())
+	assert.NoError(t, c.Stop())
+}
+
+func TestPeerFailover(t *testing.T) {
+	points := []ocommon.Point{
+		ocommon.NewPoint(100, bytes.Repeat([]byte{0xaa}, 32)),
+	}
+	mockDialFunc := newMockDialFunc(t, points)
+	var dialed []string
+	dialFunc := func(network string, address string) (net.Conn, error) {
+		dialed = append(dialed, address)
+		if address == "peer-b:3001" {
+			return nil, errNodeUnavailable
+		}
+		return mockDialFunc(network, address)
+	}
+	c := chainsync.New(
+		chainsync.WithPeers([]string{"peer-a:3001", "peer-b:3001", "peer-c:3001"}),
+		chainsync.WithNtcTcp(true),
+		chainsync.WithNetworkMagic(ouroboros_mock.MockNetworkMagic),
+		chainsync.WithIntersectPoints(points),
+		chainsync.WithAutoReconnect(false),
+		chainsync.WithDialFunc(dialFunc),
+	)
+	assert.NoError(t, c.Start())
+	assert.Equal(t, []string{"peer-a:3001"}, dialed)
+	// An unavailable peer is skipped in favor of the next one
+	c.Reconnect(errors.New("connection reset"))
+	assert.Equal(t, []string{"peer-a:3001", "peer-b:3001", "peer-c:3001"}, dialed)
+	assert.NoError(t, c.Stop())
+}
+
+func TestConnectionTimeouts(t *testing.T) {
+	// Defaults
+	c := chainsync.New()
+	assert.Equal(t, 30*time.Second, c.ConnectTimeout())
+	assert.Equal(t, 60*time.Second, c.KeepAliveConfig().Period)
+	// Configured values
+	c = chainsync.New(
+		chainsync.WithConnectTimeout(5*time.Second),
+		chainsync.WithKeepAliveInterval(15*time.Second),
+	)
+	assert.Equal(t, 5*time.Second, c.ConnectTimeout())
+	assert.Equal(t, 15*time.Second, c.KeepAliveConfig().Period)
+}
@@ -0,0 +1,167 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+)
+
+const (
+	// Minimum time between writes of the cursor file
+	cursorFileWriteInterval = 5 * time.Second
+)
+
+type cursorFile struct {
+	Points []cursorFilePoint `json:"points"`
+}
+
+type cursorFilePoint struct {
+	Slot uint64 `json:"slot"`
+	Hash string `json:"hash"`
+}
+
+// readCursorFile returns the chain points stored in the cursor file. No points are returned if the file
+// does not exist yet
+func readCursorFile(path string) ([]ocommon.Point, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var tmpCursor cursorFile
+	if err := json.Unmarshal(data, &tmpCursor); err != nil {
+		return nil, fmt.Errorf("failed to parse cursor file %s: %w", path, err)
+	}
+	points := []ocommon.Point{}
+	for _, point := range tmpCursor.Points {
+		hash, err := hex.DecodeString(point.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block hash in cursor file %s: %w", path, err)
+		}
+		points = append(points, ocommon.NewPoint(point.Slot, hash))
+	}
+	// Only keep the most recent points
+	if len(points) > cursorCacheSize {
+		points = points[len(points)-cursorCacheSize:]
+	}
+	return points, nil
+}
+
+// writeCursorFile atomically replaces the cursor file with the specified chain points. The points are
+// written to a temporary file in the same directory, which is then renamed over the original
+func writeCursorFile(path string, points []ocommon.Point) error {
+	tmpCursor := cursorFile{
+		Points: []cursorFilePoint{},
+	}
+	for _, point := range points {
+		tmpCursor.Points = append(
+			tmpCursor.Points,
+			cursorFilePoint{
+				Slot: point.Slot,
+				Hash: hex.EncodeToString(point.Hash),
+			},
+		)
+	}
+	data, err := json.Marshal(&tmpCursor)
+	if err != nil {
+		return err
+	}
+	tmpFile, err := os.CreateTemp(
+		filepath.Dir(path),
+		filepath.Base(path)+".tmp*",
+	)
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// loadCursorFile sets our intersect points from the cursor file, if one is configured and present
+func (c *ChainSync) loadCursorFile() error {
+	if c.cursorFile == "" {
+		return nil
+	}
+	points, err := readCursorFile(c.cursorFile)
+	if err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		return nil
+	}
+	if c.logger != nil {
+		c.logger.Infof(
+			"loaded %d intersect point(s) from cursor file %s",
+			len(points),
+			c.cursorFile,
+		)
+	}
+	c.intersectPoints = points
+	c.intersectTip = false
+	c.cursorCache = points[:]
+	return nil
+}
+
+// saveCursorFile writes the cursor cache to the cursor file, if one is configured. Writes are limited to
+// once per cursorFileWriteInterval unless force is specified
+func (c *ChainSync) saveCursorFile(force bool) {
+	if c.cursorFile == "" {
+		return
+	}
+	c.cursorMutex.Lock()
+	defer c.cursorMutex.Unlock()
+	if !force && time.Since(c.cursorFileLastWrite) < cursorFileWriteInterval {
+		return
+	}
+	if err := writeCursorFile(c.cursorFile, c.cursorCache); err != nil {
+		if c.logger != nil {
+			c.logger.Warnf(
+				"failed to write cursor file %s: %s",
+				c.cursorFile,
+				err,
+			)
+		}
+		return
+	}
+	c.cursorFileLastWrite = time.Now()
+}
@@ -0,0 +1,126 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/protocol"
+	ochainsync "github.com/blinklabs-io/gouroboros/protocol/chainsync"
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+	ouroboros_mock "github.com/blinklabs-io/ouroboros-mock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMockDialFunc returns a DialFunc that connects to a mock node which expects a FindIntersect for the
+// specified points and replies that the first of them was found
+func newMockDialFunc(t *testing.T, points []ocommon.Point) chainsync.DialFunc {
+	tip := ochainsync.Tip{
+		BlockNumber: 12345,
+		Point:       points[0],
+	}
+	conversation := []ouroboros_mock.ConversationEntry{
+		ouroboros_mock.ConversationEntryHandshakeRequestGeneric,
+		ouroboros_mock.ConversationEntryHandshakeNtCResponse,
+		ouroboros_mock.ConversationEntryInput{
+			ProtocolId:      ochainsync.ProtocolIdNtC,
+			Message:         ochainsync.NewMsgFindIntersect(points),
+			MsgFromCborFunc: ochainsync.NewMsgFromCborNtC,
+		},
+		ouroboros_mock.ConversationEntryOutput{
+			ProtocolId: ochainsync.ProtocolIdNtC,
+			IsResponse: true,
+			Messages: []protocol.Message{
+				ochainsync.NewMsgIntersectFound(points[0], tip),
+			},
+		},
+	}
+	return func(network string, address string) (net.Conn, error) {
+		mockConn := ouroboros_mock.NewConnection(
+			ouroboros_mock.ProtocolRoleClient,
+			conversation,
+		)
+		go func() {
+			err, ok := <-mockConn.(*ouroboros_mock.Connection).ErrorChan()
+			if ok && err != nil {
+				t.Errorf("unexpected mock connection error: %s", err)
+			}
+		}()
+		return mockConn, nil
+	}
+}
+
+func TestCursorFileResume(t *testing.T) {
+	cursorPath := filepath.Join(t.TempDir(), "cursor.json")
+	err := os.WriteFile(
+		cursorPath,
+		[]byte(`{"points":[{"slot":100,"hash":"abababababababababababababababababababababababababababababababab"},{"slot":200,"hash":"efefefefefefefefefefefefefefefefefefefefefefefefefefefefefefefef"}]}`),
+		0o644,
+	)
+	assert.NoError(t, err)
+	// Points are used newest first
+	expectedPoints := []ocommon.Point{
+		ocommon.NewPoint(200, bytes.Repeat([]byte{0xef}, 32)),
+		ocommon.NewPoint(100, bytes.Repeat([]byte{0xab}, 32)),
+	}
+	c := chainsync.New(
+		chainsync.WithSocketPath("/mock/node.socket"),
+		chainsync.WithNetworkMagic(ouroboros_mock.MockNetworkMagic),
+		chainsync.WithIntersectTip(true),
+		chainsync.WithAutoReconnect(false),
+		chainsync.WithCursorFile(cursorPath),
+		chainsync.WithDialFunc(newMockDialFunc(t, expectedPoints)),
+	)
+	assert.NoError(t, c.Start())
+	assert.NoError(t, c.Stop())
+}
+
+func TestCursorFileFirstRun(t *testing.T) {
+	cursorPath := filepath.Join(t.TempDir(), "cursor.json")
+	intersectPoints := []ocommon.Point{
+		ocommon.NewPoint(300, bytes.Repeat([]byte{0x23}, 32)),
+	}
+	c := chainsync.New(
+		chainsync.WithSocketPath("/mock/node.socket"),
+		chainsync.WithNetworkMagic(ouroboros_mock.MockNetworkMagic),
+		chainsync.WithIntersectPoints(intersectPoints),
+		chainsync.WithAutoReconnect(false),
+		chainsync.WithCursorFile(cursorPath),
+		chainsync.WithDialFunc(newMockDialFunc(t, intersectPoints)),
+	)
+	assert.NoError(t, c.Start())
+	assert.NoError(t, c.Stop())
+}
+
+func TestCursorFileWrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	cursorPath := filepath.Join(tmpDir, "cursor.json")
+	c := chainsync.New(
+		chainsync.WithCursorFile(cursorPath),
+	)
+	c.UpdateStatus(100, 10, "abcd", 200, "ef01")
+	data, err := os.ReadFile(cursorPath)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"points":[{"slot":100,"hash":"abcd"}]}`, string(data))
+	// The temporary file should have been renamed into place
+	entries, err := os.ReadDir(tmpDir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
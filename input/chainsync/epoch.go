@@ -0,0 +1,99 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"fmt"
+	"time"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+)
+
+// epochParams describes the slot layout of a network's Byron and Shelley-based eras. Slots in the Shelley-based
+// eras are always 1 second long
+type epochParams struct {
+	systemStart        time.Time
+	byronSlotLength    time.Duration
+	byronEpochLength   uint64
+	shelleyStartSlot   uint64
+	shelleyStartEpoch  uint64
+	shelleyEpochLength uint64
+}
+
+// Epoch layouts for the well-known networks, keyed by network magic
+var networkEpochParams = map[uint32]epochParams{
+	ouroboros.NetworkMainnet.NetworkMagic: {
+		systemStart:        time.Date(2017, time.September, 23, 21, 44, 51, 0, time.UTC),
+		byronSlotLength:    20 * time.Second,
+		byronEpochLength:   21600,
+		shelleyStartSlot:   4492800,
+		shelleyStartEpoch:  208,
+		shelleyEpochLength: 432000,
+	},
+	ouroboros.NetworkPreprod.NetworkMagic: {
+		systemStart:        time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC),
+		byronSlotLength:    20 * time.Second,
+		byronEpochLength:   21600,
+		shelleyStartSlot:   86400,
+		shelleyStartEpoch:  4,
+		shelleyEpochLength: 432000,
+	},
+	ouroboros.NetworkPreview.NetworkMagic: {
+		systemStart:        time.Date(2022, time.October, 25, 0, 0, 0, 0, time.UTC),
+		shelleyEpochLength: 86400,
+	},
+}
+
+// EpochFromSlot returns the epoch containing the specified slot on the network with the specified network magic.
+// Only the well-known networks are supported
+func EpochFromSlot(networkMagic uint32, slot uint64) (uint64, error) {
+	params, ok := networkEpochParams[networkMagic]
+	if !ok {
+		return 0, fmt.Errorf("unknown epoch layout for network magic %d", networkMagic)
+	}
+	if slot < params.shelleyStartSlot {
+		return slot / params.byronEpochLength, nil
+	}
+	return params.shelleyStartEpoch + (slot-params.shelleyStartSlot)/params.shelleyEpochLength, nil
+}
+
+// SlotToTime returns the start time of the specified slot on the network with the specified network magic. Only the
+// well-known networks are supported
+func SlotToTime(networkMagic uint32, slot uint64) (time.Time, error) {
+	params, ok := networkEpochParams[networkMagic]
+	if !ok {
+		return time.Time{}, fmt.Errorf("unknown slot layout for network magic %d", networkMagic)
+	}
+	if slot < params.shelleyStartSlot {
+		return params.systemStart.Add(time.Duration(slot) * params.byronSlotLength), nil
+	}
+	shelleyStart := params.systemStart.Add(time.Duration(params.shelleyStartSlot) * params.byronSlotLength)
+	return shelleyStart.Add(time.Duration(slot-params.shelleyStartSlot) * time.Second), nil
+}
+
+// BlockTime returns the time of the block's slot
+func (c BlockContext) BlockTime() (time.Time, error) {
+	return SlotToTime(c.NetworkMagic, c.SlotNumber)
+}
+
+// BlockTime returns the time of the slot of the block containing the transaction
+func (c TransactionContext) BlockTime() (time.Time, error) {
+	return SlotToTime(c.NetworkMagic, c.SlotNumber)
+}
+
+// BlockTime returns the time of the slot of the block containing the certificate
+func (c CertificateContext) BlockTime() (time.Time, error) {
+	return SlotToTime(c.NetworkMagic, c.SlotNumber)
+}
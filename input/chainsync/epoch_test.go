@@ -0,0 +1,73 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpochFromSlot(t *testing.T) {
+	testDefs := []struct {
+		networkMagic uint32
+		slot         uint64
+		epoch        uint64
+	}{
+		// Mainnet Byron
+		{networkMagic: 764824073, slot: 0, epoch: 0},
+		{networkMagic: 764824073, slot: 4492799, epoch: 207},
+		// Mainnet Shelley and later
+		{networkMagic: 764824073, slot: 4492800, epoch: 208},
+		{networkMagic: 764824073, slot: 134092810, epoch: 508},
+		// Preprod
+		{networkMagic: 1, slot: 86399, epoch: 3},
+		{networkMagic: 1, slot: 86400, epoch: 4},
+		// Preview
+		{networkMagic: 2, slot: 172800, epoch: 2},
+	}
+	for _, testDef := range testDefs {
+		epoch, err := chainsync.EpochFromSlot(testDef.networkMagic, testDef.slot)
+		assert.NoError(t, err)
+		assert.Equal(t, testDef.epoch, epoch, "slot %d", testDef.slot)
+	}
+	_, err := chainsync.EpochFromSlot(12345, 0)
+	assert.Error(t, err)
+}
+
+func TestSlotToTime(t *testing.T) {
+	testDefs := []struct {
+		networkMagic uint32
+		slot         uint64
+		time         time.Time
+	}{
+		// Mainnet Byron
+		{networkMagic: 764824073, slot: 0, time: time.Date(2017, 9, 23, 21, 44, 51, 0, time.UTC)},
+		// Mainnet Shelley and later
+		{networkMagic: 764824073, slot: 4492800, time: time.Date(2020, 7, 29, 21, 44, 51, 0, time.UTC)},
+		{networkMagic: 764824073, slot: 134092810, time: time.Date(2024, 9, 6, 21, 45, 1, 0, time.UTC)},
+		// Preview
+		{networkMagic: 2, slot: 172800, time: time.Date(2022, 10, 27, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, testDef := range testDefs {
+		slotTime, err := chainsync.SlotToTime(testDef.networkMagic, testDef.slot)
+		assert.NoError(t, err)
+		assert.True(t, testDef.time.Equal(slotTime), "slot %d: %s", testDef.slot, slotTime)
+	}
+	_, err := chainsync.SlotToTime(12345, 0)
+	assert.Error(t, err)
+}
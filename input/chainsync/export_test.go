@@ -0,0 +1,112 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blinklabs-io/adder/event"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	ochainsync "github.com/blinklabs-io/gouroboros/protocol/chainsync"
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+	"github.com/blinklabs-io/gouroboros/protocol/keepalive"
+)
+
+// UpdateStatus exposes updateStatus for tests
+func (c *ChainSync) UpdateStatus(
+	slotNumber uint64,
+	blockNumber uint64,
+	blockHash string,
+	tipSlotNumber uint64,
+	tipBlockHash string,
+) {
+	c.updateStatus(slotNumber, blockNumber, blockHash, tipSlotNumber, tipBlockHash)
+}
+
+// BufferTransaction adds a transaction event to the recent transaction buffer for tests
+func (c *ChainSync) BufferTransaction(hash string, evt event.Event) {
+	c.txBuffer.add(hash, evt)
+}
+
+// HandleEpoch exposes handleEpoch for tests
+func (c *ChainSync) HandleEpoch(ctx *gin.Context) {
+	c.handleEpoch(ctx)
+}
+
+// HandleTransaction exposes handleTransaction for tests
+func (c *ChainSync) HandleTransaction(ctx *gin.Context) {
+	c.handleTransaction(ctx)
+}
+
+// SeekSlot feeds the provided headers after an initial rollback to origin to a slot seeker for the specified
+// slot and returns its result
+func SeekSlot(slot uint64, headers []ledger.BlockHeader) (ocommon.Point, error) {
+	s := newSlotSeeker(slot)
+	if err := s.handleRollBackward(ochainsync.CallbackContext{}, ocommon.NewPointOrigin(), ochainsync.Tip{}); err != nil {
+		return ocommon.Point{}, err
+	}
+	for _, header := range headers {
+		if err := s.handleRollForward(ochainsync.CallbackContext{}, 0, header, ochainsync.Tip{}); err != nil {
+			return ocommon.Point{}, err
+		}
+	}
+	select {
+	case result := <-s.resultChan:
+		return result.point, result.err
+	default:
+		return ocommon.Point{}, errors.New("slot seeker did not finish")
+	}
+}
+
+// HandleRollBackward exposes handleRollBackward for tests
+func (c *ChainSync) HandleRollBackward(point ocommon.Point) error {
+	return c.handleRollBackward(ochainsync.CallbackContext{}, point, ochainsync.Tip{})
+}
+
+// HandleRollForward exposes handleRollForward for tests
+func (c *ChainSync) HandleRollForward(blockData interface{}) error {
+	return c.handleRollForward(ochainsync.CallbackContext{}, 0, blockData, ochainsync.Tip{})
+}
+
+// Reconnect exposes reconnect for tests
+func (c *ChainSync) Reconnect(err error) {
+	c.reconnect(err)
+}
+
+// KeepAliveConfig exposes keepAliveConfig for tests
+func (c *ChainSync) KeepAliveConfig() keepalive.Config {
+	return c.keepAliveConfig()
+}
+
+// ConnectTimeout returns the configured connect timeout for tests
+func (c *ChainSync) ConnectTimeout() time.Duration {
+	return c.connectTimeout
+}
+
+// EmitBlockEvents exposes emitBlockEvents for tests
+func (c *ChainSync) EmitBlockEvents(block ledger.Block) {
+	c.emitBlockEvents(block, NewBlockContext(block, c.networkMagic))
+}
+
+// WithResolveInputsFunc specifies the function used to resolve transaction inputs for tests
+func WithResolveInputsFunc(resolveInputsFunc ResolveInputsFunc) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.resolveInputsFunc = resolveInputsFunc
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"encoding/hex"
+	"sort"
+	"sync"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+// ExtraExtractorFunc returns era-specific data for a transaction, which is added to the Extra map
+// of the resulting TransactionEvent. Returning an empty map leaves Extra unset
+type ExtraExtractorFunc func(tx ledger.Transaction) map[string]any
+
+var (
+	extraExtractors = map[uint8]ExtraExtractorFunc{
+		ledger.EraIdConway: conwayExtraExtractor,
+	}
+	extraExtractorsMutex sync.RWMutex
+)
+
+// RegisterExtraExtractor sets the extractor used to populate Extra for transactions in the specified era,
+// replacing any existing extractor for that era. Passing a nil extractor removes it
+func RegisterExtraExtractor(eraId uint8, extractor ExtraExtractorFunc) {
+	extraExtractorsMutex.Lock()
+	defer extraExtractorsMutex.Unlock()
+	if extractor == nil {
+		delete(extraExtractors, eraId)
+		return
+	}
+	extraExtractors[eraId] = extractor
+}
+
+func extractExtra(eraId uint8, tx ledger.Transaction) map[string]any {
+	extraExtractorsMutex.RLock()
+	extractor, ok := extraExtractors[eraId]
+	extraExtractorsMutex.RUnlock()
+	if !ok {
+		return nil
+	}
+	extra := extractor(tx)
+	if len(extra) == 0 {
+		return nil
+	}
+	return extra
+}
+
+type GovAnchor struct {
+	Url      string `json:"url"`
+	DataHash string `json:"dataHash"`
+}
+
+type VotingProcedure struct {
+	VoterType     uint8      `json:"voterType"`
+	VoterHash     string     `json:"voterHash"`
+	GovActionTxId string     `json:"govActionTxId"`
+	GovActionIdx  uint32     `json:"govActionIdx"`
+	Vote          uint8      `json:"vote"`
+	Anchor        *GovAnchor `json:"anchor,omitempty"`
+}
+
+type ProposalProcedure struct {
+	Deposit       uint64    `json:"deposit"`
+	RewardAccount string    `json:"rewardAccount"`
+	GovActionType uint      `json:"govActionType"`
+	Anchor        GovAnchor `json:"anchor"`
+}
+
+func conwayExtraExtractor(tx ledger.Transaction) map[string]any {
+	extra := map[string]any{}
+	if votes := convertVotingProcedures(tx.VotingProcedures()); len(votes) > 0 {
+		extra["votingProcedures"] = votes
+	}
+	if len(tx.ProposalProcedures()) > 0 {
+		proposals := make([]ProposalProcedure, 0, len(tx.ProposalProcedures()))
+		for _, proposal := range tx.ProposalProcedures() {
+			proposals = append(
+				proposals,
+				ProposalProcedure{
+					Deposit:       proposal.Deposit,
+					RewardAccount: proposal.RewardAccount.String(),
+					GovActionType: proposal.GovAction.Type,
+					Anchor:        convertGovAnchor(proposal.Anchor),
+				},
+			)
+		}
+		extra["proposalProcedures"] = proposals
+	}
+	if tx.CurrentTreasuryValue() != 0 {
+		extra["currentTreasuryValue"] = tx.CurrentTreasuryValue()
+	}
+	if tx.Donation() != 0 {
+		extra["donation"] = tx.Donation()
+	}
+	return extra
+}
+
+// convertVotingProcedures flattens the ledger voting procedures, which are keyed by pointers and can't be
+// represented directly in JSON. The result is sorted to give a stable ordering
+func convertVotingProcedures(
+	votingProcedures ledger.VotingProcedures,
+) []VotingProcedure {
+	ret := []VotingProcedure{}
+	for voter, votes := range votingProcedures {
+		for govActionId, vote := range votes {
+			tmpVote := VotingProcedure{
+				VoterType:     voter.Type,
+				VoterHash:     hex.EncodeToString(voter.Hash[:]),
+				GovActionTxId: hex.EncodeToString(govActionId.TransactionId[:]),
+				GovActionIdx:  govActionId.GovActionIdx,
+				Vote:          vote.Vote,
+			}
+			if vote.Anchor != nil {
+				anchor := convertGovAnchor(*vote.Anchor)
+				tmpVote.Anchor = &anchor
+			}
+			ret = append(ret, tmpVote)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].VoterHash != ret[j].VoterHash {
+			return ret[i].VoterHash < ret[j].VoterHash
+		}
+		if ret[i].GovActionTxId != ret[j].GovActionTxId {
+			return ret[i].GovActionTxId < ret[j].GovActionTxId
+		}
+		return ret[i].GovActionIdx < ret[j].GovActionIdx
+	})
+	return ret
+}
+
+func convertGovAnchor(anchor ledger.GovAnchor) GovAnchor {
+	return GovAnchor{
+		Url:      anchor.Url,
+		DataHash: hex.EncodeToString(anchor.DataHash[:]),
+	}
+}
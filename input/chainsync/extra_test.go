@@ -0,0 +1,119 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockConwayTransaction struct {
+	mockTransaction
+	votingProcedures ledger.VotingProcedures
+	donation         uint64
+}
+
+func (t mockConwayTransaction) VotingProcedures() ledger.VotingProcedures {
+	return t.votingProcedures
+}
+
+func (t mockConwayTransaction) ProposalProcedures() []ledger.ProposalProcedure {
+	return nil
+}
+
+func (t mockConwayTransaction) CurrentTreasuryValue() int64 { return 0 }
+
+func (t mockConwayTransaction) Donation() uint64 { return t.donation }
+
+func newMockConwayTransaction() mockConwayTransaction {
+	voter := &ledger.Voter{Type: ledger.VoterTypeDRepKeyHash}
+	voter.Hash[0] = 0x01
+	govActionId := &ledger.GovActionId{GovActionIdx: 2}
+	govActionId.TransactionId[0] = 0xab
+	return mockConwayTransaction{
+		votingProcedures: ledger.VotingProcedures{
+			voter: {
+				govActionId: ledger.VotingProcedure{
+					Vote: ledger.GovVoteYes,
+					Anchor: &ledger.GovAnchor{
+						Url: "https://example.com/rationale.json",
+					},
+				},
+			},
+		},
+		donation: 1000000,
+	}
+}
+
+func TestNewTransactionEventConwayExtra(t *testing.T) {
+	tx := newMockConwayTransaction()
+	block := mockBlock{era: ledger.Era{Id: ledger.EraIdConway, Name: "Conway"}}
+
+	evt := chainsync.NewTransactionEvent(block, tx, false)
+
+	assert.Equal(t, uint64(1000000), evt.Extra["donation"])
+	assert.NotContains(t, evt.Extra, "proposalProcedures")
+	assert.NotContains(t, evt.Extra, "currentTreasuryValue")
+	votes, ok := evt.Extra["votingProcedures"].([]chainsync.VotingProcedure)
+	if !ok {
+		t.Fatalf("unexpected type for voting procedures: %T", evt.Extra["votingProcedures"])
+	}
+	if assert.Len(t, votes, 1) {
+		assert.Equal(t, ledger.VoterTypeDRepKeyHash, votes[0].VoterType)
+		assert.Equal(t, "01000000000000000000000000000000000000000000000000000000", votes[0].VoterHash)
+		assert.Equal(t, uint32(2), votes[0].GovActionIdx)
+		assert.Equal(t, ledger.GovVoteYes, votes[0].Vote)
+		assert.Equal(t, "https://example.com/rationale.json", votes[0].Anchor.Url)
+	}
+
+	data, err := json.Marshal(evt)
+	if err != nil {
+		t.Fatalf("unexpected error marshaling event: %s", err)
+	}
+	assert.Contains(t, string(data), `"extra":{"donation":1000000,"votingProcedures":[`)
+}
+
+func TestNewTransactionEventPreConwayExtra(t *testing.T) {
+	tx := newMockConwayTransaction()
+	block := mockBlock{era: ledger.Era{Id: ledger.EraIdBabbage, Name: "Babbage"}}
+
+	evt := chainsync.NewTransactionEvent(block, tx, false)
+
+	assert.Nil(t, evt.Extra)
+	data, err := json.Marshal(evt)
+	if err != nil {
+		t.Fatalf("unexpected error marshaling event: %s", err)
+	}
+	assert.NotContains(t, string(data), `"extra"`)
+}
+
+func TestRegisterExtraExtractor(t *testing.T) {
+	chainsync.RegisterExtraExtractor(
+		ledger.EraIdBabbage,
+		func(tx ledger.Transaction) map[string]any {
+			return map[string]any{"fee": tx.Fee()}
+		},
+	)
+	defer chainsync.RegisterExtraExtractor(ledger.EraIdBabbage, nil)
+	block := mockBlock{era: ledger.Era{Id: ledger.EraIdBabbage, Name: "Babbage"}}
+
+	evt := chainsync.NewTransactionEvent(block, mockTransaction{fee: 42}, false)
+
+	assert.Equal(t, map[string]any{"fee": uint64(42)}, evt.Extra)
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"encoding/hex"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+// HeaderDetails contains block producer details from a block header. These are only available for
+// Shelley and later eras
+type HeaderDetails struct {
+	VrfKey               string `json:"vrfKey"`
+	VrfOutput            string `json:"vrfOutput,omitempty"`
+	OpCertHotVkey        string `json:"opCertHotVkey"`
+	OpCertSequenceNumber uint32 `json:"opCertSequenceNumber"`
+	OpCertKesPeriod      uint32 `json:"opCertKesPeriod"`
+	ProtocolVersion      struct {
+		Major uint64 `json:"major"`
+		Minor uint64 `json:"minor"`
+	} `json:"protocolVersion"`
+}
+
+// NewHeaderDetails returns the header details for the specified block header, or nil if the header is from an
+// era that doesn't have them
+func NewHeaderDetails(header ledger.BlockHeader) *HeaderDetails {
+	switch h := header.(type) {
+	case *ledger.ConwayBlockHeader:
+		return newBabbageHeaderDetails(&h.BabbageBlockHeader)
+	case *ledger.BabbageBlockHeader:
+		return newBabbageHeaderDetails(h)
+	case *ledger.AlonzoBlockHeader:
+		return newShelleyHeaderDetails(&h.ShelleyBlockHeader)
+	case *ledger.MaryBlockHeader:
+		return newShelleyHeaderDetails(&h.ShelleyBlockHeader)
+	case *ledger.AllegraBlockHeader:
+		return newShelleyHeaderDetails(&h.ShelleyBlockHeader)
+	case *ledger.ShelleyBlockHeader:
+		return newShelleyHeaderDetails(h)
+	}
+	return nil
+}
+
+// blockHeader returns the header for the specified block, or nil if it's from an unknown era
+func blockHeader(block ledger.Block) ledger.BlockHeader {
+	switch b := block.(type) {
+	case *ledger.ConwayBlock:
+		return b.Header
+	case *ledger.BabbageBlock:
+		return b.Header
+	case *ledger.AlonzoBlock:
+		return b.Header
+	case *ledger.MaryBlock:
+		return b.Header
+	case *ledger.AllegraBlock:
+		return b.Header
+	case *ledger.ShelleyBlock:
+		return b.Header
+	case *ledger.ByronMainBlock:
+		return b.Header
+	case *ledger.ByronEpochBoundaryBlock:
+		return b.Header
+	}
+	return nil
+}
+
+// prevHash returns the hash of the previous block from the specified block header, or an empty string if the
+// header is from an unknown era
+func prevHash(header ledger.BlockHeader) string {
+	switch h := header.(type) {
+	case *ledger.ConwayBlockHeader:
+		return h.Body.PrevHash.String()
+	case *ledger.BabbageBlockHeader:
+		return h.Body.PrevHash.String()
+	case *ledger.AlonzoBlockHeader:
+		return h.Body.PrevHash.String()
+	case *ledger.MaryBlockHeader:
+		return h.Body.PrevHash.String()
+	case *ledger.AllegraBlockHeader:
+		return h.Body.PrevHash.String()
+	case *ledger.ShelleyBlockHeader:
+		return h.Body.PrevHash.String()
+	case *ledger.ByronMainBlockHeader:
+		return h.PrevBlock.String()
+	case *ledger.ByronEpochBoundaryBlockHeader:
+		return h.PrevBlock.String()
+	}
+	return ""
+}
+
+func newBabbageHeaderDetails(h *ledger.BabbageBlockHeader) *HeaderDetails {
+	if h == nil {
+		return nil
+	}
+	ret := &HeaderDetails{
+		VrfKey:               hex.EncodeToString(h.Body.VrfKey),
+		VrfOutput:            vrfOutput(h.Body.VrfResult),
+		OpCertHotVkey:        hex.EncodeToString(h.Body.OpCert.HotVkey),
+		OpCertSequenceNumber: h.Body.OpCert.SequenceNumber,
+		OpCertKesPeriod:      h.Body.OpCert.KesPeriod,
+	}
+	ret.ProtocolVersion.Major = h.Body.ProtoVersion.Major
+	ret.ProtocolVersion.Minor = h.Body.ProtoVersion.Minor
+	return ret
+}
+
+func newShelleyHeaderDetails(h *ledger.ShelleyBlockHeader) *HeaderDetails {
+	if h == nil {
+		return nil
+	}
+	ret := &HeaderDetails{
+		VrfKey:               hex.EncodeToString(h.Body.VrfKey),
+		VrfOutput:            vrfOutput(h.Body.LeaderVrf),
+		OpCertHotVkey:        hex.EncodeToString(h.Body.OpCertHotVkey),
+		OpCertSequenceNumber: h.Body.OpCertSequenceNumber,
+		OpCertKesPeriod:      h.Body.OpCertKesPeriod,
+	}
+	ret.ProtocolVersion.Major = h.Body.ProtoMajorVersion
+	ret.ProtocolVersion.Minor = h.Body.ProtoMinorVersion
+	return ret
+}
+
+// vrfOutput returns the hex-encoded output from a VRF certificate, which is decoded as a generic
+// [output, proof] pair
+func vrfOutput(vrfResult interface{}) string {
+	vrfParts, ok := vrfResult.([]interface{})
+	if !ok || len(vrfParts) < 1 {
+		return ""
+	}
+	output, ok := vrfParts[0].([]byte)
+	if !ok {
+		return ""
+	}
+	return hex.EncodeToString(output)
+}
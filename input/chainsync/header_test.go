@@ -0,0 +1,117 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBabbageBlockHeader() *ledger.BabbageBlockHeader {
+	header := &ledger.BabbageBlockHeader{}
+	header.Body.VrfKey = []byte{0x01, 0x02}
+	header.Body.VrfResult = []interface{}{[]byte{0xaa, 0xbb}, []byte{0xcc}}
+	header.Body.OpCert.HotVkey = []byte{0x03, 0x04}
+	header.Body.OpCert.SequenceNumber = 7
+	header.Body.OpCert.KesPeriod = 420
+	header.Body.ProtoVersion.Major = 9
+	header.Body.ProtoVersion.Minor = 1
+	return header
+}
+
+func TestNewHeaderDetails(t *testing.T) {
+	shelleyHeader := &ledger.ShelleyBlockHeader{}
+	shelleyHeader.Body.VrfKey = []byte{0x05}
+	shelleyHeader.Body.LeaderVrf = []interface{}{[]byte{0xdd}, []byte{0xee}}
+	shelleyHeader.Body.OpCertHotVkey = []byte{0x06}
+	shelleyHeader.Body.OpCertSequenceNumber = 2
+	shelleyHeader.Body.OpCertKesPeriod = 100
+	shelleyHeader.Body.ProtoMajorVersion = 2
+
+	details := chainsync.NewHeaderDetails(newBabbageBlockHeader())
+	if assert.NotNil(t, details) {
+		assert.Equal(t, "0102", details.VrfKey)
+		assert.Equal(t, "aabb", details.VrfOutput)
+		assert.Equal(t, "0304", details.OpCertHotVkey)
+		assert.Equal(t, uint32(7), details.OpCertSequenceNumber)
+		assert.Equal(t, uint32(420), details.OpCertKesPeriod)
+		assert.Equal(t, uint64(9), details.ProtocolVersion.Major)
+		assert.Equal(t, uint64(1), details.ProtocolVersion.Minor)
+	}
+	details = chainsync.NewHeaderDetails(shelleyHeader)
+	if assert.NotNil(t, details) {
+		assert.Equal(t, "dd", details.VrfOutput)
+		assert.Equal(t, "06", details.OpCertHotVkey)
+		assert.Equal(t, uint32(2), details.OpCertSequenceNumber)
+		assert.Equal(t, uint64(2), details.ProtocolVersion.Major)
+	}
+	assert.Nil(t, chainsync.NewHeaderDetails(&ledger.ByronMainBlockHeader{}))
+}
+
+func TestNewBlockEventHeaderDetails(t *testing.T) {
+	block := &ledger.BabbageBlock{Header: newBabbageBlockHeader()}
+
+	evt := chainsync.NewBlockEvent(block, false, false)
+	assert.Nil(t, evt.HeaderDetails)
+
+	evt = chainsync.NewBlockEvent(block, false, true)
+	if assert.NotNil(t, evt.HeaderDetails) {
+		assert.Equal(t, "aabb", evt.HeaderDetails.VrfOutput)
+		assert.Equal(t, uint32(7), evt.HeaderDetails.OpCertSequenceNumber)
+	}
+}
+
+func TestNewBlockEventPrevHash(t *testing.T) {
+	babbageHeader := newBabbageBlockHeader()
+	babbageHeader.Body.PrevHash = ledger.NewBlake2b256([]byte{0x01, 0x02, 0x03})
+	byronHeader := &ledger.ByronMainBlockHeader{}
+	byronHeader.PrevBlock = ledger.NewBlake2b256([]byte{0x0a, 0x0b})
+
+	evt := chainsync.NewBlockEvent(&ledger.BabbageBlock{Header: babbageHeader}, false, false)
+	assert.Equal(t, "010203"+strings.Repeat("00", 29), evt.PrevHash)
+	evt = chainsync.NewBlockEvent(&ledger.ByronMainBlock{Header: byronHeader}, false, false)
+	assert.Equal(t, "0a0b"+strings.Repeat("00", 30), evt.PrevHash)
+	// The previous hash is also available in headers-only mode
+	evt = chainsync.NewBlockEventFromHeader(babbageHeader, false)
+	assert.Equal(t, "010203"+strings.Repeat("00", 29), evt.PrevHash)
+}
+
+func TestHeadersOnlyFullBlock(t *testing.T) {
+	// Node-to-client connections deliver full blocks even in headers-only mode
+	header := newBabbageBlockHeader()
+	header.Body.PrevHash = ledger.NewBlake2b256([]byte{0x01, 0x02, 0x03})
+	c := chainsync.New(
+		chainsync.WithHeadersOnly(true),
+		chainsync.WithIncludeHeaderDetails(true),
+	)
+	assert.NoError(t, c.HandleRollForward(&ledger.BabbageBlock{Header: header}))
+	var evt event.Event
+	select {
+	case evt = <-c.OutputChan():
+	default:
+		t.Fatal("expected a block event")
+	}
+	payload := evt.Payload.(chainsync.BlockEvent)
+	assert.Equal(t, "010203"+strings.Repeat("00", 29), payload.PrevHash)
+	if assert.NotNil(t, payload.HeaderDetails) {
+		assert.Equal(t, "aabb", payload.HeaderDetails.VrfOutput)
+		assert.Equal(t, uint32(7), payload.HeaderDetails.OpCertSequenceNumber)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+)
+
+// Size of a block hash in bytes
+const blockHashSize = 32
+
+// cleanIntersectPoints returns a copy of the provided intersect points with invalid and duplicate points removed,
+// sorted from newest to oldest. The node uses the first point in the list that it knows about, so this makes sure
+// we resume from the most recent point possible. Invalid points are logged and dropped rather than failing the sync
+func (c *ChainSync) cleanIntersectPoints(points []ocommon.Point) []ocommon.Point {
+	ret := make([]ocommon.Point, 0, len(points))
+	for _, point := range points {
+		// The origin point has no hash
+		isOrigin := point.Slot == 0 && len(point.Hash) == 0
+		if !isOrigin && len(point.Hash) != blockHashSize {
+			if c.logger != nil {
+				c.logger.Warnf(
+					"dropping intersect point at slot %d with invalid hash %q: expected %d bytes, got %d",
+					point.Slot,
+					hex.EncodeToString(point.Hash),
+					blockHashSize,
+					len(point.Hash),
+				)
+			}
+			continue
+		}
+		duplicate := false
+		for _, tmpPoint := range ret {
+			if tmpPoint.Slot == point.Slot && bytes.Equal(tmpPoint.Hash, point.Hash) {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+		ret = append(ret, point)
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].Slot > ret[j].Slot
+	})
+	if len(points) > 0 && len(ret) == 0 && c.logger != nil {
+		c.logger.Warnf("none of the intersect points are valid, syncing from chain genesis")
+	}
+	return ret
+}
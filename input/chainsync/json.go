@@ -24,3 +24,16 @@ type byteSliceJsonHex []byte
 func (b byteSliceJsonHex) MarshalJSON() ([]byte, error) {
 	return json.Marshal(hex.EncodeToString([]byte(b)))
 }
+
+func (b *byteSliceJsonHex) UnmarshalJSON(data []byte) error {
+	var tmpStr string
+	if err := json.Unmarshal(data, &tmpStr); err != nil {
+		return err
+	}
+	tmpBytes, err := hex.DecodeString(tmpStr)
+	if err != nil {
+		return err
+	}
+	*b = tmpBytes
+	return nil
+}
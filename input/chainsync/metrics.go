@@ -0,0 +1,83 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type chainSyncMetrics struct {
+	blocks     prometheus.Counter
+	slot       prometheus.Gauge
+	tipSlot    prometheus.Gauge
+	syncLag    prometheus.Gauge
+	tipReached prometheus.Gauge
+}
+
+func newChainSyncMetrics() *chainSyncMetrics {
+	return &chainSyncMetrics{
+		blocks: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "adder_chainsync_blocks_total",
+			Help: "Number of blocks processed by the chainsync input",
+		}),
+		slot: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "adder_chainsync_slot",
+			Help: "Slot number of the most recently processed block",
+		}),
+		tipSlot: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "adder_chainsync_tip_slot",
+			Help: "Slot number of the chain tip reported by the node",
+		}),
+		syncLag: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "adder_chainsync_sync_lag_slots",
+			Help: "Number of slots between the most recently processed block and the chain tip",
+		}),
+		tipReached: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "adder_chainsync_tip_reached",
+			Help: "Whether the initial sync has reached the chain tip (1) or not (0)",
+		}),
+	}
+}
+
+// RegisterMetrics registers the chainsync input collectors with the provided registry
+func (c *ChainSync) RegisterMetrics(registerer prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		c.metrics.blocks,
+		c.metrics.slot,
+		c.metrics.tipSlot,
+		c.metrics.syncLag,
+		c.metrics.tipReached,
+	}
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *chainSyncMetrics) update(status ChainSyncStatus) {
+	m.blocks.Inc()
+	m.slot.Set(float64(status.SlotNumber))
+	m.tipSlot.Set(float64(status.TipSlotNumber))
+	var syncLag uint64
+	if status.TipSlotNumber > status.SlotNumber {
+		syncLag = status.TipSlotNumber - status.SlotNumber
+	}
+	m.syncLag.Set(float64(syncLag))
+	if status.TipReached {
+		m.tipReached.Set(1)
+	}
+}
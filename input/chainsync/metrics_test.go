@@ -0,0 +1,49 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+)
+
+func TestRegisterMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c := chainsync.New()
+	assert.NoError(t, c.RegisterMetrics(registry))
+	c.UpdateStatus(100, 10, "abcd", 250, "ef01")
+	c.UpdateStatus(150, 11, "ef01", 250, "2345")
+	expected := `
+# HELP adder_chainsync_blocks_total Number of blocks processed by the chainsync input
+# TYPE adder_chainsync_blocks_total counter
+adder_chainsync_blocks_total 2
+# HELP adder_chainsync_sync_lag_slots Number of slots between the most recently processed block and the chain tip
+# TYPE adder_chainsync_sync_lag_slots gauge
+adder_chainsync_sync_lag_slots 100
+`
+	err := testutil.GatherAndCompare(
+		registry,
+		strings.NewReader(expected),
+		"adder_chainsync_blocks_total",
+		"adder_chainsync_sync_lag_slots",
+	)
+	assert.NoError(t, err)
+}
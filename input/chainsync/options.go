@@ -15,6 +15,8 @@
 package chainsync
 
 import (
+	"time"
+
 	"github.com/blinklabs-io/adder/plugin"
 	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
 )
@@ -49,6 +51,17 @@ func WithNtcTcp(ntcTcp bool) ChainSyncOptionFunc {
 	}
 }
 
+// WithResolveInputsFromLedgerState specifies the socket path of a local node to look up the outputs spent by each
+// transaction from, which are included in the transaction events as resolved inputs. The lookups use the local
+// state query protocol over a separate NtC connection, with one query per transaction against the ledger state
+// just before the containing block. The node only keeps recent ledger states, so inputs are left unresolved while
+// far behind the chain tip, or if a lookup fails for any other reason
+func WithResolveInputsFromLedgerState(socketPath string) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.resolveInputsSocket = socketPath
+	}
+}
+
 // WithSocketPath specifies the socket path of the node to connect to
 func WithSocketPath(socketPath string) ChainSyncOptionFunc {
 	return func(c *ChainSync) {
@@ -63,6 +76,15 @@ func WithAddress(address string) ChainSyncOptionFunc {
 	}
 }
 
+// WithPeers specifies multiple NtN (node-to-node) peers to connect to in the form 'host:port'. The first peer is
+// used initially, and each auto-reconnect attempt rotates to the next peer in round-robin order. This takes
+// precedence over the address, socket path and any well-known network default
+func WithPeers(peers []string) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.peers = peers
+	}
+}
+
 // WithIntersectPoints specifies the point(s) to use when starting the ChainSync operation. The default is to start at the genesis of the blockchain
 func WithIntersectPoints(points []ocommon.Point) ChainSyncOptionFunc {
 	return func(c *ChainSync) {
@@ -70,6 +92,16 @@ func WithIntersectPoints(points []ocommon.Point) ChainSyncOptionFunc {
 	}
 }
 
+// WithIntersectSlot specifies a slot to start the ChainSync operation from without needing to know a block hash. On
+// startup, the chain is walked from the intersect point(s), or the genesis of the blockchain if none are specified, to
+// find the last block at or before the slot, which is then used as the intersect point. This takes precedence over
+// WithIntersectTip, but a position loaded from the cursor file takes precedence over both
+func WithIntersectSlot(slot uint64) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.intersectSlot = slot
+	}
+}
+
 // WithInterceptTip specifies whether to start the ChainSync operation from the chain tip. The default is to start at the genesis of the blockchain
 func WithIntersectTip(intersectTip bool) ChainSyncOptionFunc {
 	return func(c *ChainSync) {
@@ -84,6 +116,77 @@ func WithIncludeCbor(includeCbor bool) ChainSyncOptionFunc {
 	}
 }
 
+// WithIncludeHeaderDetails specifies whether to include block producer details from the block header, such as the VRF output
+// and operational certificate, with block events
+func WithIncludeHeaderDetails(includeHeaderDetails bool) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.includeHeaderDetails = includeHeaderDetails
+	}
+}
+
+// WithMaxDatumBytes specifies the maximum size in bytes of an inline datum to include in a transaction event. Larger datums
+// are omitted and the output is flagged as truncated. The default of 0 means no limit
+func WithMaxDatumBytes(maxDatumBytes int) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.maxDatumBytes = maxDatumBytes
+	}
+}
+
+// WithMaxMetadataBytes specifies the maximum size in bytes of transaction metadata to include in a transaction event. Larger
+// metadata is omitted and the event is flagged as truncated. The default of 0 means no limit
+func WithMaxMetadataBytes(maxMetadataBytes int) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.maxMetadataBytes = maxMetadataBytes
+	}
+}
+
+// WithEmitCertificates specifies whether to emit a separate event for each certificate in a transaction
+func WithEmitCertificates(emitCertificates bool) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.emitCertificates = emitCertificates
+	}
+}
+
+// WithEmitStakeRegistrations specifies whether to emit an event for each stake credential registration and
+// deregistration in a transaction
+func WithEmitStakeRegistrations(emitStakeRegistrations bool) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.emitStakeRegistrations = emitStakeRegistrations
+	}
+}
+
+// WithEmitPoolEvents specifies whether to emit an event with the pool details for each stake pool registration
+// in a transaction
+func WithEmitPoolEvents(emitPoolEvents bool) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.emitPoolEvents = emitPoolEvents
+	}
+}
+
+// WithEmitReferenceScripts specifies whether to emit an event for each transaction output that deploys a
+// reference script
+func WithEmitReferenceScripts(emitReferenceScripts bool) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.emitReferenceScripts = emitReferenceScripts
+	}
+}
+
+// WithHeadersOnly specifies whether to only emit block events built from the block headers, without fetching the
+// block bodies. Transaction and certificate events aren't emitted in this mode, and bulk mode is disabled
+func WithHeadersOnly(headersOnly bool) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.headersOnly = headersOnly
+	}
+}
+
+// WithSkipEmptyBlocks specifies whether to suppress block events for blocks without any transactions. This has no
+// effect in headers-only mode, where the number of transactions in a block isn't known
+func WithSkipEmptyBlocks(skipEmptyBlocks bool) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.skipEmptyBlocks = skipEmptyBlocks
+	}
+}
+
 // WithAutoReconnect specified whether to automatically reconnect if the connection is broken
 func WithAutoReconnect(autoReconnect bool) ChainSyncOptionFunc {
 	return func(c *ChainSync) {
@@ -91,6 +194,67 @@ func WithAutoReconnect(autoReconnect bool) ChainSyncOptionFunc {
 	}
 }
 
+// WithStartupRetry specifies whether to keep retrying the initial connection to the node until it becomes available
+func WithStartupRetry(startupRetry bool) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.startupRetry = startupRetry
+	}
+}
+
+// WithStartupTimeout specifies how long to keep retrying the initial connection to the node when startup retry
+// is enabled. A value of 0 retries indefinitely
+func WithStartupTimeout(startupTimeout time.Duration) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.startupTimeout = startupTimeout
+	}
+}
+
+// WithConnectTimeout specifies how long to wait when establishing the network connection to the node. The default
+// is 30 seconds. This doesn't apply when a custom dial function is used
+func WithConnectTimeout(connectTimeout time.Duration) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.connectTimeout = connectTimeout
+	}
+}
+
+// WithKeepAliveInterval specifies how often keep-alive messages are sent to the node, which determines how quickly
+// a dead connection is detected. A value of 0 uses the default of 60 seconds
+func WithKeepAliveInterval(keepAliveInterval time.Duration) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.keepAliveInterval = keepAliveInterval
+	}
+}
+
+// WithDialFunc specifies a custom function for establishing the network connection to the node
+func WithDialFunc(dialFunc DialFunc) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.dialFunc = dialFunc
+	}
+}
+
+// WithTLSClientCert specifies the certificate and key files to use for mutual TLS when connecting to the node over TCP
+func WithTLSClientCert(certPath string, keyPath string) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.tlsClientCert = certPath
+		c.tlsClientKey = keyPath
+	}
+}
+
+// WithTLSCACert specifies the CA certificate file used to verify the node certificate when connecting over TCP
+func WithTLSCACert(caCertPath string) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.tlsCaCert = caCertPath
+	}
+}
+
+// WithCursorFile specifies a file used to persist the most recent chain points. The points are used as the
+// intersect points on startup when the file exists
+func WithCursorFile(cursorFile string) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.cursorFile = cursorFile
+	}
+}
+
 // WithStatusUpdateFunc specifies a callback function for status updates. This is useful for tracking the chain-sync status
 // to be able to resume a sync at a later time, especially when any filtering could prevent you from getting all block update events
 func WithStatusUpdateFunc(
@@ -107,3 +271,38 @@ func WithBulkMode(bulkMode bool) ChainSyncOptionFunc {
 		c.bulkMode = bulkMode
 	}
 }
+
+// WithTxBufferSize specifies how many recent transaction events are kept for lookup by hash via the API. A value of 0
+// disables the buffer
+func WithTxBufferSize(txBufferSize int) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.txBufferSize = txBufferSize
+	}
+}
+
+// WithRollbackStormThreshold specifies the number of rollbacks within the rollback storm window above which the
+// chain is considered unstable. While unstable, events are held back and a single chainsync.unstable event is
+// emitted. The number of events held back is limited by WithRollbackStormMaxEvents. The default of 0 disables
+// rollback storm detection
+func WithRollbackStormThreshold(threshold int) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.rollbackStormThreshold = threshold
+	}
+}
+
+// WithRollbackStormMaxEvents specifies the maximum number of events held back during a rollback storm. Forwarding
+// is resumed early once this many events are held back, even if the chain hasn't stabilized. A value of 0 removes
+// the limit
+func WithRollbackStormMaxEvents(maxEvents int) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.rollbackStormMaxEvents = maxEvents
+	}
+}
+
+// WithRollbackStormWindow specifies the window used when counting rollbacks for rollback storm detection. Events
+// resume once a full window has passed without a rollback
+func WithRollbackStormWindow(window time.Duration) ChainSyncOptionFunc {
+	return func(c *ChainSync) {
+		c.rollbackStormWindow = window
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"encoding/hex"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/blinklabs-io/adder/internal/logging"
 	"github.com/blinklabs-io/adder/plugin"
@@ -26,16 +27,40 @@ import (
 )
 
 var cmdlineOptions struct {
-	network        string
-	networkMagic   uint
-	address        string
-	socketPath     string
-	ntcTcp         bool
-	bulkMode       bool
-	intersectTip   bool
-	intersectPoint string
-	includeCbor    bool
-	autoReconnect  bool
+	network                string
+	networkMagic           uint
+	address                string
+	peers                  string
+	socketPath             string
+	ntcTcp                 bool
+	bulkMode               bool
+	intersectTip           bool
+	intersectPoint         string
+	intersectSlot          uint
+	includeCbor            bool
+	includeHeaderDetails   bool
+	maxDatumBytes          uint
+	maxMetadataBytes       uint
+	emitCertificates       bool
+	emitStakeRegistrations bool
+	emitPoolEvents         bool
+	emitReferenceScripts   bool
+	resolveInputsSocket    string
+	headersOnly            bool
+	skipEmptyBlocks        bool
+	autoReconnect          bool
+	startupRetry           bool
+	startupTimeout         uint
+	connectTimeout         uint
+	keepAliveInterval      uint
+	cursorFile             string
+	tlsClientCert          string
+	tlsClientKey           string
+	tlsCaCert              string
+	txBufferSize           uint
+	rollbackStormThreshold uint
+	rollbackStormWindow    uint
+	rollbackStormMaxEvents uint
 }
 
 func init() {
@@ -68,6 +93,13 @@ func init() {
 					DefaultValue: "",
 					Dest:         &(cmdlineOptions.address),
 				},
+				{
+					Name:         "peers",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies a comma-separated list of NtN (node-to-node) peers in the form 'host:port', rotated through on reconnect",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.peers),
+				},
 				{
 					Name:         "socket-path",
 					Type:         plugin.PluginOptionTypeString,
@@ -104,6 +136,13 @@ func init() {
 					DefaultValue: "",
 					Dest:         &(cmdlineOptions.intersectPoint),
 				},
+				{
+					Name:         "intersect-slot",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "start syncing at the last block at or before the specified slot, overrides 'intersect-tip' (0 to disable)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.intersectSlot),
+				},
 				{
 					Name:         "include-cbor",
 					Type:         plugin.PluginOptionTypeBool,
@@ -111,6 +150,76 @@ func init() {
 					DefaultValue: false,
 					Dest:         &(cmdlineOptions.includeCbor),
 				},
+				{
+					Name:         "include-header-details",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "include block producer details (VRF output, operational certificate, protocol version) in block events",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.includeHeaderDetails),
+				},
+				{
+					Name:         "max-datum-bytes",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "omit inline datums larger than the specified size in bytes from transaction events (0 for no limit)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.maxDatumBytes),
+				},
+				{
+					Name:         "max-metadata-bytes",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "omit transaction metadata larger than the specified size in bytes from transaction events (0 for no limit)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.maxMetadataBytes),
+				},
+				{
+					Name:         "emit-certificates",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "emit a separate event for each certificate in a transaction",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.emitCertificates),
+				},
+				{
+					Name:         "emit-stake-registrations",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "emit an event for each stake registration and deregistration in a transaction",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.emitStakeRegistrations),
+				},
+				{
+					Name:         "emit-pool-events",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "emit an event with the pool details for each stake pool registration in a transaction",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.emitPoolEvents),
+				},
+				{
+					Name:         "emit-reference-scripts",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "emit an event for each transaction output that deploys a reference script",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.emitReferenceScripts),
+				},
+				{
+					Name:         "resolve-inputs-socket-path",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the socket path of a local node to resolve transaction inputs from using its ledger state",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.resolveInputsSocket),
+				},
+				{
+					Name:         "headers-only",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "only emit block events built from block headers, without fetching block bodies",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.headersOnly),
+				},
+				{
+					Name:         "skip-empty-blocks",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "suppress block events for blocks without any transactions",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.skipEmptyBlocks),
+				},
 				{
 					Name:         "auto-reconnect",
 					Type:         plugin.PluginOptionTypeBool,
@@ -118,6 +227,90 @@ func init() {
 					DefaultValue: true,
 					Dest:         &(cmdlineOptions.autoReconnect),
 				},
+				{
+					Name:         "startup-retry",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "keep retrying the initial connection until the node becomes available",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.startupRetry),
+				},
+				{
+					Name:         "startup-timeout",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "how long in seconds to retry the initial connection to the node (0 for no limit)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.startupTimeout),
+				},
+				{
+					Name:         "connect-timeout",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "how long in seconds to wait when connecting to the node",
+					DefaultValue: uint(30),
+					Dest:         &(cmdlineOptions.connectTimeout),
+				},
+				{
+					Name:         "keep-alive-interval",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "how often in seconds to send keep-alive messages to the node (0 for the default of 60)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.keepAliveInterval),
+				},
+				{
+					Name:         "cursor-file",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "file used to persist the sync position and resume from on startup",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.cursorFile),
+				},
+				{
+					Name:         "tls-client-cert",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "client certificate file for mutual TLS when connecting to the node over TCP",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.tlsClientCert),
+				},
+				{
+					Name:         "tls-client-key",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "client key file for mutual TLS when connecting to the node over TCP",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.tlsClientKey),
+				},
+				{
+					Name:         "tls-ca-cert",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "CA certificate file used to verify the node when connecting over TCP with TLS",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.tlsCaCert),
+				},
+				{
+					Name:         "tx-buffer-size",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "number of recent transactions to keep for lookup by hash via the API (0 to disable)",
+					DefaultValue: uint(1000),
+					Dest:         &(cmdlineOptions.txBufferSize),
+				},
+				{
+					Name:         "rollback-storm-threshold",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "pause events when more than this many rollbacks occur within the rollback storm window (0 to disable)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.rollbackStormThreshold),
+				},
+				{
+					Name:         "rollback-storm-window",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "window in seconds used to count rollbacks for rollback storm detection",
+					DefaultValue: uint(60),
+					Dest:         &(cmdlineOptions.rollbackStormWindow),
+				},
+				{
+					Name:         "rollback-storm-max-events",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "maximum number of events held back during a rollback storm before forwarding resumes early (0 for no limit)",
+					DefaultValue: uint(10000),
+					Dest:         &(cmdlineOptions.rollbackStormMaxEvents),
+				},
 			},
 		},
 	)
@@ -135,7 +328,46 @@ func NewFromCmdlineOptions() plugin.Plugin {
 		WithNtcTcp(cmdlineOptions.ntcTcp),
 		WithBulkMode(cmdlineOptions.bulkMode),
 		WithIncludeCbor(cmdlineOptions.includeCbor),
+		WithIncludeHeaderDetails(cmdlineOptions.includeHeaderDetails),
+		WithMaxDatumBytes(int(cmdlineOptions.maxDatumBytes)),
+		WithMaxMetadataBytes(int(cmdlineOptions.maxMetadataBytes)),
+		WithEmitCertificates(cmdlineOptions.emitCertificates),
+		WithEmitStakeRegistrations(cmdlineOptions.emitStakeRegistrations),
+		WithEmitPoolEvents(cmdlineOptions.emitPoolEvents),
+		WithEmitReferenceScripts(cmdlineOptions.emitReferenceScripts),
+		WithResolveInputsFromLedgerState(cmdlineOptions.resolveInputsSocket),
+		WithHeadersOnly(cmdlineOptions.headersOnly),
+		WithSkipEmptyBlocks(cmdlineOptions.skipEmptyBlocks),
 		WithAutoReconnect(cmdlineOptions.autoReconnect),
+		WithStartupRetry(cmdlineOptions.startupRetry),
+		WithStartupTimeout(
+			time.Duration(cmdlineOptions.startupTimeout) * time.Second,
+		),
+		WithConnectTimeout(
+			time.Duration(cmdlineOptions.connectTimeout) * time.Second,
+		),
+		WithKeepAliveInterval(
+			time.Duration(cmdlineOptions.keepAliveInterval) * time.Second,
+		),
+		WithCursorFile(cmdlineOptions.cursorFile),
+		WithTLSClientCert(
+			cmdlineOptions.tlsClientCert,
+			cmdlineOptions.tlsClientKey,
+		),
+		WithTLSCACert(cmdlineOptions.tlsCaCert),
+		WithTxBufferSize(int(cmdlineOptions.txBufferSize)),
+		WithIntersectSlot(uint64(cmdlineOptions.intersectSlot)),
+		WithRollbackStormThreshold(int(cmdlineOptions.rollbackStormThreshold)),
+		WithRollbackStormMaxEvents(int(cmdlineOptions.rollbackStormMaxEvents)),
+		WithRollbackStormWindow(
+			time.Duration(cmdlineOptions.rollbackStormWindow) * time.Second,
+		),
+	}
+	if cmdlineOptions.peers != "" {
+		opts = append(
+			opts,
+			WithPeers(strings.Split(cmdlineOptions.peers, ",")),
+		)
 	}
 	if cmdlineOptions.intersectPoint != "" {
 		intersectPoints := []ocommon.Point{}
@@ -0,0 +1,116 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+const (
+	PoolRelayTypeSingleHostAddress = "singleHostAddress"
+	PoolRelayTypeSingleHostName    = "singleHostName"
+	PoolRelayTypeMultiHostName     = "multiHostName"
+)
+
+type PoolRegistrationEvent struct {
+	BlockHash       string      `json:"blockHash"`
+	TransactionHash string      `json:"transactionHash"`
+	PoolId          string      `json:"poolId"`
+	VrfKeyHash      string      `json:"vrfKeyHash"`
+	Pledge          uint64      `json:"pledge"`
+	Cost            uint64      `json:"cost"`
+	Margin          float64     `json:"margin"`
+	RewardAccount   string      `json:"rewardAccount"`
+	Owners          []string    `json:"owners"`
+	Relays          []PoolRelay `json:"relays"`
+	MetadataUrl     string      `json:"metadataUrl,omitempty"`
+	MetadataHash    string      `json:"metadataHash,omitempty"`
+}
+
+type PoolRelay struct {
+	Type     string `json:"type"`
+	Port     uint32 `json:"port,omitempty"`
+	Ipv4     string `json:"ipv4,omitempty"`
+	Ipv6     string `json:"ipv6,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+}
+
+// NewPoolRegistrationEvent returns a new PoolRegistrationEvent for a pool registration certificate. The second
+// return value is false for all other types of certificate
+func NewPoolRegistrationEvent(
+	block ledger.Block,
+	tx ledger.Transaction,
+	cert ledger.Certificate,
+) (PoolRegistrationEvent, bool) {
+	poolCert, ok := cert.(*ledger.PoolRegistrationCertificate)
+	if !ok {
+		return PoolRegistrationEvent{}, false
+	}
+	evt := extractPoolRegistration(poolCert)
+	evt.BlockHash = block.Hash()
+	evt.TransactionHash = tx.Hash()
+	return evt, true
+}
+
+func extractPoolRegistration(cert *ledger.PoolRegistrationCertificate) PoolRegistrationEvent {
+	evt := PoolRegistrationEvent{
+		PoolId:        ledger.PoolId(cert.Operator).String(),
+		VrfKeyHash:    ledger.Blake2b256(cert.VrfKeyHash).String(),
+		Pledge:        cert.Pledge,
+		Cost:          cert.Cost,
+		RewardAccount: ledger.Blake2b224(cert.RewardAccount).String(),
+		Owners:        make([]string, 0, len(cert.PoolOwners)),
+		Relays:        make([]PoolRelay, 0, len(cert.Relays)),
+	}
+	if cert.Margin.Rat != nil {
+		evt.Margin, _ = cert.Margin.Float64()
+	}
+	for _, owner := range cert.PoolOwners {
+		evt.Owners = append(evt.Owners, ledger.Blake2b224(owner).String())
+	}
+	for _, relay := range cert.Relays {
+		evt.Relays = append(evt.Relays, convertPoolRelay(relay))
+	}
+	if cert.PoolMetadata != nil {
+		evt.MetadataUrl = cert.PoolMetadata.Url
+		evt.MetadataHash = ledger.Blake2b256(cert.PoolMetadata.Hash).String()
+	}
+	return evt
+}
+
+func convertPoolRelay(relay ledger.PoolRelay) PoolRelay {
+	ret := PoolRelay{}
+	switch relay.Type {
+	case ledger.PoolRelayTypeSingleHostAddress:
+		ret.Type = PoolRelayTypeSingleHostAddress
+	case ledger.PoolRelayTypeSingleHostName:
+		ret.Type = PoolRelayTypeSingleHostName
+	case ledger.PoolRelayTypeMultiHostName:
+		ret.Type = PoolRelayTypeMultiHostName
+	}
+	if relay.Port != nil {
+		ret.Port = *relay.Port
+	}
+	if relay.Ipv4 != nil {
+		ret.Ipv4 = relay.Ipv4.String()
+	}
+	if relay.Ipv6 != nil {
+		ret.Ipv6 = relay.Ipv6.String()
+	}
+	if relay.Hostname != nil {
+		ret.Hostname = *relay.Hostname
+	}
+	return ret
+}
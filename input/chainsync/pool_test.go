@@ -0,0 +1,80 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"math/big"
+	"net"
+	"testing"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolRegistrationEvents(t *testing.T) {
+	port := uint32(3001)
+	ipv4 := net.ParseIP("10.0.0.1")
+	hostname := "relay.example.com"
+	tx := mockTransaction{
+		certificates: []ledger.Certificate{
+			&ledger.StakeRegistrationCertificate{},
+			&ledger.PoolRegistrationCertificate{
+				Operator:      ledger.PoolKeyHash{0x01},
+				Pledge:        500000000,
+				Cost:          340000000,
+				Margin:        cbor.Rat{Rat: big.NewRat(1, 40)},
+				RewardAccount: ledger.AddrKeyHash{0x02},
+				PoolOwners:    []ledger.AddrKeyHash{{0x03}},
+				Relays: []ledger.PoolRelay{
+					{Type: ledger.PoolRelayTypeSingleHostAddress, Port: &port, Ipv4: &ipv4},
+					{Type: ledger.PoolRelayTypeMultiHostName, Hostname: &hostname},
+				},
+				PoolMetadata: &ledger.PoolMetadata{
+					Url:  "https://example.com/pool.json",
+					Hash: ledger.PoolMetadataHash{0x04},
+				},
+			},
+		},
+	}
+	c := chainsync.New(chainsync.WithEmitPoolEvents(true))
+	c.EmitBlockEvents(mockBlock{transactions: []ledger.Transaction{tx}})
+
+	// The block and transaction events are followed by a single pool event
+	assert.Len(t, c.OutputChan(), 3)
+	<-c.OutputChan()
+	<-c.OutputChan()
+	evt := <-c.OutputChan()
+	assert.Equal(t, "chainsync.pool", evt.Type)
+	assert.Equal(t, uint32(1), evt.Context.(chainsync.CertificateContext).CertificateIdx)
+	payload := evt.Payload.(chainsync.PoolRegistrationEvent)
+	assert.Equal(t, ledger.PoolId{0x01}.String(), payload.PoolId)
+	assert.Equal(t, uint64(500000000), payload.Pledge)
+	assert.Equal(t, uint64(340000000), payload.Cost)
+	assert.Equal(t, 0.025, payload.Margin)
+	assert.Equal(t, ledger.Blake2b224{0x02}.String(), payload.RewardAccount)
+	assert.Equal(t, []string{ledger.Blake2b224{0x03}.String()}, payload.Owners)
+	assert.Equal(
+		t,
+		[]chainsync.PoolRelay{
+			{Type: chainsync.PoolRelayTypeSingleHostAddress, Port: 3001, Ipv4: "10.0.0.1"},
+			{Type: chainsync.PoolRelayTypeMultiHostName, Hostname: "relay.example.com"},
+		},
+		payload.Relays,
+	)
+	assert.Equal(t, "https://example.com/pool.json", payload.MetadataUrl)
+	assert.Equal(t, ledger.Blake2b256{0x04}.String(), payload.MetadataHash)
+}
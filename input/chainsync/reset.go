@@ -0,0 +1,44 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"encoding/hex"
+
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+)
+
+const (
+	// None of the requested intersect points could be found on the chain
+	ResetReasonIntersectNotFound = "intersectNotFound"
+)
+
+// ResetEvent signals that the chain sync has been reset and consumers should purge any state built from
+// previous events. Syncing resumes from the specified point
+type ResetEvent struct {
+	Reason     string `json:"reason"`
+	BlockHash  string `json:"blockHash"`
+	SlotNumber uint64 `json:"slotNumber"`
+}
+
+func NewResetEvent(reason string, point ocommon.Point) ResetEvent {
+	blockHashHex := hex.EncodeToString(point.Hash)
+	evt := ResetEvent{
+		Reason:     reason,
+		BlockHash:  blockHashHex,
+		SlotNumber: point.Slot,
+	}
+	return evt
+}
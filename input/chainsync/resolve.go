@@ -0,0 +1,154 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+	"github.com/blinklabs-io/gouroboros/protocol/localstatequery"
+)
+
+// ResolveInputsFunc looks up the outputs spent by the specified transaction inputs in the ledger state at the
+// specified chain point, or the tip if the point is nil
+type ResolveInputsFunc func(point *ocommon.Point, inputs []ledger.TransactionInput) ([]ledger.TransactionOutput, error)
+
+// ledgerStateResolver resolves transaction inputs using the local state query protocol over a separate NtC
+// connection to a local node. The connection is made on first use, and made again after an error
+type ledgerStateResolver struct {
+	socketPath   string
+	networkMagic uint32
+	mutex        sync.Mutex
+	oConn        *ouroboros.Connection
+}
+
+func (r *ledgerStateResolver) connection() (*ouroboros.Connection, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.oConn != nil {
+		return r.oConn, nil
+	}
+	conn, err := net.Dial("unix", r.socketPath)
+	if err != nil {
+		return nil, err
+	}
+	oConn, err := ouroboros.NewConnection(
+		ouroboros.WithConnection(conn),
+		ouroboros.WithNetworkMagic(r.networkMagic),
+		ouroboros.WithNodeToNode(false),
+		ouroboros.WithLocalStateQueryConfig(localstatequery.NewConfig()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	r.oConn = oConn
+	// Drop the connection on error, so that it's made again on the next lookup
+	go func() {
+		if _, ok := <-oConn.ErrorChan(); ok {
+			r.reset(oConn)
+		}
+	}()
+	return oConn, nil
+}
+
+// reset closes the specified connection, if it's still the current one
+func (r *ledgerStateResolver) reset(oConn *ouroboros.Connection) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.oConn == oConn {
+		_ = r.oConn.Close()
+		r.oConn = nil
+	}
+}
+
+// resolveInputs looks up the outputs spent by the inputs of a transaction in a single query. The outputs are
+// returned in the order of the inputs, omitting any that weren't found in the ledger state
+func (r *ledgerStateResolver) resolveInputs(
+	point *ocommon.Point,
+	inputs []ledger.TransactionInput,
+) ([]ledger.TransactionOutput, error) {
+	oConn, err := r.connection()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to node: %w", err)
+	}
+	client := oConn.LocalStateQuery().Client
+	if err := client.Acquire(point); err != nil {
+		r.reset(oConn)
+		return nil, fmt.Errorf("failed to acquire ledger state: %w", err)
+	}
+	result, err := client.GetUTxOByTxIn(inputs)
+	if releaseErr := client.Release(); releaseErr != nil && err == nil {
+		err = releaseErr
+	}
+	if err != nil {
+		r.reset(oConn)
+		return nil, fmt.Errorf("failed to query UTxOs: %w", err)
+	}
+	ret := make([]ledger.TransactionOutput, 0, len(inputs))
+	for _, input := range inputs {
+		for utxoId, output := range result.Results {
+			if utxoId.Hash == input.Id() && uint32(utxoId.Idx) == input.Index() {
+				output := output
+				ret = append(ret, &output)
+				break
+			}
+		}
+	}
+	return ret, nil
+}
+
+func (r *ledgerStateResolver) close() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.oConn != nil {
+		_ = r.oConn.Close()
+		r.oConn = nil
+	}
+}
+
+// resolveInputs populates the resolved inputs of a transaction event from the ledger state just before the
+// block containing it. The resolved inputs are left empty if the lookup fails
+func (c *ChainSync) resolveInputs(
+	txEvt *TransactionEvent,
+	tx ledger.Transaction,
+	prevPoint *ocommon.Point,
+) {
+	resolved, err := c.resolveInputsFunc(prevPoint, tx.Inputs())
+	if err != nil {
+		// Only warn once, since this is likely to fail for every transaction while far behind the tip
+		if !c.resolveInputsWarned && c.logger != nil {
+			c.logger.Warnf("failed to resolve transaction inputs from ledger state: %s", err)
+			c.resolveInputsWarned = true
+		}
+		return
+	}
+	txEvt.ResolvedInputs = resolved
+}
+
+// previousPoint returns the chain point of the last block processed, which is the ledger state that the inputs of
+// the next block's transactions are resolved against. It returns nil if no block has been processed yet
+func (c *ChainSync) previousPoint() *ocommon.Point {
+	c.cursorMutex.Lock()
+	defer c.cursorMutex.Unlock()
+	if len(c.cursorCache) == 0 {
+		return nil
+	}
+	point := c.cursorCache[len(c.cursorCache)-1]
+	return &point
+}
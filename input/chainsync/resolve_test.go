@@ -0,0 +1,60 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveInputs(t *testing.T) {
+	resolvedOutput := mockOutput{amount: 5_000_000}
+	testDefs := []struct {
+		name           string
+		resolveErr     error
+		expectedInputs []ledger.TransactionOutput
+	}{
+		{name: "resolved", expectedInputs: []ledger.TransactionOutput{resolvedOutput}},
+		{name: "lookup failed", resolveErr: errors.New("point too old")},
+	}
+	for _, testDef := range testDefs {
+		t.Run(testDef.name, func(t *testing.T) {
+			var queriedPoint *ocommon.Point
+			c := chainsync.New(
+				chainsync.WithResolveInputsFunc(
+					func(point *ocommon.Point, inputs []ledger.TransactionInput) ([]ledger.TransactionOutput, error) {
+						queriedPoint = point
+						if testDef.resolveErr != nil {
+							return nil, testDef.resolveErr
+						}
+						return []ledger.TransactionOutput{resolvedOutput}, nil
+					},
+				),
+			)
+			// Process a previous block, which is the ledger state the inputs are resolved against
+			c.UpdateStatus(100, 1, "aabb", 200, "ccdd")
+			c.EmitBlockEvents(mockBlock{transactions: []ledger.Transaction{mockTransaction{}}})
+			<-c.OutputChan()
+			txEvt := (<-c.OutputChan()).Payload.(chainsync.TransactionEvent)
+			assert.Equal(t, testDef.expectedInputs, txEvt.ResolvedInputs)
+			assert.Equal(t, &ocommon.Point{Slot: 100, Hash: []byte{0xaa, 0xbb}}, queriedPoint)
+		})
+	}
+}
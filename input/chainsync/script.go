@@ -0,0 +1,131 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"encoding/hex"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	ScriptTypeNative   = "native"
+	ScriptTypePlutusV1 = "plutusV1"
+	ScriptTypePlutusV2 = "plutusV2"
+	ScriptTypePlutusV3 = "plutusV3"
+)
+
+var scriptTypeNames = map[uint64]string{
+	0: ScriptTypeNative,
+	1: ScriptTypePlutusV1,
+	2: ScriptTypePlutusV2,
+	3: ScriptTypePlutusV3,
+}
+
+// ScriptRef is a reference script carried by a transaction output
+type ScriptRef struct {
+	ScriptHash string `json:"scriptHash"`
+	ScriptType string `json:"scriptType"`
+}
+
+type ReferenceScriptEvent struct {
+	BlockHash       string `json:"blockHash"`
+	TransactionHash string `json:"transactionHash"`
+	OutputIdx       uint32 `json:"outputIdx"`
+	ScriptRef
+}
+
+// NewReferenceScriptEvents returns a new ReferenceScriptEvent for each output of the transaction that deploys a
+// reference script
+func NewReferenceScriptEvents(block ledger.Block, tx ledger.Transaction) []ReferenceScriptEvent {
+	var ret []ReferenceScriptEvent
+	for idx, output := range tx.Outputs() {
+		scriptRef, ok := OutputScriptRef(output)
+		if !ok {
+			continue
+		}
+		ret = append(
+			ret,
+			ReferenceScriptEvent{
+				BlockHash:       block.Hash(),
+				TransactionHash: tx.Hash(),
+				OutputIdx:       uint32(idx),
+				ScriptRef:       scriptRef,
+			},
+		)
+	}
+	return ret
+}
+
+// OutputScriptRef returns the reference script carried by a transaction output, if any. The ledger output types
+// don't expose the reference script, so it's decoded from the output's original CBOR. Only post-Alonzo outputs,
+// which are encoded as a map, can carry a reference script
+func OutputScriptRef(output ledger.TransactionOutput) (ScriptRef, bool) {
+	outputCbor := output.Cbor()
+	if len(outputCbor) == 0 {
+		return ScriptRef{}, false
+	}
+	var outputMap map[uint64]cbor.RawMessage
+	if _, err := cbor.Decode(outputCbor, &outputMap); err != nil {
+		return ScriptRef{}, false
+	}
+	scriptRefCbor, ok := outputMap[3]
+	if !ok {
+		return ScriptRef{}, false
+	}
+	// The reference script is wrapped in a tag 24 (encoded CBOR data item) containing a [type, script] pair
+	var scriptRef cbor.Tag
+	if _, err := cbor.Decode(scriptRefCbor, &scriptRef); err != nil {
+		return ScriptRef{}, false
+	}
+	scriptRefBytes, ok := scriptRef.Content.([]byte)
+	if !ok {
+		return ScriptRef{}, false
+	}
+	var script []cbor.RawMessage
+	if _, err := cbor.Decode(scriptRefBytes, &script); err != nil || len(script) != 2 {
+		return ScriptRef{}, false
+	}
+	var scriptType uint64
+	if _, err := cbor.Decode(script[0], &scriptType); err != nil {
+		return ScriptRef{}, false
+	}
+	scriptTypeName, ok := scriptTypeNames[scriptType]
+	if !ok {
+		return ScriptRef{}, false
+	}
+	// Native scripts are hashed from their CBOR encoding, and Plutus scripts from their raw bytes
+	scriptData := []byte(script[1])
+	if scriptType > 0 {
+		if _, err := cbor.Decode(script[1], &scriptData); err != nil {
+			return ScriptRef{}, false
+		}
+	}
+	// Script hashes are Blake2b-224
+	hash, err := blake2b.New(28, nil)
+	if err != nil {
+		return ScriptRef{}, false
+	}
+	// The script type is prepended to the script data when calculating the hash
+	hash.Write([]byte{byte(scriptType)})
+	hash.Write(scriptData)
+	ret := ScriptRef{
+		ScriptHash: hex.EncodeToString(hash.Sum(nil)),
+		ScriptType: scriptTypeName,
+	}
+	return ret, true
+}
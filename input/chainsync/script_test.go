@@ -0,0 +1,79 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestReferenceScriptEvents(t *testing.T) {
+	// An arbitrary Plutus V2 script, which is hashed from its raw bytes with the script type prepended
+	script := []byte{0x46, 0x01, 0x00, 0x00, 0x22, 0x49, 0x01}
+	scriptCbor, err := cbor.Encode([]any{uint64(2), script})
+	if err != nil {
+		t.Fatalf("unexpected error encoding CBOR: %s", err)
+	}
+	outputCbor, err := cbor.Encode(
+		map[uint64]any{
+			1: uint64(1000000),
+			3: cbor.Tag{Number: 24, Content: scriptCbor},
+		},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error encoding CBOR: %s", err)
+	}
+	hash, err := blake2b.New(28, nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating hash: %s", err)
+	}
+	hash.Write(append([]byte{0x02}, script...))
+	expectedHash := hex.EncodeToString(hash.Sum(nil))
+
+	tx := mockTransaction{
+		outputs: []ledger.TransactionOutput{
+			mockOutput{},
+			mockOutput{cbor: outputCbor},
+		},
+	}
+	c := chainsync.New(chainsync.WithEmitReferenceScripts(true))
+	c.EmitBlockEvents(mockBlock{transactions: []ledger.Transaction{tx}})
+
+	// The block and transaction events are followed by a single reference script event
+	assert.Len(t, c.OutputChan(), 3)
+	<-c.OutputChan()
+	<-c.OutputChan()
+	evt := <-c.OutputChan()
+	assert.Equal(t, "chainsync.reference_script", evt.Type)
+	assert.Equal(
+		t,
+		chainsync.ReferenceScriptEvent{
+			BlockHash:       "abcd",
+			TransactionHash: "deadbeef",
+			OutputIdx:       1,
+			ScriptRef: chainsync.ScriptRef{
+				ScriptHash: expectedHash,
+				ScriptType: chainsync.ScriptTypePlutusV2,
+			},
+		},
+		evt.Payload,
+	)
+}
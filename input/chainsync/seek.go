@@ -0,0 +1,158 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/protocol/blockfetch"
+	ochainsync "github.com/blinklabs-io/gouroboros/protocol/chainsync"
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+)
+
+// slotSeeker walks the chain looking for the last block at or before a slot
+type slotSeeker struct {
+	slot       uint64
+	point      ocommon.Point
+	found      bool
+	done       bool
+	resultChan chan slotSeekResult
+}
+
+type slotSeekResult struct {
+	point ocommon.Point
+	err   error
+}
+
+func newSlotSeeker(slot uint64) *slotSeeker {
+	return &slotSeeker{
+		slot:       slot,
+		resultChan: make(chan slotSeekResult, 1),
+	}
+}
+
+func (s *slotSeeker) handleRollForward(
+	ctx ochainsync.CallbackContext,
+	blockType uint,
+	blockData interface{},
+	tip ochainsync.Tip,
+) error {
+	if s.done {
+		return nil
+	}
+	// Blocks also implement the header interface, so this covers both NtC and NtN
+	header, ok := blockData.(ledger.BlockHeader)
+	if !ok {
+		return nil
+	}
+	if header.SlotNumber() <= s.slot {
+		blockHash, err := hex.DecodeString(header.Hash())
+		if err != nil {
+			return err
+		}
+		s.point = ocommon.NewPoint(header.SlotNumber(), blockHash)
+		s.found = true
+		return nil
+	}
+	// We've passed the target slot, so the last block we saw is the one we want
+	s.done = true
+	if !s.found {
+		s.resultChan <- slotSeekResult{
+			err: fmt.Errorf(
+				"no block found at or before slot %d, first block is at slot %d",
+				s.slot,
+				header.SlotNumber(),
+			),
+		}
+		return nil
+	}
+	s.resultChan <- slotSeekResult{point: s.point}
+	return nil
+}
+
+func (s *slotSeeker) handleRollBackward(
+	ctx ochainsync.CallbackContext,
+	point ocommon.Point,
+	tip ochainsync.Tip,
+) error {
+	if s.done {
+		return nil
+	}
+	// The origin point has no hash and can't be used as a block to intersect at
+	s.point = point
+	s.found = len(point.Hash) > 0
+	return nil
+}
+
+// findIntersectSlot returns the point for the last block at or before the specified slot. This uses a
+// separate connection to walk the chain from the configured intersect point(s), or the chain genesis if
+// none are configured, since the chain-sync protocol can only intersect on known block hashes
+func (c *ChainSync) findIntersectSlot(slot uint64) (ocommon.Point, error) {
+	seeker := newSlotSeeker(slot)
+	oConn, err := c.newConnection(
+		ochainsync.NewConfig(
+			ochainsync.WithRollForwardFunc(seeker.handleRollForward),
+			ochainsync.WithRollBackwardFunc(seeker.handleRollBackward),
+		),
+		blockfetch.NewConfig(),
+	)
+	if err != nil {
+		return ocommon.Point{}, err
+	}
+	defer oConn.Close()
+	client := oConn.ChainSync().Client
+	client.Start()
+	tip, err := client.GetCurrentTip()
+	if err != nil {
+		return ocommon.Point{}, err
+	}
+	// Use the chain tip if it's at or before the requested slot
+	if tip.Point.Slot <= slot {
+		if len(tip.Point.Hash) == 0 {
+			return ocommon.Point{}, fmt.Errorf(
+				"no block found at or before slot %d, chain is empty",
+				slot,
+			)
+		}
+		return tip.Point, nil
+	}
+	if c.logger != nil {
+		c.logger.Infof("searching for the last block at or before slot %d", slot)
+	}
+	if err := client.Sync(c.intersectPoints); err != nil {
+		return ocommon.Point{}, err
+	}
+	select {
+	case result := <-seeker.resultChan:
+		if result.err != nil {
+			return ocommon.Point{}, result.err
+		}
+		if c.logger != nil {
+			c.logger.Infof(
+				"found block at slot %d for intersect slot %d",
+				result.point.Slot,
+				slot,
+			)
+		}
+		return result.point, nil
+	case err, ok := <-oConn.ErrorChan():
+		if !ok {
+			err = fmt.Errorf("connection closed while searching for slot %d", slot)
+		}
+		return ocommon.Point{}, err
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"testing"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockHeader struct {
+	ledger.BlockHeader
+	slot uint64
+	hash string
+}
+
+func (h mockHeader) SlotNumber() uint64 { return h.slot }
+func (h mockHeader) Hash() string       { return h.hash }
+
+func TestSeekSlot(t *testing.T) {
+	headers := []ledger.BlockHeader{
+		mockHeader{slot: 100, hash: "0100"},
+		mockHeader{slot: 120, hash: "0120"},
+		mockHeader{slot: 150, hash: "0150"},
+	}
+	// A slot between blocks uses the block just before it
+	point, err := chainsync.SeekSlot(130, headers)
+	assert.NoError(t, err)
+	assert.Equal(t, ocommon.NewPoint(120, []byte{0x01, 0x20}), point)
+	// A slot with a block uses that block
+	point, err = chainsync.SeekSlot(120, headers)
+	assert.NoError(t, err)
+	assert.Equal(t, ocommon.NewPoint(120, []byte{0x01, 0x20}), point)
+	// A slot before the first block is an error
+	_, err = chainsync.SeekSlot(50, headers)
+	assert.ErrorContains(t, err, "no block found at or before slot 50")
+}
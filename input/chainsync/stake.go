@@ -0,0 +1,81 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"encoding/hex"
+
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+type StakeRegistrationEvent struct {
+	BlockHash       string `json:"blockHash"`
+	TransactionHash string `json:"transactionHash"`
+	CertificateType string `json:"certificateType"`
+	StakeCredential string `json:"stakeCredential"`
+	Deregistration  bool   `json:"deregistration"`
+	// Deposit is the deposit paid for a registration, or refunded for a deregistration, in lovelace. Certificates
+	// from before the Conway era don't specify the deposit, in which case this is 0
+	Deposit int64 `json:"deposit"`
+}
+
+// NewStakeRegistrationEvent returns a new StakeRegistrationEvent for a certificate that registers or deregisters a
+// stake credential. The second return value is false for all other types of certificate
+func NewStakeRegistrationEvent(
+	block ledger.Block,
+	tx ledger.Transaction,
+	cert ledger.Certificate,
+) (StakeRegistrationEvent, bool) {
+	var stakeCredential ledger.StakeCredential
+	var deposit int64
+	var deregistration bool
+	switch c := cert.(type) {
+	case *ledger.StakeRegistrationCertificate:
+		stakeCredential = c.StakeRegistration
+	case *ledger.StakeDeregistrationCertificate:
+		stakeCredential = c.StakeDeregistration
+		deregistration = true
+	case *ledger.RegistrationCertificate:
+		stakeCredential, deposit = c.StakeCredential, c.Amount
+	case *ledger.DeregistrationCertificate:
+		stakeCredential, deposit = c.StakeCredential, c.Amount
+		deregistration = true
+	case *ledger.StakeRegistrationDelegationCertificate:
+		stakeCredential, deposit = c.StakeCredential, c.Amount
+	case *ledger.VoteRegistrationDelegationCertificate:
+		stakeCredential, deposit = c.StakeCredential, c.Amount
+	case *ledger.StakeVoteRegistrationDelegationCertificate:
+		stakeCredential, deposit = c.StakeCredential, c.Amount
+	default:
+		return StakeRegistrationEvent{}, false
+	}
+	evt := StakeRegistrationEvent{
+		BlockHash:       block.Hash(),
+		TransactionHash: tx.Hash(),
+		CertificateType: CertificateTypeName(cert),
+		StakeCredential: hex.EncodeToString(stakeCredential.Credential),
+		Deregistration:  deregistration,
+		Deposit:         deposit,
+	}
+	return evt, true
+}
+
+// eventType returns the type of event to emit for the stake registration
+func (e StakeRegistrationEvent) eventType() string {
+	if e.Deregistration {
+		return "chainsync.stake_deregistration"
+	}
+	return "chainsync.stake_registration"
+}
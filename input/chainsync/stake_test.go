@@ -0,0 +1,76 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"testing"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStakeRegistrationEvents(t *testing.T) {
+	stakeCredential := ledger.StakeCredential{Credential: []byte{0x01, 0x02}}
+	tx := mockTransaction{
+		certificates: []ledger.Certificate{
+			&ledger.RegistrationCertificate{StakeCredential: stakeCredential, Amount: 2000000},
+			&ledger.PoolRetirementCertificate{},
+			&ledger.StakeDeregistrationCertificate{StakeDeregistration: stakeCredential},
+		},
+	}
+	c := chainsync.New(chainsync.WithEmitStakeRegistrations(true))
+	c.EmitBlockEvents(mockBlock{transactions: []ledger.Transaction{tx}})
+
+	events := []event.Event{}
+	for len(c.OutputChan()) > 0 {
+		events = append(events, <-c.OutputChan())
+	}
+	if !assert.Len(t, events, 4) {
+		return
+	}
+	assert.Equal(t, "chainsync.block", events[0].Type)
+	assert.Equal(t, "chainsync.transaction", events[1].Type)
+
+	assert.Equal(t, "chainsync.stake_registration", events[2].Type)
+	assert.Equal(t, uint32(0), events[2].Context.(chainsync.CertificateContext).CertificateIdx)
+	assert.Equal(
+		t,
+		chainsync.StakeRegistrationEvent{
+			BlockHash:       "abcd",
+			TransactionHash: "deadbeef",
+			CertificateType: "Registration",
+			StakeCredential: "0102",
+			Deposit:         2000000,
+		},
+		events[2].Payload,
+	)
+
+	// Certificates from before Conway don't include the deposit
+	assert.Equal(t, "chainsync.stake_deregistration", events[3].Type)
+	assert.Equal(t, uint32(2), events[3].Context.(chainsync.CertificateContext).CertificateIdx)
+	assert.Equal(
+		t,
+		chainsync.StakeRegistrationEvent{
+			BlockHash:       "abcd",
+			TransactionHash: "deadbeef",
+			CertificateType: "StakeDeregistration",
+			StakeCredential: "0102",
+			Deregistration:  true,
+		},
+		events[3].Payload,
+	)
+}
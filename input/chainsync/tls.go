@@ -0,0 +1,75 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+)
+
+// tlsClientConfig returns the TLS config for connecting to the node, or nil if no TLS certificates were configured
+func (c *ChainSync) tlsClientConfig() (*tls.Config, error) {
+	if c.tlsClientCert == "" && c.tlsCaCert == "" {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	// Use the host from the dial address for verifying the node certificate
+	if host, _, err := net.SplitHostPort(c.dialAddress); err == nil {
+		tlsConfig.ServerName = host
+	}
+	if c.tlsClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(c.tlsClientCert, c.tlsClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	if c.tlsCaCert != "" {
+		caCertData, err := os.ReadFile(c.tlsCaCert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS CA certificate: %w", err)
+		}
+		caCertPool := x509.NewCertPool()
+		if !caCertPool.AppendCertsFromPEM(caCertData) {
+			return nil, fmt.Errorf(
+				"failed to parse TLS CA certificate: no certificates found in %s",
+				c.tlsCaCert,
+			)
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+	return tlsConfig, nil
+}
+
+// tlsDialFunc wraps a DialFunc to perform a TLS handshake over the established connection
+func tlsDialFunc(dialFunc DialFunc, tlsConfig *tls.Config) DialFunc {
+	return func(network string, address string) (net.Conn, error) {
+		conn, err := dialFunc(network, address)
+		if err != nil {
+			return nil, err
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("TLS handshake failed: %w", err)
+		}
+		return tlsConn, nil
+	}
+}
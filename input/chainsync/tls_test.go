@@ -0,0 +1,157 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestCert generates a self-signed certificate for localhost and writes it and its key to the specified
+// directory. The certificate is usable as the CA, server and client certificate
+func writeTestCert(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-1 * time.Hour),
+		NotAfter:              time.Now().Add(1 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage: []x509.ExtKeyUsage{
+			x509.ExtKeyUsageServerAuth,
+			x509.ExtKeyUsageClientAuth,
+		},
+	}
+	certData, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error creating certificate: %s", err)
+	}
+	keyData, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("unexpected error marshaling key: %s", err)
+	}
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certData}), 0o600); err != nil {
+		t.Fatalf("unexpected error writing certificate: %s", err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyData}), 0o600); err != nil {
+		t.Fatalf("unexpected error writing key: %s", err)
+	}
+	return certPath, keyPath
+}
+
+func TestTLSClientCert(t *testing.T) {
+	certPath, keyPath := writeTestCert(t, t.TempDir())
+	serverCert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		t.Fatalf("unexpected error loading certificate: %s", err)
+	}
+	leafCert, err := x509.ParseCertificate(serverCert.Certificate[0])
+	if err != nil {
+		t.Fatalf("unexpected error parsing certificate: %s", err)
+	}
+	caCertPool := x509.NewCertPool()
+	caCertPool.AddCert(leafCert)
+	peerCertsChan := make(chan int, 1)
+	dialFunc := func(network string, address string) (net.Conn, error) {
+		clientConn, serverConn := net.Pipe()
+		go func() {
+			defer serverConn.Close()
+			tlsConn := tls.Server(
+				serverConn,
+				&tls.Config{
+					Certificates: []tls.Certificate{serverCert},
+					ClientAuth:   tls.RequireAndVerifyClientCert,
+					ClientCAs:    caCertPool,
+				},
+			)
+			if err := tlsConn.Handshake(); err != nil {
+				peerCertsChan <- 0
+				return
+			}
+			peerCertsChan <- len(tlsConn.ConnectionState().PeerCertificates)
+		}()
+		return clientConn, nil
+	}
+	c := chainsync.New(
+		chainsync.WithAddress("localhost:3001"),
+		chainsync.WithNetworkMagic(2),
+		chainsync.WithDialFunc(dialFunc),
+		chainsync.WithTLSClientCert(certPath, keyPath),
+		chainsync.WithTLSCACert(certPath),
+	)
+	// The mock node closes the connection after the TLS handshake, so we only care about what it saw
+	_ = c.Start()
+	select {
+	case peerCerts := <-peerCertsChan:
+		assert.Equal(t, 1, peerCerts)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for TLS handshake")
+	}
+}
+
+func TestTLSCertError(t *testing.T) {
+	missingPath := filepath.Join(t.TempDir(), "missing.pem")
+	dialErr := errors.New("dial called")
+	dialFunc := func(network string, address string) (net.Conn, error) {
+		return nil, dialErr
+	}
+	// TLS files that can't be read are reported for TCP connections
+	c := chainsync.New(
+		chainsync.WithAddress("localhost:3001"),
+		chainsync.WithDialFunc(dialFunc),
+		chainsync.WithTLSClientCert(missingPath, missingPath),
+	)
+	err := c.Start()
+	assert.ErrorContains(t, err, "failed to load TLS client certificate")
+	c = chainsync.New(
+		chainsync.WithAddress("localhost:3001"),
+		chainsync.WithDialFunc(dialFunc),
+		chainsync.WithTLSCACert(missingPath),
+	)
+	err = c.Start()
+	assert.ErrorContains(t, err, "failed to read TLS CA certificate")
+	// TLS settings are ignored for UNIX socket connections
+	c = chainsync.New(
+		chainsync.WithSocketPath("/tmp/node.socket"),
+		chainsync.WithDialFunc(dialFunc),
+		chainsync.WithTLSClientCert(missingPath, missingPath),
+		chainsync.WithTLSCACert(missingPath),
+	)
+	err = c.Start()
+	assert.ErrorIs(t, err, dialErr)
+}
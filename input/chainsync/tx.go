@@ -15,6 +15,8 @@
 package chainsync
 
 import (
+	"encoding/json"
+
 	"github.com/blinklabs-io/gouroboros/cbor"
 	"github.com/blinklabs-io/gouroboros/ledger"
 )
@@ -25,19 +27,82 @@ type TransactionContext struct {
 	TransactionHash string `json:"transactionHash"`
 	TransactionIdx  uint32 `json:"transactionIdx"`
 	NetworkMagic    uint32 `json:"networkMagic"`
+	Era             string `json:"era"`
 }
 
 type TransactionEvent struct {
-	Transaction     ledger.Transaction         `json:"-"`
-	BlockHash       string                     `json:"blockHash"`
-	TransactionCbor byteSliceJsonHex           `json:"transactionCbor,omitempty"`
-	Inputs          []ledger.TransactionInput  `json:"inputs"`
-	Outputs         []ledger.TransactionOutput `json:"outputs"`
-	Certificates    []ledger.Certificate       `json:"certificates,omitempty"`
-	ReferenceInputs []ledger.TransactionInput  `json:"referenceInputs,omitempty"`
-	Metadata        *cbor.LazyValue            `json:"metadata,omitempty"`
-	Fee             uint64                     `json:"fee"`
-	TTL             uint64                     `json:"ttl,omitempty"`
+	Transaction       ledger.Transaction         `json:"-"`
+	BlockHash         string                     `json:"blockHash"`
+	TransactionCbor   byteSliceJsonHex           `json:"transactionCbor,omitempty"`
+	Inputs            []ledger.TransactionInput  `json:"inputs"`
+	Outputs           []ledger.TransactionOutput `json:"outputs"`
+	ResolvedInputs    []ledger.TransactionOutput `json:"resolvedInputs,omitempty"`
+	Certificates      []ledger.Certificate       `json:"certificates,omitempty"`
+	ReferenceInputs   []ledger.TransactionInput  `json:"referenceInputs,omitempty"`
+	Metadata          *cbor.LazyValue            `json:"metadata,omitempty"`
+	MetadataTruncated bool                       `json:"metadataTruncated,omitempty"`
+	Fee               uint64                     `json:"fee"`
+	TTL               uint64                     `json:"ttl,omitempty"`
+	Extra             map[string]any             `json:"extra,omitempty"`
+}
+
+const (
+	AddressRoleSpentFrom = "spentFrom"
+	AddressRolePaidTo    = "paidTo"
+)
+
+// TransactionAddress is a distinct address involved in a transaction along with the role(s) it played
+type TransactionAddress struct {
+	Address string   `json:"address"`
+	Roles   []string `json:"roles"`
+}
+
+// truncatedDatumOutput wraps a transaction output whose inline datum exceeded the configured
+// size cap. The datum is omitted from the output and a flag is set to indicate the truncation
+type truncatedDatumOutput struct {
+	ledger.TransactionOutput
+}
+
+func (o truncatedDatumOutput) Datum() *cbor.LazyValue {
+	return nil
+}
+
+func (o truncatedDatumOutput) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(o.TransactionOutput)
+	if err != nil {
+		return nil, err
+	}
+	tmpObj := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &tmpObj); err != nil {
+		return nil, err
+	}
+	delete(tmpObj, "datum")
+	tmpObj["datumTruncated"] = json.RawMessage("true")
+	return json.Marshal(tmpObj)
+}
+
+// TransactionEventOptionFunc is an optional setting for NewTransactionEvent
+type TransactionEventOptionFunc func(*transactionEventOptions)
+
+type transactionEventOptions struct {
+	maxDatumBytes    int
+	maxMetadataBytes int
+}
+
+// WithTxMaxDatumBytes specifies the maximum size of an inline datum to include in a transaction event. Larger datums
+// are omitted and flagged as truncated. A value of 0 disables the limit
+func WithTxMaxDatumBytes(maxDatumBytes int) TransactionEventOptionFunc {
+	return func(o *transactionEventOptions) {
+		o.maxDatumBytes = maxDatumBytes
+	}
+}
+
+// WithTxMaxMetadataBytes specifies the maximum size of metadata to include in a transaction event. Larger metadata
+// is omitted and flagged as truncated. A value of 0 disables the limit
+func WithTxMaxMetadataBytes(maxMetadataBytes int) TransactionEventOptionFunc {
+	return func(o *transactionEventOptions) {
+		o.maxMetadataBytes = maxMetadataBytes
+	}
 }
 
 func NewTransactionContext(
@@ -52,21 +117,30 @@ func NewTransactionContext(
 		TransactionHash: tx.Hash(),
 		TransactionIdx:  index,
 		NetworkMagic:    networkMagic,
+		Era:             block.Era().Name,
 	}
 	return ctx
 }
 
+// NewTransactionEvent returns a new TransactionEvent for the specified transaction. Size limits for inline datums and
+// metadata can be given with WithTxMaxDatumBytes and WithTxMaxMetadataBytes. Era-specific data is added to Extra by
+// the extractor registered for the block's era, if any
 func NewTransactionEvent(
 	block ledger.Block,
 	tx ledger.Transaction,
 	includeCbor bool,
+	options ...TransactionEventOptionFunc,
 ) TransactionEvent {
+	var opts transactionEventOptions
+	for _, option := range options {
+		option(&opts)
+	}
 	evt := TransactionEvent{
-		Transaction:  tx,
-		BlockHash:    block.Hash(),
-		Inputs:       tx.Inputs(),
-		Outputs:      tx.Outputs(),
-		Fee:          tx.Fee(),
+		Transaction: tx,
+		BlockHash:   block.Hash(),
+		Inputs:      tx.Inputs(),
+		Outputs:     tx.Outputs(),
+		Fee:         tx.Fee(),
 	}
 	if includeCbor {
 		evt.TransactionCbor = tx.Cbor()
@@ -75,7 +149,21 @@ func NewTransactionEvent(
 		evt.Certificates = tx.Certificates()
 	}
 	if tx.Metadata() != nil {
-		evt.Metadata = tx.Metadata()
+		if opts.maxMetadataBytes > 0 && len(tx.Metadata().Cbor()) > opts.maxMetadataBytes {
+			evt.MetadataTruncated = true
+		} else {
+			evt.Metadata = tx.Metadata()
+		}
+	}
+	if opts.maxDatumBytes > 0 {
+		outputs := make([]ledger.TransactionOutput, 0, len(evt.Outputs))
+		for _, output := range evt.Outputs {
+			if datum := output.Datum(); datum != nil && len(datum.Cbor()) > opts.maxDatumBytes {
+				output = truncatedDatumOutput{output}
+			}
+			outputs = append(outputs, output)
+		}
+		evt.Outputs = outputs
 	}
 	if tx.ReferenceInputs() != nil {
 		evt.ReferenceInputs = tx.ReferenceInputs()
@@ -83,5 +171,35 @@ func NewTransactionEvent(
 	if tx.TTL() != 0 {
 		evt.TTL = tx.TTL()
 	}
+	evt.Extra = extractExtra(block.Era().Id, tx)
 	return evt
 }
+
+// Addresses returns the distinct addresses involved in the transaction, in the order they were first seen. Addresses
+// from ResolvedInputs are marked as spent from and addresses from Outputs as paid to. The chain-sync protocol does
+// not provide the outputs being spent, so ResolvedInputs is only populated when they've been looked up separately
+func (e TransactionEvent) Addresses() []TransactionAddress {
+	ret := []TransactionAddress{}
+	addrIdx := map[string]int{}
+	addAddress := func(addr string, role string) {
+		idx, ok := addrIdx[addr]
+		if !ok {
+			addrIdx[addr] = len(ret)
+			ret = append(ret, TransactionAddress{Address: addr, Roles: []string{role}})
+			return
+		}
+		for _, tmpRole := range ret[idx].Roles {
+			if tmpRole == role {
+				return
+			}
+		}
+		ret[idx].Roles = append(ret[idx].Roles, role)
+	}
+	for _, input := range e.ResolvedInputs {
+		addAddress(input.Address().String(), AddressRoleSpentFrom)
+	}
+	for _, output := range e.Outputs {
+		addAddress(output.Address().String(), AddressRolePaidTo)
+	}
+	return ret
+}
@@ -0,0 +1,60 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"sync"
+
+	"github.com/blinklabs-io/adder/event"
+)
+
+// txBuffer is a fixed-size ring buffer of recent transaction events, indexed by transaction hash
+type txBuffer struct {
+	sync.RWMutex
+	hashes []string
+	next   int
+	events map[string]event.Event
+}
+
+func newTxBuffer(size int) *txBuffer {
+	return &txBuffer{
+		hashes: make([]string, size),
+		events: make(map[string]event.Event, size),
+	}
+}
+
+// add stores a transaction event, evicting the oldest buffered event if the buffer is full
+func (b *txBuffer) add(hash string, evt event.Event) {
+	b.Lock()
+	defer b.Unlock()
+	if _, ok := b.events[hash]; ok {
+		b.events[hash] = evt
+		return
+	}
+	if oldHash := b.hashes[b.next]; oldHash != "" {
+		delete(b.events, oldHash)
+	}
+	b.hashes[b.next] = hash
+	b.events[hash] = evt
+	b.next = (b.next + 1) % len(b.hashes)
+}
+
+// get returns the buffered transaction event for the specified hash
+func (b *txBuffer) get(hash string) (event.Event, bool) {
+	b.RLock()
+	defer b.RUnlock()
+	evt, ok := b.events[hash]
+	return evt, ok
+}
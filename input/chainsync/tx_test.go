@@ -0,0 +1,183 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBlock struct {
+	ledger.Block
+	transactions []ledger.Transaction
+	era          ledger.Era
+}
+
+func (b mockBlock) Hash() string                       { return "abcd" }
+func (b mockBlock) BlockNumber() uint64                { return 12 }
+func (b mockBlock) SlotNumber() uint64                 { return 3456 }
+func (b mockBlock) Transactions() []ledger.Transaction { return b.transactions }
+func (b mockBlock) Era() ledger.Era                    { return b.era }
+func (b mockBlock) BlockBodySize() uint64              { return 0 }
+func (b mockBlock) IssuerVkey() ledger.IssuerVkey      { return ledger.IssuerVkey{} }
+
+type mockTransaction struct {
+	ledger.Transaction
+	outputs            []ledger.TransactionOutput
+	metadata           *cbor.LazyValue
+	fee                uint64
+	scriptDataHash     *ledger.Blake2b256
+	proposalProcedures []ledger.ProposalProcedure
+	certificates       []ledger.Certificate
+}
+
+func (t mockTransaction) Hash() string                               { return "deadbeef" }
+func (t mockTransaction) Inputs() []ledger.TransactionInput          { return nil }
+func (t mockTransaction) Outputs() []ledger.TransactionOutput        { return t.outputs }
+func (t mockTransaction) Fee() uint64                                { return t.fee }
+func (t mockTransaction) TTL() uint64                                { return 0 }
+func (t mockTransaction) Certificates() []ledger.Certificate         { return t.certificates }
+func (t mockTransaction) ReferenceInputs() []ledger.TransactionInput { return nil }
+func (t mockTransaction) Metadata() *cbor.LazyValue                  { return t.metadata }
+func (t mockTransaction) ScriptDataHash() *ledger.Blake2b256         { return t.scriptDataHash }
+func (t mockTransaction) VotingProcedures() ledger.VotingProcedures  { return nil }
+func (t mockTransaction) ProposalProcedures() []ledger.ProposalProcedure {
+	return t.proposalProcedures
+}
+
+type mockOutput struct {
+	ledger.TransactionOutput
+	datum   *cbor.LazyValue
+	address ledger.Address
+	amount  uint64
+	cbor    []byte
+}
+
+func (o mockOutput) Datum() *cbor.LazyValue  { return o.datum }
+func (o mockOutput) Address() ledger.Address { return o.address }
+func (o mockOutput) Amount() uint64          { return o.amount }
+func (o mockOutput) Cbor() []byte            { return o.cbor }
+
+func (o mockOutput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{"amount": 1, "datum": o.datum})
+}
+
+func newLazyValue(t *testing.T, value any) *cbor.LazyValue {
+	data, err := cbor.Encode(value)
+	if err != nil {
+		t.Fatalf("unexpected error encoding CBOR: %s", err)
+	}
+	lv := &cbor.LazyValue{}
+	if err := lv.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("unexpected error decoding CBOR: %s", err)
+	}
+	return lv
+}
+
+func TestNewTransactionEventDatumCap(t *testing.T) {
+	smallDatum := newLazyValue(t, []byte("small"))
+	largeDatum := newLazyValue(t, bytes.Repeat([]byte{0xab}, 1024))
+	tx := mockTransaction{
+		outputs: []ledger.TransactionOutput{
+			mockOutput{datum: smallDatum},
+			mockOutput{datum: largeDatum},
+		},
+	}
+	evt := chainsync.NewTransactionEvent(mockBlock{}, tx, false, chainsync.WithTxMaxDatumBytes(64))
+	assert.Equal(t, smallDatum, evt.Outputs[0].Datum())
+	assert.Nil(t, evt.Outputs[1].Datum())
+	// Original transaction outputs should be untouched
+	assert.Equal(t, largeDatum, tx.Outputs()[1].Datum())
+	data, err := json.Marshal(evt.Outputs[1])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":1,"datumTruncated":true}`, string(data))
+	// No limit
+	evt = chainsync.NewTransactionEvent(mockBlock{}, tx, false)
+	assert.Equal(t, largeDatum, evt.Outputs[1].Datum())
+}
+
+func TestNewTransactionEventMetadataCap(t *testing.T) {
+	metadata := newLazyValue(
+		t,
+		map[uint64]string{674: string(bytes.Repeat([]byte("a"), 256))},
+	)
+	tx := mockTransaction{metadata: metadata}
+	evt := chainsync.NewTransactionEvent(mockBlock{}, tx, false, chainsync.WithTxMaxMetadataBytes(128))
+	assert.Nil(t, evt.Metadata)
+	assert.True(t, evt.MetadataTruncated)
+	evt = chainsync.NewTransactionEvent(mockBlock{}, tx, false, chainsync.WithTxMaxMetadataBytes(1024))
+	assert.Equal(t, metadata, evt.Metadata)
+	assert.False(t, evt.MetadataTruncated)
+}
+
+func newAddress(t *testing.T, hashByte byte) ledger.Address {
+	paymentHash := bytes.Repeat([]byte{hashByte}, ledger.AddressHashSize)
+	addr, err := ledger.NewAddressFromParts(
+		ledger.AddressTypeKeyNone,
+		ledger.AddressNetworkMainnet,
+		paymentHash,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating address: %s", err)
+	}
+	return addr
+}
+
+func TestTransactionEventAddresses(t *testing.T) {
+	addrA := newAddress(t, 0x0a)
+	addrB := newAddress(t, 0x0b)
+	addrC := newAddress(t, 0x0c)
+	evt := chainsync.TransactionEvent{
+		ResolvedInputs: []ledger.TransactionOutput{
+			mockOutput{address: addrA},
+			mockOutput{address: addrB},
+			mockOutput{address: addrA},
+		},
+		Outputs: []ledger.TransactionOutput{
+			mockOutput{address: addrC},
+			mockOutput{address: addrA},
+			mockOutput{address: addrC},
+		},
+	}
+
+	assert.Equal(
+		t,
+		[]chainsync.TransactionAddress{
+			{
+				Address: addrA.String(),
+				Roles: []string{
+					chainsync.AddressRoleSpentFrom,
+					chainsync.AddressRolePaidTo,
+				},
+			},
+			{
+				Address: addrB.String(),
+				Roles:   []string{chainsync.AddressRoleSpentFrom},
+			},
+			{
+				Address: addrC.String(),
+				Roles:   []string{chainsync.AddressRolePaidTo},
+			},
+		},
+		evt.Addresses(),
+	)
+}
@@ -0,0 +1,148 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+)
+
+// ChainUnstableEvent signals that rapid repeated rollbacks were detected. Forwarding of events is paused until
+// the rollbacks subside, at which point a single rollback event to the earliest rollback point is emitted
+// followed by any blocks that weren't themselves rolled back
+type ChainUnstableEvent struct {
+	Rollbacks  int    `json:"rollbacks"`
+	BlockHash  string `json:"blockHash"`
+	SlotNumber uint64 `json:"slotNumber"`
+}
+
+func NewChainUnstableEvent(rollbacks int, point ocommon.Point) ChainUnstableEvent {
+	blockHashHex := hex.EncodeToString(point.Hash)
+	evt := ChainUnstableEvent{
+		Rollbacks:  rollbacks,
+		BlockHash:  blockHashHex,
+		SlotNumber: point.Slot,
+	}
+	return evt
+}
+
+// rollbackStorm tracks recent rollbacks and the events held back while forwarding is paused
+type rollbackStorm struct {
+	rollbacks     []time.Time
+	paused        bool
+	rollbackPoint ocommon.Point
+	pending       []pendingEvent
+}
+
+type pendingEvent struct {
+	slot uint64
+	evt  event.Event
+}
+
+// handleRollbackStorm records a rollback and returns true if it was absorbed because forwarding is paused
+func (c *ChainSync) handleRollbackStorm(point ocommon.Point) bool {
+	if c.rollbackStormThreshold <= 0 {
+		return false
+	}
+	s := &c.rollbackStorm
+	now := time.Now()
+	// Forget about rollbacks that have aged out of the window
+	cutoff := now.Add(-c.rollbackStormWindow)
+	idx := 0
+	for idx < len(s.rollbacks) && s.rollbacks[idx].Before(cutoff) {
+		idx++
+	}
+	s.rollbacks = append(s.rollbacks[idx:], now)
+	if s.paused {
+		// Discard held back events that this rollback has undone
+		pending := s.pending[:0]
+		for _, tmpEvt := range s.pending {
+			if tmpEvt.slot <= point.Slot {
+				pending = append(pending, tmpEvt)
+			}
+		}
+		s.pending = pending
+		if point.Slot < s.rollbackPoint.Slot {
+			s.rollbackPoint = point
+		}
+		return true
+	}
+	if len(s.rollbacks) <= c.rollbackStormThreshold {
+		return false
+	}
+	if c.logger != nil {
+		c.logger.Warnf(
+			"detected %d rollbacks within %s, pausing events until the chain stabilizes",
+			len(s.rollbacks),
+			c.rollbackStormWindow,
+		)
+	}
+	s.paused = true
+	s.rollbackPoint = point
+	evt := event.New(
+		"chainsync.unstable",
+		time.Now(),
+		nil,
+		NewChainUnstableEvent(len(s.rollbacks), point),
+	)
+	c.eventChan <- evt
+	return true
+}
+
+// sendEvent forwards an event for the block at the specified slot, or holds it back while paused due to a
+// rollback storm. Forwarding resumes once a full window has passed without a rollback, or early once the
+// maximum number of events are held back
+func (c *ChainSync) sendEvent(evt event.Event, slot uint64) {
+	s := &c.rollbackStorm
+	if s.paused {
+		lastRollback := s.rollbacks[len(s.rollbacks)-1]
+		stable := time.Since(lastRollback) >= c.rollbackStormWindow
+		full := c.rollbackStormMaxEvents > 0 && len(s.pending) >= c.rollbackStormMaxEvents
+		if !stable && !full {
+			s.pending = append(s.pending, pendingEvent{slot: slot, evt: evt})
+			return
+		}
+		if c.logger != nil {
+			if stable {
+				c.logger.Infof(
+					"chain has stabilized, resuming events from rollback to slot %d",
+					s.rollbackPoint.Slot,
+				)
+			} else {
+				c.logger.Warnf(
+					"held back %d events during rollback storm, resuming events from rollback to slot %d",
+					len(s.pending),
+					s.rollbackPoint.Slot,
+				)
+			}
+		}
+		c.eventChan <- event.New(
+			"chainsync.rollback",
+			time.Now(),
+			nil,
+			NewRollbackEvent(s.rollbackPoint),
+		)
+		for _, tmpEvt := range s.pending {
+			c.eventChan <- tmpEvt.evt
+		}
+		s.paused = false
+		s.pending = nil
+	}
+	c.eventChan <- evt
+}
@@ -0,0 +1,120 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chainsync_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	ocommon "github.com/blinklabs-io/gouroboros/protocol/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollbackStorm(t *testing.T) {
+	c := chainsync.New(
+		chainsync.WithRollbackStormThreshold(2),
+		chainsync.WithRollbackStormWindow(100*time.Millisecond),
+	)
+	// A burst of rollbacks above the threshold emits a single unstable event
+	for _, slot := range []uint64{300, 200, 100, 150} {
+		assert.NoError(
+			t,
+			c.HandleRollBackward(ocommon.NewPoint(slot, []byte{0xab})),
+		)
+	}
+	// Forwarding is paused, and the held back block is undone by a later rollback
+	assert.NoError(t, c.HandleRollForward(mockBlock{}))
+	assert.NoError(
+		t,
+		c.HandleRollBackward(ocommon.NewPoint(120, []byte{0xab})),
+	)
+	// Forwarding resumes with a single rollback once a window passes without a rollback
+	time.Sleep(150 * time.Millisecond)
+	assert.NoError(t, c.HandleRollForward(mockBlock{}))
+	expected := []struct {
+		eventType string
+		slot      uint64
+	}{
+		{"chainsync.rollback", 300},
+		{"chainsync.rollback", 200},
+		{"chainsync.unstable", 100},
+		{"chainsync.rollback", 100},
+		{"chainsync.block", 3456},
+	}
+	for _, tmpExpected := range expected {
+		var evt event.Event
+		select {
+		case evt = <-c.OutputChan():
+		default:
+			t.Fatalf("did not receive expected %s event", tmpExpected.eventType)
+		}
+		assert.Equal(t, tmpExpected.eventType, evt.Type)
+		switch payload := evt.Payload.(type) {
+		case chainsync.RollbackEvent:
+			assert.Equal(t, tmpExpected.slot, payload.SlotNumber)
+		case chainsync.ChainUnstableEvent:
+			assert.Equal(t, 3, payload.Rollbacks)
+			assert.Equal(t, tmpExpected.slot, payload.SlotNumber)
+		case chainsync.BlockEvent:
+			assert.Equal(t, tmpExpected.slot, evt.Context.(chainsync.BlockContext).SlotNumber)
+		}
+	}
+	select {
+	case evt := <-c.OutputChan():
+		t.Fatalf("unexpected %s event", evt.Type)
+	default:
+	}
+}
+
+func TestRollbackStormMaxEvents(t *testing.T) {
+	c := chainsync.New(
+		chainsync.WithRollbackStormThreshold(1),
+		chainsync.WithRollbackStormWindow(time.Hour),
+		chainsync.WithRollbackStormMaxEvents(2),
+	)
+	for _, slot := range []uint64{200, 100} {
+		assert.NoError(
+			t,
+			c.HandleRollBackward(ocommon.NewPoint(slot, []byte{0xab})),
+		)
+	}
+	// Forwarding resumes early once the maximum number of events are held back, despite the window not passing
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, c.HandleRollForward(mockBlock{}))
+	}
+	expected := []string{
+		"chainsync.rollback",
+		"chainsync.unstable",
+		"chainsync.rollback",
+		"chainsync.block",
+		"chainsync.block",
+		"chainsync.block",
+	}
+	for _, eventType := range expected {
+		select {
+		case evt := <-c.OutputChan():
+			assert.Equal(t, eventType, evt.Type)
+		default:
+			t.Fatalf("did not receive expected %s event", eventType)
+		}
+	}
+	select {
+	case evt := <-c.OutputChan():
+		t.Fatalf("unexpected %s event", evt.Type)
+	default:
+	}
+}
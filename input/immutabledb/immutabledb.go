@@ -0,0 +1,290 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package immutabledb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/plugin"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+const (
+	chunkFileExt = ".chunk"
+	// Name of the immutable DB directory within a cardano-node database directory
+	immutableDirName = "immutable"
+)
+
+// ImmutableDb emits events for the blocks stored in a cardano-node immutable DB directory, so that historical
+// blocks can be processed without a running node
+type ImmutableDb struct {
+	errorChan    chan error
+	eventChan    chan event.Event
+	stopChan     chan struct{}
+	doneChan     chan struct{}
+	stopOnce     sync.Once
+	started      bool
+	logger       plugin.Logger
+	dbPath       string
+	fromSlot     uint64
+	networkMagic uint32
+}
+
+// New returns a new ImmutableDb object with the specified options applied
+func New(options ...ImmutableDbOptionFunc) *ImmutableDb {
+	i := &ImmutableDb{
+		errorChan: make(chan error),
+		eventChan: make(chan event.Event, 10),
+		stopChan:  make(chan struct{}),
+		doneChan:  make(chan struct{}),
+	}
+	for _, option := range options {
+		option(i)
+	}
+	return i
+}
+
+// Start the immutable DB input
+func (i *ImmutableDb) Start() error {
+	chunkFiles, err := findChunkFiles(i.dbPath)
+	if err != nil {
+		return err
+	}
+	if i.logger != nil {
+		i.logger.Infof(
+			"reading blocks from %d chunk file(s) in %s",
+			len(chunkFiles),
+			i.dbPath,
+		)
+	}
+	i.started = true
+	go i.readLoop(chunkFiles)
+	return nil
+}
+
+// findChunkFiles returns the chunk files in the immutable DB directory, ordered by chunk number. The path can also
+// be a cardano-node database directory containing the immutable DB
+func findChunkFiles(dbPath string) ([]string, error) {
+	for _, dir := range []string{dbPath, filepath.Join(dbPath, immutableDirName)} {
+		matches, err := filepath.Glob(filepath.Join(dir, "*"+chunkFileExt))
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		chunkFiles := make([]string, 0, len(matches))
+		chunkNums := make(map[string]uint64, len(matches))
+		for _, match := range matches {
+			num, err := strconv.ParseUint(
+				strings.TrimSuffix(filepath.Base(match), chunkFileExt),
+				10,
+				64,
+			)
+			// Skip files that don't look like chunks
+			if err != nil {
+				continue
+			}
+			chunkFiles = append(chunkFiles, match)
+			chunkNums[match] = num
+		}
+		sort.Slice(chunkFiles, func(a, b int) bool {
+			return chunkNums[chunkFiles[a]] < chunkNums[chunkFiles[b]]
+		})
+		if len(chunkFiles) > 0 {
+			return chunkFiles, nil
+		}
+	}
+	return nil, fmt.Errorf("no chunk files found in %s", dbPath)
+}
+
+// readLoop emits the events for the blocks in each chunk file in turn. The event channel is closed once all of the
+// chunk files have been read
+func (i *ImmutableDb) readLoop(chunkFiles []string) {
+	defer close(i.doneChan)
+	defer close(i.eventChan)
+	for idx, chunkFile := range chunkFiles {
+		// The most recent chunk may still be being written to by the node, so a truncated block at the end of it
+		// is expected
+		isLast := idx == len(chunkFiles)-1
+		if err := i.readChunk(chunkFile, isLast); err != nil {
+			if errors.Is(err, errStopped) {
+				return
+			}
+			i.sendError(err)
+			return
+		}
+	}
+	if i.logger != nil {
+		i.logger.Infof("finished reading blocks from %s", i.dbPath)
+	}
+}
+
+var errStopped = errors.New("stopped")
+
+// diskBlock is a block as stored in a chunk file, wrapped with its block type
+type diskBlock struct {
+	cbor.StructAsArray
+	BlockType uint
+	BlockCbor cbor.RawMessage
+}
+
+// readChunk decodes the blocks in a chunk file, which contains them back to back, and emits their events
+func (i *ImmutableDb) readChunk(chunkFile string, isLast bool) error {
+	data, err := os.ReadFile(chunkFile)
+	if err != nil {
+		return fmt.Errorf("failed to read chunk file: %w", err)
+	}
+	for offset := 0; offset < len(data); {
+		var tmpBlock diskBlock
+		bytesRead, err := cbor.Decode(data[offset:], &tmpBlock)
+		if err != nil {
+			if isLast && errors.Is(err, io.ErrUnexpectedEOF) {
+				if i.logger != nil {
+					i.logger.Warnf(
+						"ignoring incomplete block at offset %d in %s",
+						offset,
+						chunkFile,
+					)
+				}
+				return nil
+			}
+			return fmt.Errorf(
+				"failed to decode block at offset %d in %s: %w",
+				offset,
+				chunkFile,
+				err,
+			)
+		}
+		offset += bytesRead
+		skip, err := i.skipBlock(tmpBlock)
+		if err != nil {
+			return fmt.Errorf("failed to decode block header in %s: %w", chunkFile, err)
+		}
+		if skip {
+			continue
+		}
+		block, err := ledger.NewBlockFromCbor(tmpBlock.BlockType, tmpBlock.BlockCbor)
+		if err != nil {
+			return fmt.Errorf("failed to decode block in %s: %w", chunkFile, err)
+		}
+		if err := i.emitBlockEvents(block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// skipBlock returns whether the block is before the starting slot. Only the block header is decoded, which is
+// much cheaper than the full block when skipping through a large number of chunks
+func (i *ImmutableDb) skipBlock(tmpBlock diskBlock) (bool, error) {
+	if i.fromSlot == 0 {
+		return false, nil
+	}
+	var blockItems []cbor.RawMessage
+	if _, err := cbor.Decode(tmpBlock.BlockCbor, &blockItems); err != nil {
+		return false, err
+	}
+	if len(blockItems) == 0 {
+		return false, fmt.Errorf("empty block")
+	}
+	header, err := ledger.NewBlockHeaderFromCbor(tmpBlock.BlockType, blockItems[0])
+	if err != nil {
+		return false, err
+	}
+	return header.SlotNumber() < i.fromSlot, nil
+}
+
+// emitBlockEvents emits the block event followed by an event for each of its transactions
+func (i *ImmutableDb) emitBlockEvents(block ledger.Block) error {
+	events := []event.Event{
+		event.New(
+			"chainsync.block",
+			time.Now(),
+			chainsync.NewBlockContext(block, i.networkMagic),
+			chainsync.NewBlockEvent(block, false, false),
+		),
+	}
+	for t, transaction := range block.Transactions() {
+		events = append(
+			events,
+			event.New(
+				"chainsync.transaction",
+				time.Now(),
+				chainsync.NewTransactionContext(
+					block,
+					transaction,
+					uint32(t),
+					i.networkMagic,
+				),
+				chainsync.NewTransactionEvent(block, transaction, false),
+			),
+		)
+	}
+	for _, evt := range events {
+		select {
+		case i.eventChan <- evt:
+		case <-i.stopChan:
+			return errStopped
+		}
+	}
+	return nil
+}
+
+func (i *ImmutableDb) sendError(err error) {
+	select {
+	case i.errorChan <- err:
+	case <-i.stopChan:
+	}
+}
+
+// Stop the immutable DB input
+func (i *ImmutableDb) Stop() error {
+	i.stopOnce.Do(func() {
+		close(i.stopChan)
+		if i.started {
+			<-i.doneChan
+		}
+		close(i.errorChan)
+	})
+	return nil
+}
+
+// ErrorChan returns the input error channel
+func (i *ImmutableDb) ErrorChan() chan error {
+	return i.errorChan
+}
+
+// InputChan always returns nil
+func (i *ImmutableDb) InputChan() chan<- event.Event {
+	return nil
+}
+
+// OutputChan returns the output event channel
+func (i *ImmutableDb) OutputChan() <-chan event.Event {
+	return i.eventChan
+}
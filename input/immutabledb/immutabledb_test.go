@@ -0,0 +1,192 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package immutabledb_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/input/immutabledb"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestBlock returns a Shelley block with the specified number of transactions, wrapped with its block type as
+// stored in a chunk file
+func newTestBlock(t *testing.T, blockNumber uint64, slot uint64, txCount int) []byte {
+	header := &ledger.ShelleyBlockHeader{}
+	header.Body.BlockNumber = blockNumber
+	header.Body.Slot = slot
+	block := &ledger.ShelleyBlock{
+		Header:                 header,
+		TransactionBodies:      []ledger.ShelleyTransactionBody{},
+		TransactionWitnessSets: []ledger.ShelleyTransactionWitnessSet{},
+		TransactionMetadataSet: map[uint]*cbor.LazyValue{},
+	}
+	for i := 0; i < txCount; i++ {
+		block.TransactionBodies = append(
+			block.TransactionBodies,
+			ledger.ShelleyTransactionBody{TxFee: 170_000 + uint64(i)},
+		)
+		block.TransactionWitnessSets = append(
+			block.TransactionWitnessSets,
+			ledger.ShelleyTransactionWitnessSet{},
+		)
+	}
+	blockCbor, err := cbor.Encode(block)
+	if err != nil {
+		t.Fatalf("unexpected error encoding block: %s", err)
+	}
+	data, err := cbor.Encode(
+		[]any{ledger.BlockTypeShelley, cbor.RawMessage(blockCbor)},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error encoding block: %s", err)
+	}
+	return data
+}
+
+// newTestDb writes a chunk file for each list of blocks and returns the DB directory
+func newTestDb(t *testing.T, chunks ...[][]byte) string {
+	dbPath := t.TempDir()
+	for idx, blocks := range chunks {
+		chunkPath := filepath.Join(dbPath, fmt.Sprintf("%05d.chunk", idx))
+		if err := os.WriteFile(chunkPath, bytes.Join(blocks, nil), 0o600); err != nil {
+			t.Fatalf("unexpected error writing chunk file: %s", err)
+		}
+	}
+	return dbPath
+}
+
+// receiveAll returns the events emitted until the output channel is closed
+func receiveAll(t *testing.T, i *immutabledb.ImmutableDb) []event.Event {
+	ret := []event.Event{}
+	for {
+		select {
+		case evt, ok := <-i.OutputChan():
+			if !ok {
+				return ret
+			}
+			ret = append(ret, evt)
+		case err := <-i.ErrorChan():
+			t.Fatalf("unexpected error: %s", err)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for events")
+		}
+	}
+}
+
+// blockSlots returns the slots of the block events
+func blockSlots(events []event.Event) []uint64 {
+	ret := []uint64{}
+	for _, evt := range events {
+		if ctx, ok := evt.Context.(chainsync.BlockContext); ok {
+			ret = append(ret, ctx.SlotNumber)
+		}
+	}
+	return ret
+}
+
+func TestImmutableDb(t *testing.T) {
+	// Chunks are read in numeric order, including across an epoch boundary
+	dbPath := newTestDb(
+		t,
+		[][]byte{newTestBlock(t, 1, 100, 0), newTestBlock(t, 2, 120, 2)},
+		[][]byte{newTestBlock(t, 3, 21600, 1)},
+	)
+	i := immutabledb.New(
+		immutabledb.WithDbPath(dbPath),
+		immutabledb.WithNetworkMagic(2),
+	)
+	assert.NoError(t, i.Start())
+	events := receiveAll(t, i)
+	assert.NoError(t, i.Stop())
+
+	types := []string{}
+	for _, evt := range events {
+		types = append(types, evt.Type)
+	}
+	assert.Equal(
+		t,
+		[]string{
+			"chainsync.block",
+			"chainsync.block",
+			"chainsync.transaction",
+			"chainsync.transaction",
+			"chainsync.block",
+			"chainsync.transaction",
+		},
+		types,
+	)
+	assert.Equal(t, []uint64{100, 120, 21600}, blockSlots(events))
+	assert.Equal(
+		t,
+		chainsync.BlockContext{
+			BlockNumber:  2,
+			SlotNumber:   120,
+			NetworkMagic: 2,
+			Era:          "Shelley",
+		},
+		events[1].Context,
+	)
+	txCtx := events[3].Context.(chainsync.TransactionContext)
+	assert.Equal(t, uint64(120), txCtx.SlotNumber)
+	assert.Equal(t, uint32(1), txCtx.TransactionIdx)
+	assert.Equal(t, uint64(170_001), events[3].Payload.(chainsync.TransactionEvent).Fee)
+}
+
+func TestImmutableDbFromSlot(t *testing.T) {
+	dbPath := newTestDb(
+		t,
+		[][]byte{newTestBlock(t, 1, 100, 1), newTestBlock(t, 2, 120, 0)},
+		[][]byte{newTestBlock(t, 3, 21600, 0)},
+	)
+	i := immutabledb.New(
+		immutabledb.WithDbPath(dbPath),
+		immutabledb.WithFromSlot(110),
+	)
+	assert.NoError(t, i.Start())
+	events := receiveAll(t, i)
+	assert.NoError(t, i.Stop())
+	assert.Len(t, events, 2)
+	assert.Equal(t, []uint64{120, 21600}, blockSlots(events))
+}
+
+func TestImmutableDbTruncatedChunk(t *testing.T) {
+	lastBlock := newTestBlock(t, 3, 140, 0)
+	dbPath := newTestDb(
+		t,
+		[][]byte{newTestBlock(t, 1, 100, 0)},
+		[][]byte{newTestBlock(t, 2, 120, 0), lastBlock[:len(lastBlock)/2]},
+	)
+	i := immutabledb.New(immutabledb.WithDbPath(dbPath))
+	assert.NoError(t, i.Start())
+	events := receiveAll(t, i)
+	assert.NoError(t, i.Stop())
+	assert.Equal(t, []uint64{100, 120}, blockSlots(events))
+}
+
+func TestImmutableDbNoChunks(t *testing.T) {
+	i := immutabledb.New(immutabledb.WithDbPath(t.TempDir()))
+	assert.ErrorContains(t, i.Start(), "no chunk files found")
+	assert.NoError(t, i.Stop())
+}
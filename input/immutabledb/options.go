@@ -0,0 +1,48 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package immutabledb
+
+import "github.com/blinklabs-io/adder/plugin"
+
+type ImmutableDbOptionFunc func(*ImmutableDb)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) ImmutableDbOptionFunc {
+	return func(i *ImmutableDb) {
+		i.logger = logger
+	}
+}
+
+// WithDbPath specifies the path of the immutable DB directory to read blocks from. This can also be the
+// cardano-node database directory that contains it
+func WithDbPath(dbPath string) ImmutableDbOptionFunc {
+	return func(i *ImmutableDb) {
+		i.dbPath = dbPath
+	}
+}
+
+// WithFromSlot specifies the slot to start emitting events from. Blocks before this slot are skipped
+func WithFromSlot(fromSlot uint64) ImmutableDbOptionFunc {
+	return func(i *ImmutableDb) {
+		i.fromSlot = fromSlot
+	}
+}
+
+// WithNetworkMagic specifies the network magic value to include in the event context
+func WithNetworkMagic(networkMagic uint32) ImmutableDbOptionFunc {
+	return func(i *ImmutableDb) {
+		i.networkMagic = networkMagic
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package immutabledb
+
+import (
+	"fmt"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+	ouroboros "github.com/blinklabs-io/gouroboros"
+)
+
+var cmdlineOptions struct {
+	dbPath       string
+	fromSlot     uint
+	network      string
+	networkMagic uint
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeInput,
+			Name:               "immutabledb",
+			Description:        "emits events for the blocks in a cardano-node immutable DB directory, without a running node",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "db-path",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the path of the immutable DB directory, or the node database directory containing it",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.dbPath),
+				},
+				{
+					Name:         "from-slot",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the slot to start emitting events from",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.fromSlot),
+				},
+				{
+					Name:         "network",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies a well-known Cardano network name",
+					DefaultValue: "mainnet",
+					Dest:         &(cmdlineOptions.network),
+				},
+				{
+					Name:         "network-magic",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the network magic value to use, overrides 'network'",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.networkMagic),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	networkMagic := uint32(cmdlineOptions.networkMagic)
+	if networkMagic == 0 {
+		network := ouroboros.NetworkByName(cmdlineOptions.network)
+		if network == ouroboros.NetworkInvalid {
+			panic(fmt.Sprintf("unknown network: %s", cmdlineOptions.network))
+		}
+		networkMagic = network.NetworkMagic
+	}
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "input.immutabledb"),
+		),
+		WithDbPath(cmdlineOptions.dbPath),
+		WithFromSlot(uint64(cmdlineOptions.fromSlot)),
+		WithNetworkMagic(networkMagic),
+	)
+	return p
+}
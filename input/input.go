@@ -17,4 +17,8 @@ package input
 // We import the various plugins that we want to be auto-registered
 import (
 	_ "github.com/blinklabs-io/adder/input/chainsync"
+	_ "github.com/blinklabs-io/adder/input/immutabledb"
+	_ "github.com/blinklabs-io/adder/input/replay"
+	_ "github.com/blinklabs-io/adder/input/synthetic"
+	_ "github.com/blinklabs-io/adder/input/txsubmit"
 )
@@ -0,0 +1,88 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"encoding/json"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+)
+
+type decodeFunc func(data json.RawMessage) (any, error)
+
+// eventDecoders maps event types to the decoders for their context and payload. Certificate payloads include ledger
+// types that can't be rebuilt from JSON, so they're decoded generically like unknown event types
+var eventDecoders = map[string]struct {
+	context decodeFunc
+	payload decodeFunc
+}{
+	"chainsync.block": {
+		decodeAs[chainsync.BlockContext],
+		decodeAs[chainsync.BlockEvent],
+	},
+	"chainsync.transaction":          {decodeAs[chainsync.TransactionContext], decodeTransaction},
+	"chainsync.certificate":          {decodeAs[chainsync.CertificateContext], decodeGeneric},
+	"chainsync.rollback":             {decodeGeneric, decodeAs[chainsync.RollbackEvent]},
+	"chainsync.reset":                {decodeGeneric, decodeAs[chainsync.ResetEvent]},
+	"chainsync.unstable":             {decodeGeneric, decodeAs[chainsync.ChainUnstableEvent]},
+	"chainsync.stake_registration":   {decodeAs[chainsync.CertificateContext], decodeAs[chainsync.StakeRegistrationEvent]},
+	"chainsync.stake_deregistration": {decodeAs[chainsync.CertificateContext], decodeAs[chainsync.StakeRegistrationEvent]},
+}
+
+// decodeEvent rebuilds an event, using the original context and payload types for known event types
+func decodeEvent(raw rawEvent) (event.Event, error) {
+	contextDecoder, payloadDecoder := decodeFunc(decodeGeneric), decodeFunc(decodeGeneric)
+	if decoders, ok := eventDecoders[event.BaseType(raw.Type)]; ok {
+		contextDecoder, payloadDecoder = decoders.context, decoders.payload
+	}
+	evt := event.Event{
+		Type:      raw.Type,
+		Timestamp: raw.Timestamp,
+	}
+	var err error
+	if len(raw.Context) > 0 && string(raw.Context) != "null" {
+		if evt.Context, err = contextDecoder(raw.Context); err != nil {
+			return evt, err
+		}
+	}
+	if evt.Payload, err = payloadDecoder(raw.Payload); err != nil {
+		return evt, err
+	}
+	if te, ok := evt.Payload.(chainsync.TransactionEvent); ok {
+		txCtx, _ := evt.Context.(chainsync.TransactionContext)
+		if err := rebuildTransaction(&te, txCtx.Era); err != nil {
+			return evt, err
+		}
+		evt.Payload = te
+	}
+	return evt, nil
+}
+
+func decodeAs[T any](data json.RawMessage) (any, error) {
+	var ret T
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func decodeGeneric(data json.RawMessage) (any, error) {
+	var ret any
+	if err := json.Unmarshal(data, &ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import "github.com/blinklabs-io/adder/plugin"
+
+type ReplayOptionFunc func(*Replay)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) ReplayOptionFunc {
+	return func(r *Replay) {
+		r.logger = logger
+	}
+}
+
+// WithPath specifies the path of the newline-delimited JSON file to replay events from
+func WithPath(path string) ReplayOptionFunc {
+	return func(r *Replay) {
+		r.path = path
+	}
+}
+
+// WithSpeed specifies how fast to replay events relative to the time between them when they were captured. For
+// example, 2 replays events twice as fast, and 0 replays them as fast as possible. The default is 1
+func WithSpeed(speed float64) ReplayOptionFunc {
+	return func(r *Replay) {
+		r.speed = speed
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	path  string
+	speed string
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeInput,
+			Name:               "replay",
+			Description:        "replays events from a newline-delimited JSON file, such as captured from the log output",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "path",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the path of the file to replay events from",
+					DefaultValue: "events.jsonl",
+					Dest:         &(cmdlineOptions.path),
+				},
+				{
+					Name:         "speed",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the replay speed relative to the original time between events (0 for as fast as possible)",
+					DefaultValue: "1",
+					Dest:         &(cmdlineOptions.speed),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	speed, err := strconv.ParseFloat(cmdlineOptions.speed, 64)
+	if err != nil || speed < 0 {
+		panic(fmt.Sprintf("invalid replay speed: %s", cmdlineOptions.speed))
+	}
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "input.replay"),
+		),
+		WithPath(cmdlineOptions.path),
+		WithSpeed(speed),
+	)
+	return p
+}
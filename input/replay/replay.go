@@ -0,0 +1,190 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+const (
+	// Maximum length of a line in the replay file, which needs to fit blocks that include their CBOR
+	maxLineSize = 64 * 1024 * 1024
+)
+
+// Replay emits events previously captured as newline-delimited JSON, such as by the log output
+type Replay struct {
+	errorChan chan error
+	eventChan chan event.Event
+	stopChan  chan struct{}
+	doneChan  chan struct{}
+	stopOnce  sync.Once
+	logger    plugin.Logger
+	path      string
+	speed     float64
+	file      *os.File
+}
+
+// New returns a new Replay object with the specified options applied
+func New(options ...ReplayOptionFunc) *Replay {
+	r := &Replay{
+		errorChan: make(chan error),
+		eventChan: make(chan event.Event, 10),
+		stopChan:  make(chan struct{}),
+		doneChan:  make(chan struct{}),
+		speed:     1,
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Start the replay input
+func (r *Replay) Start() error {
+	file, err := os.Open(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to open replay file: %w", err)
+	}
+	r.file = file
+	if r.logger != nil {
+		r.logger.Infof("replaying events from %s", r.path)
+	}
+	go r.replayLoop()
+	return nil
+}
+
+// replayLoop reads events from the replay file and emits them, waiting between events for the time that passed
+// between them originally scaled by the speed. The event channel is closed once the file has been read
+func (r *Replay) replayLoop() {
+	defer close(r.doneChan)
+	defer close(r.eventChan)
+	defer r.file.Close()
+	scanner := bufio.NewScanner(r.file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	var lastTimestamp time.Time
+	var lineNum int
+	for scanner.Scan() {
+		lineNum++
+		evt, ok, err := decodeLine(scanner.Bytes())
+		if err != nil {
+			r.sendError(fmt.Errorf("failed to decode event on line %d: %w", lineNum, err))
+			return
+		}
+		// Skip other log messages
+		if !ok {
+			continue
+		}
+		if r.speed > 0 && !lastTimestamp.IsZero() {
+			delay := time.Duration(float64(evt.Timestamp.Sub(lastTimestamp)) / r.speed)
+			if delay > 0 {
+				select {
+				case <-time.After(delay):
+				case <-r.stopChan:
+					return
+				}
+			}
+		}
+		lastTimestamp = evt.Timestamp
+		select {
+		case r.eventChan <- evt:
+		case <-r.stopChan:
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		r.sendError(fmt.Errorf("failed to read replay file: %w", err))
+		return
+	}
+	if r.logger != nil {
+		r.logger.Infof("finished replaying events from %s", r.path)
+	}
+}
+
+func (r *Replay) sendError(err error) {
+	select {
+	case r.errorChan <- err:
+	case <-r.stopChan:
+	}
+}
+
+// Stop the replay input
+func (r *Replay) Stop() error {
+	r.stopOnce.Do(func() {
+		close(r.stopChan)
+		if r.file != nil {
+			<-r.doneChan
+		}
+		close(r.errorChan)
+	})
+	return nil
+}
+
+// ErrorChan returns the input error channel
+func (r *Replay) ErrorChan() chan error {
+	return r.errorChan
+}
+
+// InputChan always returns nil
+func (r *Replay) InputChan() chan<- event.Event {
+	return nil
+}
+
+// OutputChan returns the output event channel
+func (r *Replay) OutputChan() <-chan event.Event {
+	return r.eventChan
+}
+
+type rawEvent struct {
+	Type      string          `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Context   json.RawMessage `json:"context"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+// decodeLine decodes an event from a line written by the log output, which wraps each event in a log message, or
+// from a line containing only the event, as written by the file output. The second return value is false for lines
+// that don't contain an event
+func decodeLine(line []byte) (event.Event, bool, error) {
+	var logMsg struct {
+		Event json.RawMessage `json:"event"`
+	}
+	if err := json.Unmarshal(line, &logMsg); err != nil {
+		return event.Event{}, false, err
+	}
+	data := line
+	if len(logMsg.Event) > 0 {
+		data = logMsg.Event
+	}
+	var raw rawEvent
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return event.Event{}, false, err
+	}
+	if raw.Type == "" || len(raw.Payload) == 0 {
+		return event.Event{}, false, nil
+	}
+	evt, err := decodeEvent(raw)
+	if err != nil {
+		return event.Event{}, false, err
+	}
+	return evt, true, nil
+}
@@ -0,0 +1,241 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	filter_chainsync "github.com/blinklabs-io/adder/filter/chainsync"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/input/replay"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+)
+
+// Lines as written by the log output, with an unrelated log message and an event as written by the file output
+var testLines = []string{
+	`{"level":"info","timestamp":"2024-01-01T00:00:00Z","msg":"","type":"event","event":{"type":"chainsync.block","timestamp":"2024-01-01T00:00:00Z","context":{"blockNumber":12,"slotNumber":3456,"networkMagic":2,"era":"Conway"},"payload":{"blockHash":"abcd","blockCbor":"a0ff","transactionCount":1}}}`,
+	`{"level":"info","timestamp":"2024-01-01T00:00:00Z","msg":"chain has stabilized"}`,
+	`{"level":"info","timestamp":"2024-01-01T00:00:00Z","msg":"","type":"event","event":{"type":"chainsync.transaction","timestamp":"2024-01-01T00:00:00.1Z","context":{"blockNumber":12,"transactionHash":"deadbeef"},"payload":{"blockHash":"abcd","fee":170000}}}`,
+	`{"type":"chainsync.rollback","timestamp":"2024-01-01T00:00:00.2Z","payload":{"blockHash":"ef01","slotNumber":3400}}`,
+}
+
+func newTestFile(t *testing.T, lines []string) string {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error writing test file: %s", err)
+	}
+	return path
+}
+
+// receiveAll returns the events emitted until the output channel is closed
+func receiveAll(t *testing.T, r *replay.Replay) []event.Event {
+	ret := []event.Event{}
+	for {
+		select {
+		case evt, ok := <-r.OutputChan():
+			if !ok {
+				return ret
+			}
+			ret = append(ret, evt)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for events")
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	r := replay.New(
+		replay.WithPath(newTestFile(t, testLines)),
+		replay.WithSpeed(0),
+	)
+	assert.NoError(t, r.Start())
+	events := receiveAll(t, r)
+	assert.NoError(t, r.Stop())
+
+	if !assert.Len(t, events, 3) {
+		return
+	}
+	assert.Equal(t, "chainsync.block", events[0].Type)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), events[0].Timestamp)
+	assert.Equal(
+		t,
+		chainsync.BlockContext{BlockNumber: 12, SlotNumber: 3456, NetworkMagic: 2, Era: "Conway"},
+		events[0].Context,
+	)
+	blockEvt := events[0].Payload.(chainsync.BlockEvent)
+	assert.Equal(t, "abcd", blockEvt.BlockHash)
+	assert.Equal(t, []byte{0xa0, 0xff}, []byte(blockEvt.BlockCbor))
+	assert.Equal(t, "deadbeef", events[1].Context.(chainsync.TransactionContext).TransactionHash)
+	txEvt := events[1].Payload.(chainsync.TransactionEvent)
+	assert.Equal(t, "abcd", txEvt.BlockHash)
+	assert.Equal(t, uint64(170000), txEvt.Fee)
+	assert.Nil(t, txEvt.Transaction)
+	assert.Nil(t, events[2].Context)
+	assert.Equal(t, chainsync.RollbackEvent{BlockHash: "ef01", SlotNumber: 3400}, events[2].Payload)
+}
+
+func TestReplaySpeed(t *testing.T) {
+	// The events are 200ms apart, which takes 100ms at double speed
+	r := replay.New(
+		replay.WithPath(newTestFile(t, testLines)),
+		replay.WithSpeed(2),
+	)
+	start := time.Now()
+	assert.NoError(t, r.Start())
+	assert.Len(t, receiveAll(t, r), 3)
+	elapsed := time.Since(start)
+	assert.NoError(t, r.Stop())
+	assert.GreaterOrEqual(t, elapsed, 100*time.Millisecond)
+	assert.Less(t, elapsed, 190*time.Millisecond)
+}
+
+func TestReplayInvalidLine(t *testing.T) {
+	r := replay.New(
+		replay.WithPath(newTestFile(t, []string{testLines[0], "not json"})),
+		replay.WithSpeed(0),
+	)
+	assert.NoError(t, r.Start())
+	select {
+	case err := <-r.ErrorChan():
+		assert.ErrorContains(t, err, "line 2")
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for error")
+	}
+	assert.Len(t, receiveAll(t, r), 1)
+	assert.NoError(t, r.Stop())
+}
+
+func newAddress(t *testing.T, hashByte byte) ledger.Address {
+	hash := []byte(strings.Repeat(string([]byte{hashByte}), ledger.AddressHashSize))
+	addr, err := ledger.NewAddressFromParts(
+		ledger.AddressTypeKeyKey,
+		ledger.AddressNetworkMainnet,
+		hash,
+		hash,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error creating address: %s", err)
+	}
+	return addr
+}
+
+func TestReplayTransactionFilter(t *testing.T) {
+	addrA := newAddress(t, 0x01)
+	addrB := newAddress(t, 0x02)
+	policyId := strings.Repeat("ab", ledger.AddressHashSize)
+	outputA := fmt.Sprintf(
+		`{"address":"%s","amount":1000000,"assets":[{"name":"x","nameHex":"78","policyId":"%s","fingerprint":"","amount":5}]}`,
+		addrA.String(),
+		policyId,
+	)
+	lines := []string{
+		fmt.Sprintf(
+			`{"type":"chainsync.transaction","timestamp":"2024-01-01T00:00:00Z","context":{"transactionHash":"aa"},"payload":{"blockHash":"abcd","inputs":["%s#1"],"outputs":[%s],"fee":170000}}`,
+			strings.Repeat("cd", 32),
+			outputA,
+		),
+		fmt.Sprintf(
+			`{"type":"chainsync.transaction","timestamp":"2024-01-01T00:00:00Z","context":{"transactionHash":"bb"},"payload":{"blockHash":"abcd","outputs":[{"address":"%s","amount":2000000}],"fee":180000}}`,
+			addrB.String(),
+		),
+	}
+	r := replay.New(
+		replay.WithPath(newTestFile(t, lines)),
+		replay.WithSpeed(0),
+	)
+	events := receiveAllAfterStart(t, r)
+	if !assert.Len(t, events, 2) {
+		return
+	}
+	// Outputs are written back out as they were read
+	txEvt := events[0].Payload.(chainsync.TransactionEvent)
+	data, err := json.Marshal(txEvt.Outputs)
+	assert.NoError(t, err)
+	assert.JSONEq(t, "["+outputA+"]", string(data))
+	if assert.Len(t, txEvt.Inputs, 1) {
+		assert.Equal(t, strings.Repeat("cd", 32), txEvt.Inputs[0].Id().String())
+		assert.Equal(t, uint32(1), txEvt.Inputs[0].Index())
+	}
+
+	for _, filterOption := range []filter_chainsync.ChainSyncOptionFunc{
+		filter_chainsync.WithAddresses([]string{addrA.String()}),
+		filter_chainsync.WithPolicies([]string{policyId}),
+	} {
+		f := filter_chainsync.New(filterOption)
+		assert.NoError(t, f.Start())
+		for _, evt := range events {
+			f.InputChan() <- evt
+		}
+		// Only the transaction paying to the first address, which carries the asset, passes the filter
+		select {
+		case evt := <-f.OutputChan():
+			assert.Equal(t, "aa", evt.Context.(chainsync.TransactionContext).TransactionHash)
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for event")
+		}
+		select {
+		case evt := <-f.OutputChan():
+			t.Fatalf("unexpected event for transaction %s", evt.Context.(chainsync.TransactionContext).TransactionHash)
+		case <-time.After(50 * time.Millisecond):
+		}
+		assert.NoError(t, f.Stop())
+	}
+}
+
+func TestReplayTransactionCbor(t *testing.T) {
+	tx := ledger.ShelleyTransaction{
+		Body: ledger.ShelleyTransactionBody{TxFee: 170000},
+	}
+	txCbor, err := cbor.Encode(&tx)
+	if err != nil {
+		t.Fatalf("unexpected error encoding transaction: %s", err)
+	}
+	line := fmt.Sprintf(
+		`{"type":"chainsync.transaction","timestamp":"2024-01-01T00:00:00Z","context":{"era":"Shelley"},"payload":{"blockHash":"abcd","transactionCbor":"%s","fee":170000}}`,
+		hex.EncodeToString(txCbor),
+	)
+	r := replay.New(
+		replay.WithPath(newTestFile(t, []string{line})),
+		replay.WithSpeed(0),
+	)
+	events := receiveAllAfterStart(t, r)
+	if !assert.Len(t, events, 1) {
+		return
+	}
+	// The ledger transaction is rebuilt from the CBOR
+	txEvt := events[0].Payload.(chainsync.TransactionEvent)
+	if assert.NotNil(t, txEvt.Transaction) {
+		assert.Equal(t, uint64(170000), txEvt.Transaction.Fee())
+	}
+	assert.Equal(t, txCbor, []byte(txEvt.TransactionCbor))
+}
+
+// receiveAllAfterStart starts the replay input and returns all of its events
+func receiveAllAfterStart(t *testing.T, r *replay.Replay) []event.Event {
+	assert.NoError(t, r.Start())
+	events := receiveAll(t, r)
+	assert.NoError(t, r.Stop())
+	return events
+}
@@ -0,0 +1,265 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	utxorpc "github.com/utxorpc/go-codegen/utxorpc/v1alpha/cardano"
+)
+
+// transactionJson is the JSON representation of a transaction event, limited to the fields that can be rebuilt
+type transactionJson struct {
+	BlockHash         string                    `json:"blockHash"`
+	TransactionCbor   string                    `json:"transactionCbor"`
+	Inputs            []string                  `json:"inputs"`
+	Outputs           []replayTransactionOutput `json:"outputs"`
+	ResolvedInputs    []replayTransactionOutput `json:"resolvedInputs"`
+	ReferenceInputs   []string                  `json:"referenceInputs"`
+	MetadataTruncated bool                      `json:"metadataTruncated"`
+	Fee               uint64                    `json:"fee"`
+	TTL               uint64                    `json:"ttl"`
+	Extra             map[string]any            `json:"extra"`
+}
+
+// decodeTransaction rebuilds a transaction event from its JSON, so that filters can inspect its outputs. The
+// certificates and metadata are only available when the event includes the transaction CBOR, in which case
+// they're decoded from it by rebuildTransaction
+func decodeTransaction(data json.RawMessage) (any, error) {
+	var tmpTx transactionJson
+	if err := json.Unmarshal(data, &tmpTx); err != nil {
+		return nil, err
+	}
+	txCbor, err := hex.DecodeString(tmpTx.TransactionCbor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction CBOR: %w", err)
+	}
+	ret := chainsync.TransactionEvent{
+		BlockHash:         tmpTx.BlockHash,
+		MetadataTruncated: tmpTx.MetadataTruncated,
+		Fee:               tmpTx.Fee,
+		TTL:               tmpTx.TTL,
+		Extra:             tmpTx.Extra,
+	}
+	if len(txCbor) > 0 {
+		ret.TransactionCbor = txCbor
+	}
+	if ret.Inputs, err = decodeInputs(tmpTx.Inputs); err != nil {
+		return nil, err
+	}
+	if tmpTx.ReferenceInputs != nil {
+		if ret.ReferenceInputs, err = decodeInputs(tmpTx.ReferenceInputs); err != nil {
+			return nil, err
+		}
+	}
+	ret.Outputs = outputList(tmpTx.Outputs)
+	if tmpTx.ResolvedInputs != nil {
+		ret.ResolvedInputs = outputList(tmpTx.ResolvedInputs)
+	}
+	return ret, nil
+}
+
+// rebuildTransaction decodes the ledger transaction from the transaction CBOR, if present, using the era from the
+// event context. This makes the original transaction available to filters, such as for withdrawals and certificates
+func rebuildTransaction(te *chainsync.TransactionEvent, era string) error {
+	if len(te.TransactionCbor) == 0 {
+		return nil
+	}
+	txType, ok := txTypeByEraName(era)
+	if !ok {
+		return fmt.Errorf("unknown era for transaction CBOR: %s", era)
+	}
+	tx, err := ledger.NewTransactionFromCbor(uint(txType), te.TransactionCbor)
+	if err != nil {
+		return fmt.Errorf("failed to decode transaction CBOR: %w", err)
+	}
+	te.Transaction = tx
+	if tx.Certificates() != nil {
+		te.Certificates = tx.Certificates()
+	}
+	if tx.Metadata() != nil && !te.MetadataTruncated {
+		te.Metadata = tx.Metadata()
+	}
+	return nil
+}
+
+// txTypeByEraName returns the transaction type for the specified era name. The transaction types match the era IDs
+func txTypeByEraName(name string) (uint8, bool) {
+	for eraId := uint8(ledger.EraIdByron); eraId <= ledger.EraIdConway; eraId++ {
+		if ledger.GetEraById(eraId).Name == name {
+			return eraId, true
+		}
+	}
+	return 0, false
+}
+
+// decodeInputs parses transaction inputs in '<transaction hash>#<index>' format
+func decodeInputs(inputs []string) ([]ledger.TransactionInput, error) {
+	ret := make([]ledger.TransactionInput, 0, len(inputs))
+	for _, input := range inputs {
+		txId, idx, ok := strings.Cut(input, "#")
+		if !ok {
+			return nil, fmt.Errorf("invalid transaction input: %s", input)
+		}
+		txIdBytes, err := hex.DecodeString(txId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction input: %s", input)
+		}
+		outputIdx, err := strconv.ParseUint(idx, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction input: %s", input)
+		}
+		ret = append(
+			ret,
+			ledger.ShelleyTransactionInput{
+				TxId:        ledger.NewBlake2b256(txIdBytes),
+				OutputIndex: uint32(outputIdx),
+			},
+		)
+	}
+	return ret, nil
+}
+
+func outputList(outputs []replayTransactionOutput) []ledger.TransactionOutput {
+	ret := make([]ledger.TransactionOutput, 0, len(outputs))
+	for _, output := range outputs {
+		ret = append(ret, output)
+	}
+	return ret
+}
+
+// replayTransactionOutput is a transaction output rebuilt from its JSON. The address, amount, assets and datum hash
+// are available, but inline datums and reference scripts aren't, since the output CBOR isn't part of the JSON. The
+// original JSON is kept so that the output is written back out unchanged
+type replayTransactionOutput struct {
+	raw       json.RawMessage
+	address   ledger.Address
+	amount    uint64
+	assets    *ledger.MultiAsset[ledger.MultiAssetTypeOutput]
+	datumHash *ledger.Blake2b256
+}
+
+type assetJson struct {
+	PolicyId string `json:"policyId"`
+	NameHex  string `json:"nameHex"`
+	Amount   uint64 `json:"amount"`
+}
+
+func (o *replayTransactionOutput) UnmarshalJSON(data []byte) error {
+	var tmpOutput struct {
+		Address   string      `json:"address"`
+		Amount    uint64      `json:"amount"`
+		Assets    []assetJson `json:"assets"`
+		DatumHash string      `json:"datumHash"`
+	}
+	if err := json.Unmarshal(data, &tmpOutput); err != nil {
+		return err
+	}
+	o.raw = append(json.RawMessage{}, data...)
+	o.amount = tmpOutput.Amount
+	if tmpOutput.Address != "" {
+		addr, err := ledger.NewAddress(tmpOutput.Address)
+		if err != nil {
+			return fmt.Errorf("invalid output address: %w", err)
+		}
+		o.address = addr
+	}
+	if len(tmpOutput.Assets) > 0 {
+		assets, err := decodeAssets(tmpOutput.Assets)
+		if err != nil {
+			return err
+		}
+		o.assets = assets
+	}
+	if tmpOutput.DatumHash != "" {
+		datumHashBytes, err := hex.DecodeString(tmpOutput.DatumHash)
+		if err != nil {
+			return fmt.Errorf("invalid output datum hash: %w", err)
+		}
+		datumHash := ledger.NewBlake2b256(datumHashBytes)
+		o.datumHash = &datumHash
+	}
+	return nil
+}
+
+// decodeAssets rebuilds the assets of an output. The ledger type can only be built from CBOR, so the assets are
+// encoded in the same format as a transaction output first
+func decodeAssets(assets []assetJson) (*ledger.MultiAsset[ledger.MultiAssetTypeOutput], error) {
+	tmpAssets := map[ledger.Blake2b224]map[cbor.ByteString]uint64{}
+	for _, asset := range assets {
+		policyIdBytes, err := hex.DecodeString(asset.PolicyId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset policy ID: %w", err)
+		}
+		nameBytes, err := hex.DecodeString(asset.NameHex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid asset name: %w", err)
+		}
+		policyId := ledger.NewBlake2b224(policyIdBytes)
+		if tmpAssets[policyId] == nil {
+			tmpAssets[policyId] = map[cbor.ByteString]uint64{}
+		}
+		tmpAssets[policyId][cbor.NewByteString(nameBytes)] = asset.Amount
+	}
+	data, err := cbor.Encode(tmpAssets)
+	if err != nil {
+		return nil, err
+	}
+	ret := &ledger.MultiAsset[ledger.MultiAssetTypeOutput]{}
+	if err := ret.UnmarshalCBOR(data); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (o replayTransactionOutput) MarshalJSON() ([]byte, error) {
+	return o.raw, nil
+}
+
+func (o replayTransactionOutput) Address() ledger.Address {
+	return o.address
+}
+
+func (o replayTransactionOutput) Amount() uint64 {
+	return o.amount
+}
+
+func (o replayTransactionOutput) Assets() *ledger.MultiAsset[ledger.MultiAssetTypeOutput] {
+	return o.assets
+}
+
+func (o replayTransactionOutput) Datum() *cbor.LazyValue {
+	return nil
+}
+
+func (o replayTransactionOutput) DatumHash() *ledger.Blake2b256 {
+	return o.datumHash
+}
+
+func (o replayTransactionOutput) Cbor() []byte {
+	return nil
+}
+
+func (o replayTransactionOutput) Utxorpc() *utxorpc.TxOutput {
+	return nil
+}
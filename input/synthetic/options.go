@@ -0,0 +1,58 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synthetic
+
+import (
+	"time"
+
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+type SyntheticOptionFunc func(*Synthetic)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) SyntheticOptionFunc {
+	return func(s *Synthetic) {
+		s.logger = logger
+	}
+}
+
+// WithRate specifies the number of blocks to generate per second. The default is 1
+func WithRate(rate uint) SyntheticOptionFunc {
+	return func(s *Synthetic) {
+		s.rate = rate
+	}
+}
+
+// WithTxPerBlock specifies the number of transactions to generate for each block. The default is 10
+func WithTxPerBlock(txPerBlock uint) SyntheticOptionFunc {
+	return func(s *Synthetic) {
+		s.txPerBlock = txPerBlock
+	}
+}
+
+// WithTxSize specifies the size in bytes of the fake CBOR included in each transaction event. The default is 300
+func WithTxSize(txSize uint) SyntheticOptionFunc {
+	return func(s *Synthetic) {
+		s.txSize = txSize
+	}
+}
+
+// WithDuration specifies how long to generate events for. A value of 0 generates events until stopped
+func WithDuration(duration time.Duration) SyntheticOptionFunc {
+	return func(s *Synthetic) {
+		s.duration = duration
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synthetic
+
+import (
+	"time"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	rate       uint
+	txPerBlock uint
+	txSize     uint
+	duration   uint
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeInput,
+			Name:               "synthetic",
+			Description:        "generates fake block and transaction events for load testing, without a node",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "rate",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "number of blocks to generate per second",
+					DefaultValue: uint(1),
+					Dest:         &(cmdlineOptions.rate),
+				},
+				{
+					Name:         "tx-per-block",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "number of transactions to generate for each block",
+					DefaultValue: uint(10),
+					Dest:         &(cmdlineOptions.txPerBlock),
+				},
+				{
+					Name:         "tx-size",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "size in bytes of the fake CBOR included in each transaction event",
+					DefaultValue: uint(300),
+					Dest:         &(cmdlineOptions.txSize),
+				},
+				{
+					Name:         "duration",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "how long in seconds to generate events for (0 for no limit)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.duration),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "input.synthetic"),
+		),
+		WithRate(cmdlineOptions.rate),
+		WithTxPerBlock(cmdlineOptions.txPerBlock),
+		WithTxSize(cmdlineOptions.txSize),
+		WithDuration(time.Duration(cmdlineOptions.duration)*time.Second),
+	)
+	return p
+}
@@ -0,0 +1,182 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synthetic
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+const (
+	// Slots between generated blocks, matching the average block interval on mainnet
+	slotsPerBlock = 20
+)
+
+// Synthetic generates fake block and transaction events at a fixed rate without a node, for load testing outputs
+// and filters
+type Synthetic struct {
+	errorChan  chan error
+	eventChan  chan event.Event
+	stopChan   chan struct{}
+	doneChan   chan struct{}
+	logger     plugin.Logger
+	rate       uint
+	txPerBlock uint
+	txSize     uint
+	duration   time.Duration
+}
+
+// New returns a new Synthetic object with the specified options applied
+func New(options ...SyntheticOptionFunc) *Synthetic {
+	s := &Synthetic{
+		errorChan:  make(chan error),
+		eventChan:  make(chan event.Event, 10),
+		stopChan:   make(chan struct{}),
+		doneChan:   make(chan struct{}),
+		rate:       1,
+		txPerBlock: 10,
+		txSize:     300,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// Start the synthetic input
+func (s *Synthetic) Start() error {
+	if s.logger != nil {
+		s.logger.Infof(
+			"generating %d block(s) per second with %d transaction(s) each",
+			s.rate,
+			s.txPerBlock,
+		)
+	}
+	go s.generateLoop()
+	return nil
+}
+
+func (s *Synthetic) generateLoop() {
+	defer close(s.doneChan)
+	ticker := time.NewTicker(time.Second / time.Duration(max(s.rate, 1)))
+	defer ticker.Stop()
+	var deadline <-chan time.Time
+	if s.duration > 0 {
+		deadline = time.After(s.duration)
+	}
+	var blockNumber uint64
+	for {
+		select {
+		case <-s.stopChan:
+			return
+		case <-deadline:
+			if s.logger != nil {
+				s.logger.Infof("finished generating events after %s", s.duration)
+			}
+			return
+		case <-ticker.C:
+			blockNumber++
+			for _, evt := range s.blockEvents(blockNumber) {
+				select {
+				case s.eventChan <- evt:
+				case <-s.stopChan:
+					return
+				}
+			}
+		}
+	}
+}
+
+// blockEvents returns the block event for a generated block followed by the events for its transactions
+func (s *Synthetic) blockEvents(blockNumber uint64) []event.Event {
+	now := time.Now()
+	slotNumber := blockNumber * slotsPerBlock
+	blockHash := randomHash()
+	ret := make([]event.Event, 0, s.txPerBlock+1)
+	ret = append(
+		ret,
+		event.New(
+			"chainsync.block",
+			now,
+			chainsync.BlockContext{
+				BlockNumber: blockNumber,
+				SlotNumber:  slotNumber,
+				Era:         "Conway",
+			},
+			chainsync.BlockEvent{
+				BlockBodySize:    uint64(s.txPerBlock * s.txSize),
+				BlockHash:        blockHash,
+				TransactionCount: uint64(s.txPerBlock),
+			},
+		),
+	)
+	for idx := uint(0); idx < s.txPerBlock; idx++ {
+		ret = append(
+			ret,
+			event.New(
+				"chainsync.transaction",
+				now,
+				chainsync.TransactionContext{
+					BlockNumber:     blockNumber,
+					SlotNumber:      slotNumber,
+					TransactionHash: randomHash(),
+					TransactionIdx:  uint32(idx),
+					Era:             "Conway",
+				},
+				chainsync.TransactionEvent{
+					BlockHash:       blockHash,
+					TransactionCbor: make([]byte, s.txSize),
+				},
+			),
+		)
+	}
+	return ret
+}
+
+// randomHash returns a random hex-encoded 32 byte hash
+func randomHash() string {
+	hash := make([]byte, 32)
+	_, _ = rand.Read(hash)
+	return hex.EncodeToString(hash)
+}
+
+// Stop the synthetic input
+func (s *Synthetic) Stop() error {
+	close(s.stopChan)
+	<-s.doneChan
+	close(s.eventChan)
+	close(s.errorChan)
+	return nil
+}
+
+// ErrorChan returns the input error channel
+func (s *Synthetic) ErrorChan() chan error {
+	return s.errorChan
+}
+
+// InputChan always returns nil
+func (s *Synthetic) InputChan() chan<- event.Event {
+	return nil
+}
+
+// OutputChan returns the output event channel
+func (s *Synthetic) OutputChan() <-chan event.Event {
+	return s.eventChan
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package synthetic_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/input/synthetic"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyntheticRateAndDuration(t *testing.T) {
+	s := synthetic.New(
+		synthetic.WithRate(50),
+		synthetic.WithTxPerBlock(2),
+		synthetic.WithTxSize(100),
+		synthetic.WithDuration(500*time.Millisecond),
+	)
+	start := time.Now()
+	assert.NoError(t, s.Start())
+
+	var blocks, txs int
+	var lastEvent time.Time
+	timeout := time.After(time.Second)
+loop:
+	for {
+		select {
+		case evt := <-s.OutputChan():
+			lastEvent = time.Now()
+			switch payload := evt.Payload.(type) {
+			case chainsync.BlockEvent:
+				blocks++
+				assert.Equal(t, uint64(2), payload.TransactionCount)
+			case chainsync.TransactionEvent:
+				txs++
+				assert.Len(t, payload.TransactionCbor, 100)
+			default:
+				t.Fatalf("unexpected payload type %T", payload)
+			}
+		case <-timeout:
+			break loop
+		}
+	}
+	assert.NoError(t, s.Stop())
+
+	// 50 blocks/s for 500ms is 25 blocks, with some slack for scheduling
+	assert.InDelta(t, 25, blocks, 5)
+	assert.Equal(t, blocks*2, txs)
+	// Nothing should be generated once the duration has elapsed
+	assert.Less(t, lastEvent.Sub(start), 700*time.Millisecond)
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txsubmit
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/blinklabs-io/adder/api"
+)
+
+var routesRegistered = false
+
+func (t *TxSubmit) RegisterRoutes() {
+	if routesRegistered {
+		return
+	}
+
+	apiInstance := api.GetInstance()
+	apiInstance.AddRoute("POST", "/txsubmit", t.handleSubmit)
+
+	routesRegistered = true
+}
+
+// @Summary		Submit transaction
+// @Description	Submit a raw CBOR transaction to the local node
+// @Accept			application/cbor
+// @Produce		json
+// @Success		202	{object}	map[string]string	"Transaction hash"
+// @Failure		400	{object}	map[string]string	"Invalid or rejected transaction"
+// @Failure		500	{object}	map[string]string	"Submission failed"
+// @Router			/txsubmit [post]
+func (t *TxSubmit) handleSubmit(ctx *gin.Context) {
+	txCbor, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "could not read request body"})
+		return
+	}
+	txHash, err := t.submitTx(txCbor)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if txHash == "" || isRejection(err) {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, gin.H{"txHash": txHash, "error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusAccepted, gin.H{"txHash": txHash})
+}
@@ -0,0 +1,29 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txsubmit
+
+import "github.com/gin-gonic/gin"
+
+// WithSubmitFunc specifies the function used to submit transactions in place of a connection to a node, for tests
+func WithSubmitFunc(submitFunc func(eraId uint16, tx []byte) error) TxSubmitOptionFunc {
+	return func(t *TxSubmit) {
+		t.submitFunc = submitFunc
+	}
+}
+
+// HandleSubmit exposes handleSubmit for tests
+func (t *TxSubmit) HandleSubmit(ctx *gin.Context) {
+	t.handleSubmit(ctx)
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txsubmit
+
+import "github.com/blinklabs-io/adder/plugin"
+
+type TxSubmitOptionFunc func(*TxSubmit)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) TxSubmitOptionFunc {
+	return func(t *TxSubmit) {
+		t.logger = logger
+	}
+}
+
+// WithSocketPath specifies the socket path of the local node to submit transactions to
+func WithSocketPath(socketPath string) TxSubmitOptionFunc {
+	return func(t *TxSubmit) {
+		t.socketPath = socketPath
+	}
+}
+
+// WithNetwork specifies the well-known network name of the local node
+func WithNetwork(network string) TxSubmitOptionFunc {
+	return func(t *TxSubmit) {
+		t.network = network
+	}
+}
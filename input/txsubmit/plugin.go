@@ -0,0 +1,64 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txsubmit
+
+import (
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	socketPath string
+	network    string
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeInput,
+			Name:               "txsubmit",
+			Description:        "submits transactions posted to the API to a local node and emits the results",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "socket-path",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the path to the UNIX socket of the local node",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.socketPath),
+				},
+				{
+					Name:         "network",
+					Type:         plugin.PluginOptionTypeString,
+					CustomEnvVar: "CARDANO_NETWORK",
+					Description:  "specifies a well-known Cardano network name",
+					DefaultValue: "mainnet",
+					Dest:         &(cmdlineOptions.network),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "input.txsubmit"),
+		),
+		WithSocketPath(cmdlineOptions.socketPath),
+		WithNetwork(cmdlineOptions.network),
+	)
+	return p
+}
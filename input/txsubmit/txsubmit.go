@@ -0,0 +1,196 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txsubmit
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/plugin"
+	ouroboros "github.com/blinklabs-io/gouroboros"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/blinklabs-io/gouroboros/protocol/localtxsubmission"
+)
+
+// TxSubmit submits transactions posted to the API to a local node using the LocalTxSubmission protocol, and
+// emits an event with the result of each submission
+type TxSubmit struct {
+	errorChan    chan error
+	eventChan    chan event.Event
+	logger       plugin.Logger
+	socketPath   string
+	network      string
+	networkMagic uint32
+	oConn        *ouroboros.Connection
+	// submitFunc submits the transaction CBOR for the specified era to the node
+	submitFunc func(eraId uint16, tx []byte) error
+	// stopMutex guards stopped and is held for reading by in-flight submissions, so that Stop can wait for them
+	// before closing the event channel
+	stopMutex sync.RWMutex
+	stopped   bool
+	doneChan  chan struct{}
+}
+
+type SubmitResultContext struct {
+	NetworkMagic uint32 `json:"networkMagic"`
+}
+
+type SubmitResultEvent struct {
+	TransactionHash string `json:"transactionHash"`
+	Accepted        bool   `json:"accepted"`
+	// RejectReason is the reason given by the node for rejecting the transaction
+	RejectReason string `json:"rejectReason,omitempty"`
+}
+
+// New returns a new TxSubmit object with the specified options applied
+func New(options ...TxSubmitOptionFunc) *TxSubmit {
+	t := &TxSubmit{
+		errorChan: make(chan error),
+		eventChan: make(chan event.Event, 10),
+		doneChan:  make(chan struct{}),
+	}
+	for _, option := range options {
+		option(t)
+	}
+	return t
+}
+
+// Start the transaction submission input
+func (t *TxSubmit) Start() error {
+	if t.socketPath == "" {
+		return errors.New("you must specify a UNIX socket path")
+	}
+	// Lookup network by name, if provided
+	if t.network != "" {
+		network := ouroboros.NetworkByName(t.network)
+		if network == ouroboros.NetworkInvalid {
+			return fmt.Errorf("unknown network: %s", t.network)
+		}
+		t.networkMagic = network.NetworkMagic
+	}
+	conn, err := net.Dial("unix", t.socketPath)
+	if err != nil {
+		return err
+	}
+	oConn, err := ouroboros.NewConnection(
+		ouroboros.WithConnection(conn),
+		ouroboros.WithNetworkMagic(t.networkMagic),
+		ouroboros.WithNodeToNode(false),
+		ouroboros.WithLocalTxSubmissionConfig(localtxsubmission.NewConfig()),
+	)
+	if err != nil {
+		return err
+	}
+	t.oConn = oConn
+	t.submitFunc = oConn.LocalTxSubmission().Client.SubmitTx
+	// Start async error handler
+	go func() {
+		err, ok := <-oConn.ErrorChan()
+		if ok {
+			// Pass error through our own error channel
+			t.errorChan <- err
+		}
+	}()
+	if t.logger != nil {
+		t.logger.Infof("connected to node at %s", t.socketPath)
+	}
+	return nil
+}
+
+// Stop the transaction submission input
+func (t *TxSubmit) Stop() error {
+	// Unblock any in-flight submissions waiting to send a result event
+	close(t.doneChan)
+	if t.oConn != nil {
+		if err := t.oConn.Close(); err != nil {
+			return err
+		}
+	}
+	// Wait for in-flight submissions to finish before closing the event channel
+	t.stopMutex.Lock()
+	t.stopped = true
+	t.stopMutex.Unlock()
+	close(t.eventChan)
+	close(t.errorChan)
+	return nil
+}
+
+// ErrorChan returns the input error channel
+func (t *TxSubmit) ErrorChan() chan error {
+	return t.errorChan
+}
+
+// InputChan always returns nil
+func (t *TxSubmit) InputChan() chan<- event.Event {
+	return nil
+}
+
+// OutputChan returns the output event channel
+func (t *TxSubmit) OutputChan() <-chan event.Event {
+	return t.eventChan
+}
+
+// submitTx submits the transaction CBOR to the node and emits a result event. It returns the transaction hash,
+// along with an error if the transaction couldn't be decoded, the node couldn't be reached, or the node rejected
+// the transaction. Invalid transactions aren't submitted, so no event is emitted for them
+func (t *TxSubmit) submitTx(txCbor []byte) (string, error) {
+	txType, err := ledger.DetermineTransactionType(txCbor)
+	if err != nil {
+		return "", fmt.Errorf("could not parse transaction: %w", err)
+	}
+	tx, err := ledger.NewTransactionFromCbor(txType, txCbor)
+	if err != nil {
+		return "", fmt.Errorf("could not parse transaction: %w", err)
+	}
+	t.stopMutex.RLock()
+	defer t.stopMutex.RUnlock()
+	if t.stopped {
+		return tx.Hash(), errors.New("transaction submission is stopped")
+	}
+	if t.submitFunc == nil {
+		return tx.Hash(), errors.New("not connected to node")
+	}
+	submitErr := t.submitFunc(uint16(txType), txCbor)
+	if submitErr != nil && !isRejection(submitErr) {
+		return tx.Hash(), submitErr
+	}
+	evt := SubmitResultEvent{
+		TransactionHash: tx.Hash(),
+		Accepted:        submitErr == nil,
+	}
+	if submitErr != nil {
+		evt.RejectReason = submitErr.Error()
+	}
+	select {
+	case t.eventChan <- event.New(
+		"txsubmit.result",
+		time.Now(),
+		SubmitResultContext{NetworkMagic: t.networkMagic},
+		evt,
+	):
+	case <-t.doneChan:
+	}
+	return tx.Hash(), submitErr
+}
+
+// isRejection returns true if the error is the node rejecting a transaction
+func isRejection(err error) bool {
+	var rejectErr localtxsubmission.TransactionRejectedError
+	return errors.As(err, &rejectErr)
+}
@@ -0,0 +1,146 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package txsubmit_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/blinklabs-io/gouroboros/protocol/localtxsubmission"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/txsubmit"
+)
+
+// Minimal Shelley transaction with an empty body and witness set
+const (
+	testTxCbor = "83a40080018002000300a0f6"
+	testTxHash = "ef49c70eb59fe267f7847a876b1c38b5a139bd959b42f50c209390cae428881a"
+)
+
+func TestSubmit(t *testing.T) {
+	txCbor, _ := hex.DecodeString(testTxCbor)
+	testDefs := []struct {
+		submitErr      error
+		body           []byte
+		expectedStatus int
+		expectedEvent  *txsubmit.SubmitResultEvent
+	}{
+		{
+			body:           txCbor,
+			expectedStatus: http.StatusAccepted,
+			expectedEvent: &txsubmit.SubmitResultEvent{
+				TransactionHash: testTxHash,
+				Accepted:        true,
+			},
+		},
+		{
+			submitErr:      localtxsubmission.TransactionRejectedError{ReasonCbor: []byte{0x01}},
+			body:           txCbor,
+			expectedStatus: http.StatusBadRequest,
+			expectedEvent: &txsubmit.SubmitResultEvent{
+				TransactionHash: testTxHash,
+				RejectReason:    "transaction rejected: CBOR reason hex: 01",
+			},
+		},
+		// Connection errors aren't a result from the node, so no event is emitted
+		{
+			submitErr:      errors.New("connection reset"),
+			body:           txCbor,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		// Invalid transactions aren't submitted
+		{
+			body:           []byte{0x01, 0x02},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, testDef := range testDefs {
+		var submitted []byte
+		ts := txsubmit.New(
+			txsubmit.WithSubmitFunc(func(eraId uint16, tx []byte) error {
+				submitted = tx
+				return testDef.submitErr
+			}),
+		)
+		router := gin.New()
+		router.POST("/txsubmit", ts.HandleSubmit)
+		req, _ := http.NewRequest("POST", "/txsubmit", bytes.NewReader(testDef.body))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, testDef.expectedStatus, w.Code)
+		if testDef.expectedStatus == http.StatusAccepted {
+			var body map[string]string
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, testTxHash, body["txHash"])
+			assert.Equal(t, txCbor, submitted)
+		}
+		if testDef.expectedEvent == nil {
+			assert.Len(t, ts.OutputChan(), 0)
+			continue
+		}
+		var evt event.Event
+		select {
+		case evt = <-ts.OutputChan():
+		default:
+			t.Fatal("expected a result event")
+		}
+		assert.Equal(t, "txsubmit.result", evt.Type)
+		assert.Equal(t, *testDef.expectedEvent, evt.Payload)
+	}
+}
+
+func TestSubmitStop(t *testing.T) {
+	txCbor, _ := hex.DecodeString(testTxCbor)
+	submitting := make(chan struct{})
+	release := make(chan struct{})
+	ts := txsubmit.New(
+		txsubmit.WithSubmitFunc(func(eraId uint16, tx []byte) error {
+			close(submitting)
+			<-release
+			return nil
+		}),
+	)
+	router := gin.New()
+	router.POST("/txsubmit", ts.HandleSubmit)
+	submitDone := make(chan int)
+	go func() {
+		req, _ := http.NewRequest("POST", "/txsubmit", bytes.NewReader(txCbor))
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		submitDone <- w.Code
+	}()
+	<-submitting
+	// Stop must wait for the in-flight submission rather than closing the event channel under it
+	stopDone := make(chan error)
+	go func() {
+		stopDone <- ts.Stop()
+	}()
+	close(release)
+	assert.Equal(t, http.StatusAccepted, <-submitDone)
+	assert.NoError(t, <-stopDone)
+	// Submissions after stopping return an error instead of sending on the closed event channel
+	req, _ := http.NewRequest("POST", "/txsubmit", bytes.NewReader(txCbor))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
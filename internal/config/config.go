@@ -15,9 +15,14 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/blinklabs-io/adder/plugin"
 
@@ -31,19 +36,30 @@ const (
 )
 
 type Config struct {
-	Api        ApiConfig                                         `yaml:"api"`
-	ConfigFile string                                            `yaml:"-"`
-	Version    bool                                              `yaml:"-"`
-	Logging    LoggingConfig                                     `yaml:"logging"`
-	Debug      DebugConfig                                       `yaml:"debug"`
-	Input      string                                            `yaml:"input"   envconfig:"INPUT"`
-	Output     string                                            `yaml:"output"  envconfig:"OUTPUT"`
-	Plugin     map[string]map[string]map[interface{}]interface{} `yaml:"plugins"`
+	Api             ApiConfig                                         `yaml:"api"`
+	ConfigFile      string                                            `yaml:"-"`
+	Version         bool                                              `yaml:"-"`
+	Schema          bool                                              `yaml:"-"`
+	Logging         LoggingConfig                                     `yaml:"logging"`
+	Debug           DebugConfig                                       `yaml:"debug"`
+	Input           string                                            `yaml:"input"   envconfig:"INPUT"`
+	Output          string                                            `yaml:"output"  envconfig:"OUTPUT"`
+	Outputs         []string                                          `yaml:"outputs" envconfig:"OUTPUTS"`
+	Plugin          map[string]map[string]map[interface{}]interface{} `yaml:"plugins"`
+	EventTypePrefix string                                            `yaml:"eventTypePrefix" envconfig:"EVENT_TYPE_PREFIX"`
+	// OutputBufferSize and OutputDropOnFull control how the pipeline handles an output that falls behind
+	OutputBufferSize int  `yaml:"outputBufferSize" envconfig:"OUTPUT_BUFFER_SIZE"`
+	OutputDropOnFull bool `yaml:"outputDropOnFull" envconfig:"OUTPUT_DROP_ON_FULL"`
+	// OutputMaxEventAge is the maximum age, based on block time, of events delivered to outputs
+	OutputMaxEventAge time.Duration `yaml:"outputMaxEventAge" envconfig:"OUTPUT_MAX_EVENT_AGE"`
+	// DeadLetterOutput is an output plugin that receives events the main output failed to deliver
+	DeadLetterOutput string `yaml:"deadLetterOutput" envconfig:"DEAD_LETTER_OUTPUT"`
 }
 
 type ApiConfig struct {
-	ListenAddress string `yaml:"address" envconfig:"API_ADDRESS"`
-	ListenPort    uint   `yaml:"port" envconfig:"API_PORT"`
+	ListenAddress string `yaml:"address"  envconfig:"API_ADDRESS"`
+	ListenPort    uint   `yaml:"port"     envconfig:"API_PORT"`
+	NonFatal      bool   `yaml:"nonFatal" envconfig:"API_NON_FATAL"`
 }
 
 type LoggingConfig struct {
@@ -73,12 +89,18 @@ var globalConfig = &Config{
 }
 
 func (c *Config) Load(configFile string) error {
-	// Load config file as YAML if provided
+	// Load config file as YAML, or JSON based on the file extension, if provided
 	if configFile != "" {
 		buf, err := os.ReadFile(configFile)
 		if err != nil {
 			return fmt.Errorf("error reading config file: %s", err)
 		}
+		if strings.EqualFold(filepath.Ext(configFile), ".json") {
+			buf, err = jsonToYaml(buf)
+			if err != nil {
+				return fmt.Errorf("error parsing config file: %s", err)
+			}
+		}
 		err = yaml.Unmarshal(buf, c)
 		if err != nil {
 			return fmt.Errorf("error parsing config file: %s", err)
@@ -94,10 +116,47 @@ func (c *Config) Load(configFile string) error {
 	return nil
 }
 
+// jsonToYaml converts a JSON document to YAML, so that JSON config files are parsed with the same rules
+// and field names as YAML config files. While YAML is mostly a superset of JSON, some valid JSON (such as
+// escaped forward slashes in strings) is rejected by the YAML parser
+func jsonToYaml(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// Preserve integers rather than decoding all numbers as floats
+	dec.UseNumber()
+	var tmpData any
+	if err := dec.Decode(&tmpData); err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(convertJSONNumbers(tmpData))
+}
+
+func convertJSONNumbers(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			val[k] = convertJSONNumbers(item)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = convertJSONNumbers(item)
+		}
+	case json.Number:
+		if tmpInt, err := val.Int64(); err == nil {
+			return tmpInt
+		}
+		if tmpFloat, err := val.Float64(); err == nil {
+			return tmpFloat
+		}
+		return val.String()
+	}
+	return v
+}
+
 func (c *Config) ParseCmdlineArgs(programName string, args []string) error {
 	fs := flag.NewFlagSet(programName, flag.ExitOnError)
 	fs.StringVar(&c.ConfigFile, "config", "", "path to config file to load")
 	fs.BoolVar(&c.Version, "version", false, "show version and exit")
+	fs.BoolVar(&c.Schema, "schema", false, "print JSON schemas for the event types and exit")
 	fs.StringVar(
 		&c.Input,
 		"input",
@@ -110,6 +169,36 @@ func (c *Config) ParseCmdlineArgs(programName string, args []string) error {
 		DefaultOutputPlugin,
 		"output plugin to use, 'list' to show available",
 	)
+	fs.StringVar(
+		&c.EventTypePrefix,
+		"event-type-prefix",
+		"",
+		"source identifier to prepend to the type of all events",
+	)
+	fs.IntVar(
+		&c.OutputBufferSize,
+		"output-buffer-size",
+		0,
+		"number of events to buffer for each output (0 to disable)",
+	)
+	fs.BoolVar(
+		&c.OutputDropOnFull,
+		"output-drop-on-full",
+		false,
+		"drop events rather than wait when an output isn't ready to accept them",
+	)
+	fs.DurationVar(
+		&c.OutputMaxEventAge,
+		"output-max-event-age",
+		0,
+		"drop events older than this, based on block time, rather than send them to outputs (0 to disable)",
+	)
+	fs.StringVar(
+		&c.DeadLetterOutput,
+		"dead-letter-output",
+		"",
+		"output plugin to send events the main output failed to deliver to",
+	)
 	if err := plugin.PopulateCmdlineOptions(fs); err != nil {
 		return err
 	}
@@ -119,6 +208,14 @@ func (c *Config) ParseCmdlineArgs(programName string, args []string) error {
 	return nil
 }
 
+// OutputNames returns the output plugins to use. Outputs takes precedence over the single Output when it's set
+func (c *Config) OutputNames() []string {
+	if len(c.Outputs) > 0 {
+		return c.Outputs
+	}
+	return []string{c.Output}
+}
+
 // GetConfig returns the global config instance
 func GetConfig() *Config {
 	return globalConfig
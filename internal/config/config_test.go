@@ -0,0 +1,108 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blinklabs-io/adder/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+const testYamlConfig = `
+input: chainsync
+output: webhook
+api:
+  address: 127.0.0.1
+  port: 8081
+logging:
+  level: debug
+plugins:
+  input:
+    chainsync:
+      network: preview
+      intersect-tip: false
+      tx-buffer-size: 500
+  output:
+    webhook:
+      url: https://example.com/webhook
+`
+
+// Uses escaped forward slashes, which the YAML parser doesn't accept
+const testJsonConfig = `{
+	"input": "chainsync",
+	"output": "webhook",
+	"api": {
+		"address": "127.0.0.1",
+		"port": 8081
+	},
+	"logging": {
+		"level": "debug"
+	},
+	"plugins": {
+		"input": {
+			"chainsync": {
+				"network": "preview",
+				"intersect-tip": false,
+				"tx-buffer-size": 500
+			}
+		},
+		"output": {
+			"webhook": {
+				"url": "https:\/\/example.com\/webhook"
+			}
+		}
+	}
+}`
+
+func TestLoadJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	yamlPath := filepath.Join(tmpDir, "config.yaml")
+	jsonPath := filepath.Join(tmpDir, "config.json")
+	assert.NoError(t, os.WriteFile(yamlPath, []byte(testYamlConfig), 0o644))
+	assert.NoError(t, os.WriteFile(jsonPath, []byte(testJsonConfig), 0o644))
+	yamlCfg := &config.Config{}
+	assert.NoError(t, yamlCfg.Load(yamlPath))
+	jsonCfg := &config.Config{}
+	assert.NoError(t, jsonCfg.Load(jsonPath))
+	assert.Equal(t, yamlCfg, jsonCfg)
+	assert.Equal(t, uint(8081), jsonCfg.Api.ListenPort)
+	assert.Equal(
+		t,
+		500,
+		jsonCfg.Plugin["input"]["chainsync"]["tx-buffer-size"],
+	)
+}
+
+func TestLoadInvalidJSON(t *testing.T) {
+	jsonPath := filepath.Join(t.TempDir(), "config.json")
+	assert.NoError(t, os.WriteFile(jsonPath, []byte(`{"input": `), 0o644))
+	cfg := &config.Config{}
+	assert.ErrorContains(t, cfg.Load(jsonPath), "error parsing config file")
+}
+
+func TestOutputNames(t *testing.T) {
+	cfg := &config.Config{Output: "log"}
+	assert.Equal(t, []string{"log"}, cfg.OutputNames())
+	yamlPath := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(
+		t,
+		os.WriteFile(yamlPath, []byte("outputs: [log, webhook]\n"), 0o644),
+	)
+	assert.NoError(t, cfg.Load(yamlPath))
+	assert.Equal(t, []string{"log", "webhook"}, cfg.OutputNames())
+}
@@ -0,0 +1,145 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+const (
+	// Buffered events are written out early once they reach this size
+	flushThreshold = 64 * 1024
+)
+
+type FileOutput struct {
+	errorChan     chan error
+	eventChan     chan event.Event
+	doneChan      chan error
+	logger        plugin.Logger
+	path          string
+	maxSizeMB     int
+	maxBackups    int
+	compress      bool
+	flushInterval time.Duration
+	writer        *lumberjack.Logger
+	buffer        bytes.Buffer
+	jsonOptions   event.JSONOptions
+}
+
+func New(options ...FileOptionFunc) *FileOutput {
+	f := &FileOutput{
+		errorChan:     make(chan error),
+		eventChan:     make(chan event.Event, 10),
+		doneChan:      make(chan error, 1),
+		path:          "events.jsonl",
+		maxSizeMB:     100,
+		flushInterval: 1 * time.Second,
+	}
+	for _, option := range options {
+		option(f)
+	}
+	return f
+}
+
+// Start the file output
+func (f *FileOutput) Start() error {
+	f.writer = &lumberjack.Logger{
+		Filename:   f.path,
+		MaxSize:    f.maxSizeMB,
+		MaxBackups: f.maxBackups,
+		Compress:   f.compress,
+	}
+	go func() {
+		ticker := time.NewTicker(f.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := f.flush(); err != nil {
+					f.errorChan <- err
+				}
+			case evt, ok := <-f.eventChan:
+				// Channel has been closed, which means we're shutting down
+				if !ok {
+					err := f.flush()
+					if closeErr := f.writer.Close(); err == nil {
+						err = closeErr
+					}
+					f.doneChan <- err
+					return
+				}
+				data, err := event.MarshalJSON(evt, f.jsonOptions)
+				if err != nil {
+					if f.logger != nil {
+						f.logger.Errorf("failed to encode event: %s", err)
+					}
+					continue
+				}
+				// Write out buffered events first if this one would take us over the threshold, so that
+				// lines are never split across rotated files
+				if f.buffer.Len()+len(data)+1 > flushThreshold {
+					if err := f.flush(); err != nil {
+						f.errorChan <- err
+					}
+				}
+				f.buffer.Write(data)
+				f.buffer.WriteByte('\n')
+			}
+		}
+	}()
+	return nil
+}
+
+// flush writes any buffered events to the current file
+func (f *FileOutput) flush() error {
+	if f.buffer.Len() == 0 {
+		return nil
+	}
+	defer f.buffer.Reset()
+	if _, err := f.writer.Write(f.buffer.Bytes()); err != nil {
+		return fmt.Errorf("failed to write events to %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// Stop the file output. Any buffered events are written out and the current file closed before returning
+func (f *FileOutput) Stop() error {
+	close(f.eventChan)
+	err := <-f.doneChan
+	close(f.errorChan)
+	return err
+}
+
+// ErrorChan returns the plugin's error channel
+func (f *FileOutput) ErrorChan() chan error {
+	return f.errorChan
+}
+
+// InputChan returns the input event channel
+func (f *FileOutput) InputChan() chan<- event.Event {
+	return f.eventChan
+}
+
+// OutputChan always returns nil
+func (f *FileOutput) OutputChan() <-chan event.Event {
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/output/file"
+	"github.com/stretchr/testify/assert"
+)
+
+func readLines(t *testing.T, path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unexpected error opening file: %s", err)
+	}
+	defer f.Close()
+	lines := []string{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("unexpected error reading file: %s", err)
+	}
+	return lines
+}
+
+func TestFileOutputStopFlushes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	f := file.New(
+		file.WithPath(path),
+		// Long enough that only Stop flushes the events
+		file.WithFlushInterval(time.Hour),
+	)
+	assert.NoError(t, f.Start())
+	for i := 0; i < 3; i++ {
+		f.InputChan() <- event.New(
+			"chainsync.block",
+			time.Unix(0, 0).UTC(),
+			nil,
+			map[string]int{"idx": i},
+		)
+	}
+	assert.NoError(t, f.Stop())
+
+	lines := readLines(t, path)
+	if assert.Len(t, lines, 3) {
+		var evt map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(lines[2]), &evt))
+		assert.Equal(t, "chainsync.block", evt["type"])
+		assert.Equal(t, map[string]any{"idx": float64(2)}, evt["payload"])
+	}
+}
+
+func TestFileOutputRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "events.jsonl")
+	f := file.New(
+		file.WithPath(path),
+		file.WithMaxSizeMB(1),
+		file.WithFlushInterval(10*time.Millisecond),
+	)
+	assert.NoError(t, f.Start())
+	// Write roughly 1.5MB of events to force a rotation
+	payload := strings.Repeat("a", 10*1024)
+	eventCount := 150
+	for i := 0; i < eventCount; i++ {
+		f.InputChan() <- event.New("test", time.Now(), nil, payload)
+	}
+	assert.NoError(t, f.Stop())
+
+	files, err := filepath.Glob(filepath.Join(dir, "events*.jsonl"))
+	if err != nil {
+		t.Fatalf("unexpected error listing files: %s", err)
+	}
+	assert.Len(t, files, 2)
+	// No events are lost or split across files
+	totalLines := 0
+	for _, tmpFile := range files {
+		for _, line := range readLines(t, tmpFile) {
+			assert.True(t, json.Valid([]byte(line)))
+			totalLines++
+		}
+	}
+	assert.Equal(t, eventCount, totalLines)
+}
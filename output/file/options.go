@@ -0,0 +1,72 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"time"
+
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+type FileOptionFunc func(*FileOutput)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) FileOptionFunc {
+	return func(o *FileOutput) {
+		o.logger = logger
+	}
+}
+
+// WithPath specifies the path of the file to write events to. Rotated files are kept alongside it
+func WithPath(path string) FileOptionFunc {
+	return func(o *FileOutput) {
+		o.path = path
+	}
+}
+
+// WithMaxSizeMB specifies the size in megabytes at which the file is rotated
+func WithMaxSizeMB(maxSizeMB int) FileOptionFunc {
+	return func(o *FileOutput) {
+		o.maxSizeMB = maxSizeMB
+	}
+}
+
+// WithMaxBackups specifies the number of rotated files to keep. The default of 0 keeps all of them
+func WithMaxBackups(maxBackups int) FileOptionFunc {
+	return func(o *FileOutput) {
+		o.maxBackups = maxBackups
+	}
+}
+
+// WithCompress specifies whether to gzip rotated files
+func WithCompress(compress bool) FileOptionFunc {
+	return func(o *FileOutput) {
+		o.compress = compress
+	}
+}
+
+// WithFlushInterval specifies how often buffered events are written to the file
+func WithFlushInterval(flushInterval time.Duration) FileOptionFunc {
+	return func(o *FileOutput) {
+		o.flushInterval = flushInterval
+	}
+}
+
+// WithSortKeys specifies whether to render the keys of all JSON objects in sorted order for reproducible output
+func WithSortKeys(sortKeys bool) FileOptionFunc {
+	return func(o *FileOutput) {
+		o.jsonOptions.SortKeys = sortKeys
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package file
+
+import (
+	"time"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	path          string
+	maxSizeMB     uint
+	maxBackups    uint
+	compress      bool
+	flushInterval uint
+	sortKeys      bool
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeOutput,
+			Name:               "file",
+			Description:        "write events as newline-delimited JSON to a file with size-based rotation",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "path",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the path of the file to write events to",
+					DefaultValue: "events.jsonl",
+					Dest:         &(cmdlineOptions.path),
+				},
+				{
+					Name:         "max-size-mb",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the size in megabytes at which the file is rotated",
+					DefaultValue: uint(100),
+					Dest:         &(cmdlineOptions.maxSizeMB),
+				},
+				{
+					Name:         "max-backups",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the number of rotated files to keep (0 to keep all)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.maxBackups),
+				},
+				{
+					Name:         "compress",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "gzip rotated files",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.compress),
+				},
+				{
+					Name:         "flush-interval",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies how often in seconds buffered events are written to the file",
+					DefaultValue: uint(1),
+					Dest:         &(cmdlineOptions.flushInterval),
+				},
+				{
+					Name:         "sort-keys",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "render the keys of all JSON objects in sorted order for reproducible output",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.sortKeys),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "output.file"),
+		),
+		WithPath(cmdlineOptions.path),
+		WithMaxSizeMB(int(cmdlineOptions.maxSizeMB)),
+		WithMaxBackups(int(cmdlineOptions.maxBackups)),
+		WithCompress(cmdlineOptions.compress),
+		WithFlushInterval(
+			time.Duration(cmdlineOptions.flushInterval)*time.Second,
+		),
+		WithSortKeys(cmdlineOptions.sortKeys),
+	)
+	return p
+}
@@ -0,0 +1,234 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/output/grpc/eventpb"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+type GrpcOutput struct {
+	eventpb.UnimplementedEventServiceServer
+	errorChan     chan error
+	eventChan     chan event.Event
+	doneChan      chan struct{}
+	logger        plugin.Logger
+	listenAddress string
+	maxClients    int
+	bufferSize    int
+	server        *grpc.Server
+	clientsMutex  sync.Mutex
+	clients       map[*subscriber]struct{}
+	stopping      bool
+}
+
+// subscriber is a connected client of the SubscribeEvents stream
+type subscriber struct {
+	eventChan chan *eventpb.Event
+	// slowChan is closed when the client falls too far behind and is disconnected
+	slowChan chan struct{}
+}
+
+func New(options ...GrpcOptionFunc) *GrpcOutput {
+	g := &GrpcOutput{
+		errorChan:     make(chan error),
+		eventChan:     make(chan event.Event, 10),
+		doneChan:      make(chan struct{}),
+		listenAddress: ":50051",
+		maxClients:    100,
+		bufferSize:    100,
+		clients:       make(map[*subscriber]struct{}),
+	}
+	for _, option := range options {
+		option(g)
+	}
+	return g
+}
+
+// Start the gRPC output
+func (g *GrpcOutput) Start() error {
+	listener, err := net.Listen("tcp", g.listenAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", g.listenAddress, err)
+	}
+	g.server = grpc.NewServer()
+	eventpb.RegisterEventServiceServer(g.server, g)
+	go func() {
+		if err := g.server.Serve(listener); err != nil {
+			g.errorChan <- fmt.Errorf("gRPC server failed: %w", err)
+		}
+	}()
+	if g.logger != nil {
+		g.logger.Infof("gRPC server listening on %s", listener.Addr().String())
+	}
+	go func() {
+		defer close(g.doneChan)
+		for {
+			evt, ok := <-g.eventChan
+			// Channel has been closed, which means we're shutting down
+			if !ok {
+				g.closeSubscribers()
+				return
+			}
+			msg, err := newEventMessage(evt)
+			if err != nil {
+				if g.logger != nil {
+					g.logger.Errorf("failed to encode event: %s", err)
+				}
+				continue
+			}
+			g.broadcast(msg)
+		}
+	}()
+	return nil
+}
+
+// broadcast queues the event for all connected clients. Clients whose buffer is full are disconnected rather
+// than blocking the pipeline
+func (g *GrpcOutput) broadcast(msg *eventpb.Event) {
+	g.clientsMutex.Lock()
+	defer g.clientsMutex.Unlock()
+	for sub := range g.clients {
+		select {
+		case sub.eventChan <- msg:
+		default:
+			if g.logger != nil {
+				g.logger.Warnf("disconnecting gRPC client that fell behind")
+			}
+			delete(g.clients, sub)
+			close(sub.slowChan)
+		}
+	}
+}
+
+// closeSubscribers closes the event channel of all connected clients, which ends their stream once any buffered
+// events have been sent
+func (g *GrpcOutput) closeSubscribers() {
+	g.clientsMutex.Lock()
+	defer g.clientsMutex.Unlock()
+	g.stopping = true
+	for sub := range g.clients {
+		delete(g.clients, sub)
+		close(sub.eventChan)
+	}
+}
+
+func (g *GrpcOutput) removeSubscriber(sub *subscriber) {
+	g.clientsMutex.Lock()
+	defer g.clientsMutex.Unlock()
+	delete(g.clients, sub)
+}
+
+// SubscribeEvents streams events to the client as they arrive
+func (g *GrpcOutput) SubscribeEvents(
+	req *eventpb.SubscribeEventsRequest,
+	stream eventpb.EventService_SubscribeEventsServer,
+) error {
+	g.clientsMutex.Lock()
+	if g.stopping {
+		g.clientsMutex.Unlock()
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+	if g.maxClients > 0 && len(g.clients) >= g.maxClients {
+		g.clientsMutex.Unlock()
+		return status.Error(codes.ResourceExhausted, "too many clients")
+	}
+	sub := &subscriber{
+		eventChan: make(chan *eventpb.Event, g.bufferSize),
+		slowChan:  make(chan struct{}),
+	}
+	g.clients[sub] = struct{}{}
+	g.clientsMutex.Unlock()
+	// Send headers right away so the client knows it's subscribed before the first event arrives
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		g.removeSubscriber(sub)
+		return err
+	}
+	for {
+		select {
+		case msg, ok := <-sub.eventChan:
+			// Channel has been closed, which means we're shutting down
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(msg); err != nil {
+				g.removeSubscriber(sub)
+				return err
+			}
+		case <-sub.slowChan:
+			return status.Error(codes.ResourceExhausted, "client fell too far behind")
+		case <-stream.Context().Done():
+			g.removeSubscriber(sub)
+			return stream.Context().Err()
+		}
+	}
+}
+
+// Stop the gRPC output. Events already queued for connected clients are sent before their streams are closed
+func (g *GrpcOutput) Stop() error {
+	close(g.eventChan)
+	<-g.doneChan
+	g.server.GracefulStop()
+	close(g.errorChan)
+	return nil
+}
+
+// ErrorChan returns the plugin's error channel
+func (g *GrpcOutput) ErrorChan() chan error {
+	return g.errorChan
+}
+
+// InputChan returns the input event channel
+func (g *GrpcOutput) InputChan() chan<- event.Event {
+	return g.eventChan
+}
+
+// OutputChan always returns nil
+func (g *GrpcOutput) OutputChan() <-chan event.Event {
+	return nil
+}
+
+// newEventMessage returns the protobuf message for the event, with the context and payload encoded as JSON
+func newEventMessage(evt event.Event) (*eventpb.Event, error) {
+	msg := &eventpb.Event{
+		Type:      evt.Type,
+		Timestamp: timestamppb.New(evt.Timestamp),
+	}
+	if evt.Context != nil {
+		data, err := json.Marshal(evt.Context)
+		if err != nil {
+			return nil, err
+		}
+		msg.Context = data
+	}
+	payload, err := json.Marshal(evt.Payload)
+	if err != nil {
+		return nil, err
+	}
+	msg.Payload = payload
+	return msg, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/blinklabs-io/adder/event"
+	output_grpc "github.com/blinklabs-io/adder/output/grpc"
+	"github.com/blinklabs-io/adder/output/grpc/eventpb"
+)
+
+// freeAddress returns a local address with a port that is free to listen on
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func newClient(t *testing.T, address string) eventpb.EventServiceClient {
+	conn, err := grpc.NewClient(
+		address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return eventpb.NewEventServiceClient(conn)
+}
+
+// subscribe opens a stream and waits for the server to register it
+func subscribe(
+	t *testing.T,
+	ctx context.Context,
+	client eventpb.EventServiceClient,
+) eventpb.EventService_SubscribeEventsClient {
+	stream, err := client.SubscribeEvents(
+		ctx,
+		&eventpb.SubscribeEventsRequest{},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	// Headers are sent once the server has registered the stream
+	if _, err := stream.Header(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return stream
+}
+
+func newEvent(idx int) event.Event {
+	return event.New(
+		"chainsync.block",
+		time.Unix(1700000000, 0).UTC(),
+		nil,
+		map[string]int{"idx": idx},
+	)
+}
+
+func TestGrpcOutputStreamAndStop(t *testing.T) {
+	address := freeAddress(t)
+	g := output_grpc.New(output_grpc.WithListenAddress(address))
+	assert.NoError(t, g.Start())
+	stream := subscribe(t, context.Background(), newClient(t, address))
+	for i := 0; i < 3; i++ {
+		g.InputChan() <- newEvent(i)
+	}
+	// Queued events are delivered before the stream is closed
+	assert.NoError(t, g.Stop())
+	for i := 0; i < 3; i++ {
+		msg, err := stream.Recv()
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, "chainsync.block", msg.GetType())
+		assert.Equal(t, int64(1700000000), msg.GetTimestamp().GetSeconds())
+		var payload map[string]int
+		assert.NoError(t, json.Unmarshal(msg.GetPayload(), &payload))
+		assert.Equal(t, map[string]int{"idx": i}, payload)
+	}
+	_, err := stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestGrpcOutputMaxClients(t *testing.T) {
+	address := freeAddress(t)
+	g := output_grpc.New(
+		output_grpc.WithListenAddress(address),
+		output_grpc.WithMaxClients(1),
+	)
+	assert.NoError(t, g.Start())
+	defer func() {
+		_ = g.Stop()
+	}()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := newClient(t, address)
+	subscribe(t, ctx, client)
+	stream, err := client.SubscribeEvents(ctx, &eventpb.SubscribeEventsRequest{})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestGrpcOutputSlowClient(t *testing.T) {
+	address := freeAddress(t)
+	g := output_grpc.New(
+		output_grpc.WithListenAddress(address),
+		output_grpc.WithBufferSize(1),
+	)
+	assert.NoError(t, g.Start())
+	defer func() {
+		_ = g.Stop()
+	}()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := subscribe(t, ctx, newClient(t, address))
+	// Send more than the transport can buffer without the client reading, which must not block the pipeline
+	largePayload := strings.Repeat("x", 64*1024)
+	for i := 0; i < 200; i++ {
+		g.InputChan() <- event.New("chainsync.block", time.Now(), nil, largePayload)
+	}
+	for {
+		_, err := stream.Recv()
+		if err != nil {
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			break
+		}
+	}
+}
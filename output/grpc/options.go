@@ -0,0 +1,50 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+type GrpcOptionFunc func(*GrpcOutput)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) GrpcOptionFunc {
+	return func(o *GrpcOutput) {
+		o.logger = logger
+	}
+}
+
+// WithListenAddress specifies the address for the gRPC server to listen on in the form 'host:port'
+func WithListenAddress(listenAddress string) GrpcOptionFunc {
+	return func(o *GrpcOutput) {
+		o.listenAddress = listenAddress
+	}
+}
+
+// WithMaxClients specifies the maximum number of connected clients. A value of 0 means there is no limit
+func WithMaxClients(maxClients int) GrpcOptionFunc {
+	return func(o *GrpcOutput) {
+		o.maxClients = maxClients
+	}
+}
+
+// WithBufferSize specifies the number of events buffered for each client. Clients that fall further behind
+// than this are disconnected
+func WithBufferSize(bufferSize int) GrpcOptionFunc {
+	return func(o *GrpcOutput) {
+		o.bufferSize = bufferSize
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpc
+
+import (
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	listenAddress string
+	maxClients    uint
+	bufferSize    uint
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeOutput,
+			Name:               "grpc",
+			Description:        "stream events to clients of a gRPC server",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "listen-address",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the address for the gRPC server to listen on in the form 'host:port'",
+					DefaultValue: ":50051",
+					Dest:         &(cmdlineOptions.listenAddress),
+				},
+				{
+					Name:         "max-clients",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the maximum number of connected clients (0 for no limit)",
+					DefaultValue: uint(100),
+					Dest:         &(cmdlineOptions.maxClients),
+				},
+				{
+					Name:         "buffer-size",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the number of events buffered for each client before it is disconnected",
+					DefaultValue: uint(100),
+					Dest:         &(cmdlineOptions.bufferSize),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "output.grpc"),
+		),
+		WithListenAddress(cmdlineOptions.listenAddress),
+		WithMaxClients(int(cmdlineOptions.maxClients)),
+		WithBufferSize(int(cmdlineOptions.bufferSize)),
+	)
+	return p
+}
@@ -0,0 +1,94 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+)
+
+var csvHeader = []string{"type", "timestamp", "slot", "block", "hash", "summary"}
+
+// writeCSV writes the event as a CSV row, preceded by the header row for the first event
+func (l *LogOutput) writeCSV(evt event.Event) error {
+	if !l.csvHeaderWritten {
+		if err := l.csvWriter.Write(csvHeader); err != nil {
+			return err
+		}
+		l.csvHeaderWritten = true
+	}
+	row, err := l.csvRow(evt)
+	if err != nil {
+		return err
+	}
+	if err := l.csvWriter.Write(row); err != nil {
+		return err
+	}
+	l.csvWriter.Flush()
+	return l.csvWriter.Error()
+}
+
+func (l *LogOutput) csvRow(evt event.Event) ([]string, error) {
+	var slot, block, hash, summary string
+	switch payload := evt.Payload.(type) {
+	case chainsync.BlockEvent:
+		if ctx, ok := evt.Context.(chainsync.BlockContext); ok {
+			slot = strconv.FormatUint(ctx.SlotNumber, 10)
+			block = strconv.FormatUint(ctx.BlockNumber, 10)
+		}
+		hash = payload.BlockHash
+		summary = fmt.Sprintf(
+			"transactions=%d fees=%d output=%d",
+			payload.TransactionCount,
+			payload.TotalFees,
+			payload.TotalOutput,
+		)
+	case chainsync.TransactionEvent:
+		if ctx, ok := evt.Context.(chainsync.TransactionContext); ok {
+			slot = strconv.FormatUint(ctx.SlotNumber, 10)
+			block = strconv.FormatUint(ctx.BlockNumber, 10)
+			hash = ctx.TransactionHash
+		}
+		summary = fmt.Sprintf(
+			"inputs=%d outputs=%d fee=%d",
+			len(payload.Inputs),
+			len(payload.Outputs),
+			payload.Fee,
+		)
+	case chainsync.RollbackEvent:
+		slot = strconv.FormatUint(payload.SlotNumber, 10)
+		hash = payload.BlockHash
+	default:
+		// Use the JSON encoded payload for other event types
+		data, err := event.MarshalJSON(evt.Payload, l.jsonOptions)
+		if err != nil {
+			return nil, err
+		}
+		summary = string(data)
+	}
+	row := []string{
+		evt.Type,
+		evt.Timestamp.Format(time.RFC3339Nano),
+		slot,
+		block,
+		hash,
+		summary,
+	}
+	return row, nil
+}
@@ -15,17 +15,34 @@
 package log
 
 import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+
 	"github.com/blinklabs-io/adder/event"
 	"github.com/blinklabs-io/adder/internal/logging"
 	"github.com/blinklabs-io/adder/plugin"
 )
 
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
 type LogOutput struct {
-	errorChan    chan error
-	eventChan    chan event.Event
-	logger       plugin.Logger
-	outputLogger *logging.Logger
-	level        string
+	errorChan        chan error
+	eventChan        chan event.Event
+	logger           plugin.Logger
+	outputLogger     *logging.Logger
+	level            string
+	jsonOptions      event.JSONOptions
+	format           string
+	writer           io.Writer
+	csvWriter        *csv.Writer
+	csvHeaderWritten bool
+	eventTypes       map[string]bool
+	serializeWorkers int
 }
 
 func New(options ...LogOptionFunc) *LogOutput {
@@ -33,6 +50,8 @@ func New(options ...LogOptionFunc) *LogOutput {
 		errorChan: make(chan error),
 		eventChan: make(chan event.Event, 10),
 		level:     "info",
+		format:    FormatJSON,
+		writer:    os.Stdout,
 	}
 	for _, option := range options {
 		option(l)
@@ -50,34 +69,87 @@ func New(options ...LogOptionFunc) *LogOutput {
 		l.outputLogger = logging.GetLogger()
 	}
 	l.outputLogger = l.outputLogger.With("type", "event")
+	l.csvWriter = csv.NewWriter(l.writer)
 	return l
 }
 
 // Start the log output
 func (l *LogOutput) Start() error {
 	go func() {
+		// Encode events across a pool of workers, while still logging them in order
+		if l.serializeWorkers > 1 && l.format != FormatCSV {
+			serialized := event.SerializeOrdered(
+				l.eventChan,
+				l.serializeWorkers,
+				func(evt event.Event) ([]byte, error) {
+					if !l.wantEvent(evt) {
+						return nil, nil
+					}
+					return event.MarshalJSON(evt, l.jsonOptions)
+				},
+			)
+			for result := range serialized {
+				if !l.wantEvent(result.Event) {
+					continue
+				}
+				if result.Err != nil {
+					l.logger.Errorf("failed to encode event: %s", result.Err)
+					continue
+				}
+				l.logEvent(json.RawMessage(result.Data))
+			}
+			return
+		}
 		for {
 			evt, ok := <-l.eventChan
 			// Channel has been closed, which means we're shutting down
 			if !ok {
 				return
 			}
-			switch l.level {
-			case "info":
-				l.outputLogger.Infow("", "event", evt)
-			case "warn":
-				l.outputLogger.Warnw("", "event", evt)
-			case "error":
-				l.outputLogger.Errorw("", "event", evt)
-			default:
-				// Use INFO level if log level isn't recognized
-				l.outputLogger.Infow("", "event", evt)
+			if !l.wantEvent(evt) {
+				continue
+			}
+			if l.format == FormatCSV {
+				if err := l.writeCSV(evt); err != nil {
+					l.logger.Errorf("failed to write event: %s", err)
+				}
+				continue
+			}
+			var logEvt interface{} = evt
+			if l.jsonOptions != (event.JSONOptions{}) {
+				data, err := event.MarshalJSON(evt, l.jsonOptions)
+				if err != nil {
+					l.logger.Errorf("failed to encode event: %s", err)
+					continue
+				}
+				logEvt = json.RawMessage(data)
 			}
+			l.logEvent(logEvt)
 		}
 	}()
 	return nil
 }
 
+// wantEvent returns whether the event matches the configured event types
+func (l *LogOutput) wantEvent(evt event.Event) bool {
+	return len(l.eventTypes) == 0 || l.eventTypes[event.BaseType(evt.Type)]
+}
+
+// logEvent writes the event using the output logger at the configured level
+func (l *LogOutput) logEvent(logEvt interface{}) {
+	switch l.level {
+	case "info":
+		l.outputLogger.Infow("", "event", logEvt)
+	case "warn":
+		l.outputLogger.Warnw("", "event", logEvt)
+	case "error":
+		l.outputLogger.Errorw("", "event", logEvt)
+	default:
+		// Use INFO level if log level isn't recognized
+		l.outputLogger.Infow("", "event", logEvt)
+	}
+}
+
 // Stop the log output
 func (l *LogOutput) Stop() error {
 	close(l.eventChan)
@@ -0,0 +1,161 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package log_test
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/output/log"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// syncBuffer allows reading output while the log output goroutine writes to it
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestCSVFormat(t *testing.T) {
+	var buf syncBuffer
+	l := log.New(
+		log.WithLogger(zap.NewNop().Sugar()),
+		log.WithFormat(log.FormatCSV),
+		log.WithWriter(&buf),
+	)
+	assert.NoError(t, l.Start())
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.InputChan() <- event.New(
+		"chainsync.block",
+		timestamp,
+		chainsync.BlockContext{BlockNumber: 12, SlotNumber: 3456},
+		chainsync.BlockEvent{BlockHash: "abcd", TransactionCount: 2, TotalFees: 300},
+	)
+	l.InputChan() <- event.New(
+		"chainsync.rollback",
+		timestamp,
+		nil,
+		chainsync.RollbackEvent{BlockHash: "ef01", SlotNumber: 3400},
+	)
+	l.InputChan() <- event.New(
+		"chainsync.reset",
+		timestamp,
+		nil,
+		chainsync.ResetEvent{Reason: "intersectNotFound", SlotNumber: 3500},
+	)
+	assert.NoError(t, l.Stop())
+	assert.Eventually(
+		t,
+		func() bool { return strings.Count(buf.String(), "\n") == 4 },
+		time.Second,
+		10*time.Millisecond,
+	)
+	assert.Equal(
+		t,
+		"type,timestamp,slot,block,hash,summary\n"+
+			"chainsync.block,2024-01-01T00:00:00Z,3456,12,abcd,transactions=2 fees=300 output=0\n"+
+			"chainsync.rollback,2024-01-01T00:00:00Z,3400,,ef01,\n"+
+			`chainsync.reset,2024-01-01T00:00:00Z,,,,"{""reason"":""intersectNotFound"",""blockHash"":"""",""slotNumber"":3500}"`+"\n",
+		buf.String(),
+	)
+}
+
+func TestEventTypes(t *testing.T) {
+	var buf syncBuffer
+	l := log.New(
+		log.WithLogger(zap.NewNop().Sugar()),
+		log.WithFormat(log.FormatCSV),
+		log.WithWriter(&buf),
+		log.WithEventTypes([]string{"chainsync.rollback"}),
+	)
+	assert.NoError(t, l.Start())
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.InputChan() <- event.New(
+		"chainsync.block",
+		timestamp,
+		chainsync.BlockContext{BlockNumber: 12, SlotNumber: 3456},
+		chainsync.BlockEvent{BlockHash: "abcd"},
+	)
+	l.InputChan() <- event.New(
+		"chainsync.rollback",
+		timestamp,
+		nil,
+		chainsync.RollbackEvent{BlockHash: "ef01", SlotNumber: 3400},
+	)
+	assert.NoError(t, l.Stop())
+	assert.Eventually(
+		t,
+		func() bool { return strings.Count(buf.String(), "\n") == 2 },
+		time.Second,
+		10*time.Millisecond,
+	)
+	assert.Equal(
+		t,
+		"type,timestamp,slot,block,hash,summary\n"+
+			"chainsync.rollback,2024-01-01T00:00:00Z,3400,,ef01,\n",
+		buf.String(),
+	)
+}
+
+func TestMaskAddresses(t *testing.T) {
+	address := "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x"
+	for _, maskAddresses := range []bool{false, true} {
+		var buf syncBuffer
+		l := log.New(
+			log.WithLogger(zap.NewNop().Sugar()),
+			log.WithFormat(log.FormatCSV),
+			log.WithWriter(&buf),
+			log.WithMaskAddresses(maskAddresses),
+		)
+		assert.NoError(t, l.Start())
+		l.InputChan() <- event.New(
+			"chainsync.address",
+			time.Now(),
+			nil,
+			map[string]any{"address": address},
+		)
+		assert.NoError(t, l.Stop())
+		assert.Eventually(
+			t,
+			func() bool { return strings.Count(buf.String(), "\n") == 2 },
+			time.Second,
+			10*time.Millisecond,
+		)
+		if maskAddresses {
+			assert.Contains(t, buf.String(), "addr1qx2fxv...e35a3x")
+			assert.NotContains(t, buf.String(), address)
+		} else {
+			assert.Contains(t, buf.String(), address)
+		}
+	}
+}
@@ -31,3 +31,59 @@ func WithLevel(level string) LogOptionFunc {
 		o.level = level
 	}
 }
+
+// WithLargeIntsAsStrings specifies whether to render integers outside of the JavaScript safe integer range as strings
+func WithLargeIntsAsStrings(largeIntsAsStrings bool) LogOptionFunc {
+	return func(o *LogOutput) {
+		o.jsonOptions.LargeIntsAsStrings = largeIntsAsStrings
+	}
+}
+
+// WithSortKeys specifies whether to render the keys of all JSON objects in sorted order for reproducible output
+func WithSortKeys(sortKeys bool) LogOptionFunc {
+	return func(o *LogOutput) {
+		o.jsonOptions.SortKeys = sortKeys
+	}
+}
+
+// WithCborDiagnostic specifies whether to add the CBOR diagnostic notation alongside any CBOR included in logged
+// events
+func WithCborDiagnostic(cborDiagnostic bool) LogOptionFunc {
+	return func(o *LogOutput) {
+		o.jsonOptions.CborDiagnostic = cborDiagnostic
+	}
+}
+
+// WithMaskAddresses specifies whether to mask the middle of addresses in logged events
+func WithMaskAddresses(maskAddresses bool) LogOptionFunc {
+	return func(o *LogOutput) {
+		o.jsonOptions.MaskAddresses = maskAddresses
+	}
+}
+
+// WithEventTypes specifies the event types to log, such as chainsync.rollback. Events of other types are skipped,
+// and an empty list (the default) logs all events
+func WithEventTypes(eventTypes []string) LogOptionFunc {
+	return func(o *LogOutput) {
+		o.eventTypes = make(map[string]bool, len(eventTypes))
+		for _, eventType := range eventTypes {
+			o.eventTypes[eventType] = true
+		}
+	}
+}
+
+// WithFormat specifies the output format for events. The default of FormatJSON logs each event using the logger,
+// while FormatCSV writes a header row followed by one row per event to stdout
+func WithFormat(format string) LogOptionFunc {
+	return func(o *LogOutput) {
+		o.format = format
+	}
+}
+
+// WithSerializeWorkers specifies the number of workers used to encode events to JSON in parallel. Events are still
+// logged in the order they were received. A value of 1 or less, or the CSV format, encodes them on the output goroutine
+func WithSerializeWorkers(serializeWorkers int) LogOptionFunc {
+	return func(o *LogOutput) {
+		o.serializeWorkers = serializeWorkers
+	}
+}
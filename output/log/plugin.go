@@ -15,12 +15,21 @@
 package log
 
 import (
+	"strings"
+
 	"github.com/blinklabs-io/adder/internal/logging"
 	"github.com/blinklabs-io/adder/plugin"
 )
 
 var cmdlineOptions struct {
-	level string
+	level              string
+	largeIntsAsStrings bool
+	sortKeys           bool
+	format             string
+	eventTypes         string
+	maskAddresses      bool
+	cborDiagnostic     bool
+	serializeWorkers   uint
 }
 
 func init() {
@@ -38,17 +47,79 @@ func init() {
 					DefaultValue: "info",
 					Dest:         &(cmdlineOptions.level),
 				},
+				{
+					Name:         "large-ints-as-strings",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "render integers outside of the JavaScript safe integer range as strings",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.largeIntsAsStrings),
+				},
+				{
+					Name:         "sort-keys",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "render the keys of all JSON objects in sorted order for reproducible output",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.sortKeys),
+				},
+				{
+					Name:         "mask-addresses",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "mask the middle of addresses in logged events",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.maskAddresses),
+				},
+				{
+					Name:         "cbor-diagnostic",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "add the CBOR diagnostic notation alongside any CBOR included in logged events",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.cborDiagnostic),
+				},
+				{
+					Name:         "format",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the output format to use (json or csv)",
+					DefaultValue: FormatJSON,
+					Dest:         &(cmdlineOptions.format),
+				},
+				{
+					Name:         "event-types",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the event type(s) to log, separated by commas (all if empty)",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.eventTypes),
+				},
+				{
+					Name:         "serialize-workers",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the number of workers used to encode events in parallel (json format only)",
+					DefaultValue: uint(1),
+					Dest:         &(cmdlineOptions.serializeWorkers),
+				},
 			},
 		},
 	)
 }
 
 func NewFromCmdlineOptions() plugin.Plugin {
-	p := New(
+	options := []LogOptionFunc{
 		WithLogger(
 			logging.GetLogger().With("plugin", "output.log"),
 		),
 		WithLevel(cmdlineOptions.level),
-	)
+		WithLargeIntsAsStrings(cmdlineOptions.largeIntsAsStrings),
+		WithSortKeys(cmdlineOptions.sortKeys),
+		WithFormat(cmdlineOptions.format),
+		WithMaskAddresses(cmdlineOptions.maskAddresses),
+		WithCborDiagnostic(cmdlineOptions.cborDiagnostic),
+		WithSerializeWorkers(int(cmdlineOptions.serializeWorkers)),
+	}
+	if cmdlineOptions.eventTypes != "" {
+		options = append(
+			options,
+			WithEventTypes(strings.Split(cmdlineOptions.eventTypes, ",")),
+		)
+	}
+	p := New(options...)
 	return p
 }
@@ -0,0 +1,171 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+const (
+	// Maximum time to wait for the initial connection to the broker
+	connectTimeout = 30 * time.Second
+
+	// Maximum time to wait for a publish to complete
+	publishTimeout = 30 * time.Second
+
+	// Time in milliseconds to wait for in-flight work to complete when disconnecting
+	disconnectQuiesce = 250
+)
+
+type MqttOutput struct {
+	errorChan chan error
+	eventChan chan event.Event
+	doneChan  chan struct{}
+	logger    plugin.Logger
+	broker    string
+	clientId  string
+	qos       byte
+	username  string
+	password  string
+	tls       bool
+	baseTopic string
+	client    paho.Client
+}
+
+func New(options ...MqttOptionFunc) *MqttOutput {
+	m := &MqttOutput{
+		errorChan: make(chan error),
+		eventChan: make(chan event.Event, 10),
+		doneChan:  make(chan struct{}),
+		broker:    "tcp://localhost:1883",
+		clientId:  "adder",
+		baseTopic: "cardano",
+	}
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+// Start the MQTT output
+func (m *MqttOutput) Start() error {
+	opts := paho.NewClientOptions().
+		AddBroker(m.broker).
+		SetClientID(m.clientId).
+		SetUsername(m.username).
+		SetPassword(m.password).
+		SetAutoReconnect(true).
+		SetConnectTimeout(connectTimeout).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			if m.logger != nil {
+				m.logger.Warnf("lost connection to MQTT broker: %s", err)
+			}
+		}).
+		SetReconnectingHandler(func(_ paho.Client, _ *paho.ClientOptions) {
+			if m.logger != nil {
+				m.logger.Infof("reconnecting to MQTT broker at %s", m.broker)
+			}
+		})
+	if m.tls {
+		opts.SetTLSConfig(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	client := paho.NewClient(opts)
+	token := client.Connect()
+	if !token.WaitTimeout(connectTimeout) {
+		return fmt.Errorf("timed out connecting to MQTT broker at %s", m.broker)
+	}
+	if err := token.Error(); err != nil {
+		return fmt.Errorf("failed to connect to MQTT broker: %w", err)
+	}
+	m.client = client
+	if m.logger != nil {
+		m.logger.Infof("connected to MQTT broker at %s", m.broker)
+	}
+	go m.publishLoop()
+	return nil
+}
+
+func (m *MqttOutput) publishLoop() {
+	defer close(m.doneChan)
+	for {
+		evt, ok := <-m.eventChan
+		// Channel has been closed, which means we're shutting down
+		if !ok {
+			return
+		}
+		data, err := json.Marshal(&evt)
+		if err != nil {
+			if m.logger != nil {
+				m.logger.Errorf("failed to encode event: %s", err)
+			}
+			continue
+		}
+		topic := m.topic(evt.Type)
+		// Wait for the publish to complete, which includes the broker acknowledgement for QoS 1 and 2
+		token := m.client.Publish(topic, m.qos, false, data)
+		if !token.WaitTimeout(publishTimeout) {
+			m.errorChan <- fmt.Errorf("timed out publishing event to MQTT topic %s", topic)
+			continue
+		}
+		if err := token.Error(); err != nil {
+			m.errorChan <- fmt.Errorf("failed to publish event to MQTT topic %s: %w", topic, err)
+		}
+	}
+}
+
+// topic returns the MQTT topic to publish the specified event type to. The parts of the event type become topic
+// levels under the base topic (e.g. cardano/chainsync/block)
+func (m *MqttOutput) topic(eventType string) string {
+	topic := strings.ReplaceAll(eventType, ".", "/")
+	if m.baseTopic == "" {
+		return topic
+	}
+	return strings.TrimSuffix(m.baseTopic, "/") + "/" + topic
+}
+
+// Stop the MQTT output, waiting for any in-flight events to be published before disconnecting
+func (m *MqttOutput) Stop() error {
+	close(m.eventChan)
+	if m.client != nil {
+		<-m.doneChan
+		m.client.Disconnect(disconnectQuiesce)
+	}
+	close(m.errorChan)
+	return nil
+}
+
+// ErrorChan returns the output error channel
+func (m *MqttOutput) ErrorChan() chan error {
+	return m.errorChan
+}
+
+// InputChan returns the input event channel
+func (m *MqttOutput) InputChan() chan<- event.Event {
+	return m.eventChan
+}
+
+// OutputChan always returns nil
+func (m *MqttOutput) OutputChan() <-chan event.Event {
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt_test
+
+import (
+	"testing"
+
+	"github.com/blinklabs-io/adder/output/mqtt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopic(t *testing.T) {
+	testDefs := []struct {
+		baseTopic     string
+		eventType     string
+		expectedTopic string
+	}{
+		{baseTopic: "cardano", eventType: "chainsync.block", expectedTopic: "cardano/chainsync/block"},
+		{baseTopic: "dashboards/cardano/", eventType: "chainsync.transaction", expectedTopic: "dashboards/cardano/chainsync/transaction"},
+		{baseTopic: "", eventType: "chainsync.rollback", expectedTopic: "chainsync/rollback"},
+	}
+	for _, testDef := range testDefs {
+		m := mqtt.New(mqtt.WithBaseTopic(testDef.baseTopic))
+		assert.Equal(t, testDef.expectedTopic, m.Topic(testDef.eventType))
+	}
+}
+
+func TestStartBrokerUnavailable(t *testing.T) {
+	m := mqtt.New(mqtt.WithBroker("tcp://127.0.0.1:1"))
+	assert.ErrorContains(t, m.Start(), "failed to connect to MQTT broker")
+	assert.NoError(t, m.Stop())
+}
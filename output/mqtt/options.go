@@ -0,0 +1,77 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import "github.com/blinklabs-io/adder/plugin"
+
+type MqttOptionFunc func(*MqttOutput)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) MqttOptionFunc {
+	return func(o *MqttOutput) {
+		o.logger = logger
+	}
+}
+
+// WithBroker specifies the URL of the MQTT broker (e.g. tcp://localhost:1883)
+func WithBroker(broker string) MqttOptionFunc {
+	return func(o *MqttOutput) {
+		o.broker = broker
+	}
+}
+
+// WithClientID specifies the client ID to use when connecting to the broker
+func WithClientID(clientId string) MqttOptionFunc {
+	return func(o *MqttOutput) {
+		o.clientId = clientId
+	}
+}
+
+// WithQoS specifies the MQTT quality of service level (0, 1 or 2) to publish events with
+func WithQoS(qos byte) MqttOptionFunc {
+	return func(o *MqttOutput) {
+		o.qos = qos
+	}
+}
+
+// WithUsername specifies the username to use for authentication
+func WithUsername(username string) MqttOptionFunc {
+	return func(o *MqttOutput) {
+		o.username = username
+	}
+}
+
+// WithPassword specifies the password to use for authentication
+func WithPassword(password string) MqttOptionFunc {
+	return func(o *MqttOutput) {
+		o.password = password
+	}
+}
+
+// WithTLS specifies whether to use TLS when connecting to the broker. Brokers with an ssl:// or tls:// URL always
+// use TLS
+func WithTLS(tls bool) MqttOptionFunc {
+	return func(o *MqttOutput) {
+		o.tls = tls
+	}
+}
+
+// WithBaseTopic specifies the base topic events are published under. The event type is appended as topic
+// levels to form the full topic (e.g. cardano/chainsync/block)
+func WithBaseTopic(baseTopic string) MqttOptionFunc {
+	return func(o *MqttOutput) {
+		o.baseTopic = baseTopic
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mqtt
+
+import (
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	broker    string
+	clientId  string
+	qos       uint
+	username  string
+	password  string
+	tls       bool
+	baseTopic string
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeOutput,
+			Name:               "mqtt",
+			Description:        "publish events to an MQTT broker",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "broker",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the URL of the MQTT broker",
+					DefaultValue: "tcp://localhost:1883",
+					Dest:         &(cmdlineOptions.broker),
+				},
+				{
+					Name:         "client-id",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the client ID to use when connecting to the broker",
+					DefaultValue: "adder",
+					Dest:         &(cmdlineOptions.clientId),
+				},
+				{
+					Name:         "qos",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the quality of service level (0, 1 or 2) to publish events with",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.qos),
+				},
+				{
+					Name:         "username",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the username to use for authentication",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.username),
+				},
+				{
+					Name:         "password",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the password to use for authentication",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.password),
+				},
+				{
+					Name:         "tls",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "use TLS when connecting to the broker",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.tls),
+				},
+				{
+					Name:         "base-topic",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the base topic events are published under",
+					DefaultValue: "cardano",
+					Dest:         &(cmdlineOptions.baseTopic),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	if cmdlineOptions.qos > 2 {
+		panic("invalid MQTT QoS level, must be 0, 1 or 2")
+	}
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "output.mqtt"),
+		),
+		WithBroker(cmdlineOptions.broker),
+		WithClientID(cmdlineOptions.clientId),
+		WithQoS(byte(cmdlineOptions.qos)),
+		WithUsername(cmdlineOptions.username),
+		WithPassword(cmdlineOptions.password),
+		WithTLS(cmdlineOptions.tls),
+		WithBaseTopic(cmdlineOptions.baseTopic),
+	)
+	return p
+}
@@ -0,0 +1,194 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/nats-io/nats.go"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+const (
+	// Delay bounds between reconnect attempts
+	reconnectMinDelay = 1 * time.Second
+	reconnectMaxDelay = 30 * time.Second
+
+	// Maximum time to wait for pending messages to be flushed on shutdown
+	drainTimeout = 30 * time.Second
+)
+
+type NatsOutput struct {
+	errorChan       chan error
+	eventChan       chan event.Event
+	logger          plugin.Logger
+	url             string
+	subjectPrefix   string
+	credentialsFile string
+	jetStream       bool
+	conn            *nats.Conn
+	js              nats.JetStreamContext
+	doneChan        chan struct{}
+	closedChan      chan struct{}
+}
+
+func New(options ...NatsOptionFunc) *NatsOutput {
+	n := &NatsOutput{
+		errorChan:     make(chan error),
+		eventChan:     make(chan event.Event, 10),
+		url:           nats.DefaultURL,
+		subjectPrefix: "cardano",
+		doneChan:      make(chan struct{}),
+		closedChan:    make(chan struct{}),
+	}
+	for _, option := range options {
+		option(n)
+	}
+	return n
+}
+
+// Start the NATS output
+func (n *NatsOutput) Start() error {
+	natsOpts := []nats.Option{
+		nats.Name("adder"),
+		// Keep trying to reconnect forever, backing off between attempts
+		nats.MaxReconnects(-1),
+		nats.CustomReconnectDelay(reconnectDelay),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if n.logger != nil && err != nil {
+				n.logger.Warnf("disconnected from NATS server: %s", err)
+			}
+		}),
+		nats.ReconnectHandler(func(conn *nats.Conn) {
+			if n.logger != nil {
+				n.logger.Infof("reconnected to NATS server at %s", conn.ConnectedUrl())
+			}
+		}),
+		nats.ClosedHandler(func(_ *nats.Conn) {
+			close(n.closedChan)
+		}),
+	}
+	if n.credentialsFile != "" {
+		natsOpts = append(natsOpts, nats.UserCredentials(n.credentialsFile))
+	}
+	conn, err := nats.Connect(n.url, natsOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS server: %w", err)
+	}
+	n.conn = conn
+	if n.jetStream {
+		js, err := conn.JetStream()
+		if err != nil {
+			conn.Close()
+			return fmt.Errorf("failed to create JetStream context: %w", err)
+		}
+		n.js = js
+	}
+	if n.logger != nil {
+		n.logger.Infof("connected to NATS server at %s", conn.ConnectedUrl())
+	}
+	go n.publishLoop()
+	return nil
+}
+
+func (n *NatsOutput) publishLoop() {
+	defer close(n.doneChan)
+	for {
+		evt, ok := <-n.eventChan
+		// Channel has been closed, which means we're shutting down
+		if !ok {
+			return
+		}
+		data, err := json.Marshal(&evt)
+		if err != nil {
+			if n.logger != nil {
+				n.logger.Errorf("failed to encode event: %s", err)
+			}
+			continue
+		}
+		subject := n.subject(evt.Type)
+		if n.js != nil {
+			// Wait for the server to acknowledge the message
+			if _, err := n.js.Publish(subject, data); err != nil {
+				n.errorChan <- fmt.Errorf("failed to publish event to JetStream subject %s: %w", subject, err)
+				return
+			}
+		} else {
+			if err := n.conn.Publish(subject, data); err != nil {
+				if n.logger != nil {
+					n.logger.Errorf("failed to publish event to subject %s: %s", subject, err)
+				}
+			}
+		}
+	}
+}
+
+// subject returns the NATS subject to publish the specified event type to
+func (n *NatsOutput) subject(eventType string) string {
+	if n.subjectPrefix == "" {
+		return eventType
+	}
+	return n.subjectPrefix + "." + eventType
+}
+
+// reconnectDelay returns the delay before the next reconnect attempt, doubling with each attempt
+func reconnectDelay(attempts int) time.Duration {
+	delay := reconnectMinDelay
+	for i := 1; i < attempts && delay < reconnectMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, reconnectMaxDelay)
+}
+
+// Stop the NATS output, flushing any pending messages before closing the connection
+func (n *NatsOutput) Stop() error {
+	close(n.eventChan)
+	var err error
+	if n.conn != nil {
+		// Wait for any in-flight events to be published
+		<-n.doneChan
+		if err = n.conn.Drain(); err == nil {
+			select {
+			case <-n.closedChan:
+			case <-time.After(drainTimeout):
+				err = fmt.Errorf("timed out draining NATS connection")
+				n.conn.Close()
+			}
+		} else {
+			n.conn.Close()
+		}
+	}
+	close(n.errorChan)
+	return err
+}
+
+// ErrorChan returns the output error channel
+func (n *NatsOutput) ErrorChan() chan error {
+	return n.errorChan
+}
+
+// InputChan returns the input event channel
+func (n *NatsOutput) InputChan() chan<- event.Event {
+	return n.eventChan
+}
+
+// OutputChan always returns nil
+func (n *NatsOutput) OutputChan() <-chan event.Event {
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/output/nats"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubject(t *testing.T) {
+	testDefs := []struct {
+		subjectPrefix   string
+		eventType       string
+		expectedSubject string
+	}{
+		{subjectPrefix: "", eventType: "chainsync.block", expectedSubject: "chainsync.block"},
+		{subjectPrefix: "cardano", eventType: "chainsync.transaction", expectedSubject: "cardano.chainsync.transaction"},
+	}
+	for _, testDef := range testDefs {
+		n := nats.New(nats.WithSubjectPrefix(testDef.subjectPrefix))
+		assert.Equal(t, testDef.expectedSubject, n.Subject(testDef.eventType))
+	}
+}
+
+func TestReconnectDelay(t *testing.T) {
+	testDefs := []struct {
+		attempts      int
+		expectedDelay time.Duration
+	}{
+		{attempts: 1, expectedDelay: 1 * time.Second},
+		{attempts: 2, expectedDelay: 2 * time.Second},
+		{attempts: 5, expectedDelay: 16 * time.Second},
+		// The delay is capped at 30s
+		{attempts: 6, expectedDelay: 30 * time.Second},
+		{attempts: 100, expectedDelay: 30 * time.Second},
+	}
+	for _, testDef := range testDefs {
+		assert.Equal(t, testDef.expectedDelay, nats.ReconnectDelay(testDef.attempts))
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import "github.com/blinklabs-io/adder/plugin"
+
+type NatsOptionFunc func(*NatsOutput)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) NatsOptionFunc {
+	return func(o *NatsOutput) {
+		o.logger = logger
+	}
+}
+
+// WithUrl specifies the URL of the NATS server
+func WithUrl(url string) NatsOptionFunc {
+	return func(o *NatsOutput) {
+		o.url = url
+	}
+}
+
+// WithSubjectPrefix specifies the prefix for the subject events are published to. The event type is
+// appended to form the full subject (e.g. cardano.chainsync.block)
+func WithSubjectPrefix(subjectPrefix string) NatsOptionFunc {
+	return func(o *NatsOutput) {
+		o.subjectPrefix = subjectPrefix
+	}
+}
+
+// WithCredentialsFile specifies the NATS credentials file to use for authentication
+func WithCredentialsFile(credentialsFile string) NatsOptionFunc {
+	return func(o *NatsOutput) {
+		o.credentialsFile = credentialsFile
+	}
+}
+
+// WithJetStream specifies whether to publish events to JetStream and wait for an acknowledgement
+func WithJetStream(jetStream bool) NatsOptionFunc {
+	return func(o *NatsOutput) {
+		o.jetStream = jetStream
+	}
+}
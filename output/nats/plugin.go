@@ -0,0 +1,83 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nats
+
+import (
+	"github.com/nats-io/nats.go"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	url             string
+	subjectPrefix   string
+	credentialsFile string
+	jetStream       bool
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeOutput,
+			Name:               "nats",
+			Description:        "publish events to a NATS server",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "url",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the URL of the NATS server",
+					DefaultValue: nats.DefaultURL,
+					Dest:         &(cmdlineOptions.url),
+				},
+				{
+					Name:         "subject-prefix",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the prefix for the subject events are published to",
+					DefaultValue: "cardano",
+					Dest:         &(cmdlineOptions.subjectPrefix),
+				},
+				{
+					Name:         "credentials-file",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the NATS credentials file to use for authentication",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.credentialsFile),
+				},
+				{
+					Name:         "jetstream",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "publish events to JetStream and wait for an acknowledgement",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.jetStream),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "output.nats"),
+		),
+		WithUrl(cmdlineOptions.url),
+		WithSubjectPrefix(cmdlineOptions.subjectPrefix),
+		WithCredentialsFile(cmdlineOptions.credentialsFile),
+		WithJetStream(cmdlineOptions.jetStream),
+	)
+	return p
+}
@@ -51,94 +51,59 @@ func (n *NotifyOutput) Start() error {
 			if !ok {
 				return
 			}
-			switch evt.Type {
-			case "chainsync.block":
-				payload := evt.Payload
-				if payload == nil {
-					panic(fmt.Errorf("ERROR: %v", payload))
-				}
-				context := evt.Context
-				if context == nil {
-					panic(fmt.Errorf("ERROR: %v", context))
-				}
-
-				be := payload.(chainsync.BlockEvent)
-				bc := context.(chainsync.BlockContext)
-				err := beeep.Notify(
-					n.title,
-					fmt.Sprintf(
-						"New Block!\nBlockNumber: %d, SlotNumber: %d, TransactionCount: %d\nHash: %s",
-						bc.BlockNumber,
-						bc.SlotNumber,
-						be.TransactionCount,
-						be.BlockHash,
-					),
-					"assets/adder-icon.png",
-				)
-				if err != nil {
-					panic(err)
-				}
-			case "chainsync.rollback":
-				payload := evt.Payload
-				if payload == nil {
-					panic(fmt.Errorf("ERROR: %v", payload))
-				}
-
-				re := payload.(chainsync.RollbackEvent)
-				err := beeep.Notify(
-					n.title,
-					fmt.Sprintf("Rollback!\nSlotNumber: %d\nBlockHash: %s",
-						re.SlotNumber,
-						re.BlockHash,
-					),
-					"assets/adder-icon.png",
-				)
-				if err != nil {
-					panic(err)
-				}
-			case "chainsync.transaction":
-				payload := evt.Payload
-				if payload == nil {
-					panic(fmt.Errorf("ERROR: %v", payload))
-				}
-				context := evt.Context
-				if context == nil {
-					panic(fmt.Errorf("ERROR: %v", context))
-				}
-
-				te := payload.(chainsync.TransactionEvent)
-				tc := context.(chainsync.TransactionContext)
-				err := beeep.Notify(
-					n.title,
-					fmt.Sprintf(
-						"New Transaction!\nBlockNumber: %d, SlotNumber: %d\nInputs: %d, Outputs: %d\nFee: %d\nHash: %s",
-						tc.BlockNumber,
-						tc.SlotNumber,
-						len(te.Inputs),
-						len(te.Outputs),
-						te.Fee,
-						tc.TransactionHash,
-					),
-					"assets/adder-icon.png",
-				)
-				if err != nil {
-					panic(err)
-				}
-			default:
-				err := beeep.Notify(
-					n.title,
-					fmt.Sprintf("New Event!\nEvent: %v", evt),
-					"assets/adder-icon.png",
-				)
-				if err != nil {
-					panic(err)
-				}
+			err := beeep.Notify(
+				n.title,
+				notifyMessage(evt),
+				"assets/adder-icon.png",
+			)
+			if err != nil {
+				panic(err)
 			}
 		}
 	}()
 	return nil
 }
 
+// notifyMessage returns the notification text for an event. Events without the payload types from the chainsync
+// input get a generic message
+func notifyMessage(evt event.Event) string {
+	eventType := event.BaseType(evt.Type)
+	if !chainsync.HasTypedPayload(evt) {
+		eventType = ""
+	}
+	switch eventType {
+	case "chainsync.block":
+		be := evt.Payload.(chainsync.BlockEvent)
+		bc := evt.Context.(chainsync.BlockContext)
+		return fmt.Sprintf(
+			"New Block!\nBlockNumber: %d, SlotNumber: %d, TransactionCount: %d\nHash: %s",
+			bc.BlockNumber,
+			bc.SlotNumber,
+			be.TransactionCount,
+			be.BlockHash,
+		)
+	case "chainsync.rollback":
+		re := evt.Payload.(chainsync.RollbackEvent)
+		return fmt.Sprintf("Rollback!\nSlotNumber: %d\nBlockHash: %s",
+			re.SlotNumber,
+			re.BlockHash,
+		)
+	case "chainsync.transaction":
+		te := evt.Payload.(chainsync.TransactionEvent)
+		tc := evt.Context.(chainsync.TransactionContext)
+		return fmt.Sprintf(
+			"New Transaction!\nBlockNumber: %d, SlotNumber: %d\nInputs: %d, Outputs: %d\nFee: %d\nHash: %s",
+			tc.BlockNumber,
+			tc.SlotNumber,
+			len(te.Inputs),
+			len(te.Outputs),
+			te.Fee,
+			tc.TransactionHash,
+		)
+	}
+	return fmt.Sprintf("New Event!\nEvent: %v", evt)
+}
+
 // Stop the embedded output
 func (n *NotifyOutput) Stop() error {
 	close(n.eventChan)
@@ -0,0 +1,61 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notify_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/filter/project"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/output/notify"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotifyMessage(t *testing.T) {
+	txEvt := event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{BlockNumber: 12, TransactionHash: "abcd"},
+		chainsync.TransactionEvent{Fee: 170000, TransactionCbor: []byte{0x84}},
+	)
+	assert.True(t, strings.HasPrefix(notify.NotifyMessage(txEvt), "New Transaction!"))
+
+	// Events passed through the project filter carry generic payloads, which get the generic message
+	p := project.New(
+		project.WithExcludeFields([]string{"payload.transactionCbor"}),
+	)
+	assert.NoError(t, p.Start())
+	defer func() {
+		_ = p.Stop()
+	}()
+	p.InputChan() <- txEvt
+	var projectedEvt event.Event
+	select {
+	case projectedEvt = <-p.OutputChan():
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	msg := notify.NotifyMessage(projectedEvt)
+	assert.True(t, strings.HasPrefix(msg, "New Event!"))
+	assert.NotContains(t, msg, "transactionCbor")
+	// Events without a payload don't panic either
+	assert.True(
+		t,
+		strings.HasPrefix(notify.NotifyMessage(event.New("chainsync.block", time.Now(), nil, nil)), "New Event!"),
+	)
+}
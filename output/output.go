@@ -16,8 +16,18 @@ package output
 
 // We import the various plugins that we want to be auto-registered
 import (
+	_ "github.com/blinklabs-io/adder/output/amqp"
+	_ "github.com/blinklabs-io/adder/output/file"
+	_ "github.com/blinklabs-io/adder/output/grpc"
 	_ "github.com/blinklabs-io/adder/output/log"
+	_ "github.com/blinklabs-io/adder/output/mqtt"
+	_ "github.com/blinklabs-io/adder/output/nats"
 	_ "github.com/blinklabs-io/adder/output/notify"
+	_ "github.com/blinklabs-io/adder/output/parquet"
+	_ "github.com/blinklabs-io/adder/output/postgres"
 	_ "github.com/blinklabs-io/adder/output/push"
+	_ "github.com/blinklabs-io/adder/output/redis"
+	_ "github.com/blinklabs-io/adder/output/sqlite"
 	_ "github.com/blinklabs-io/adder/output/webhook"
+	_ "github.com/blinklabs-io/adder/output/websocket"
 )
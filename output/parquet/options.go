@@ -0,0 +1,53 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"time"
+
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+type ParquetOptionFunc func(*ParquetOutput)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) ParquetOptionFunc {
+	return func(o *ParquetOutput) {
+		o.logger = logger
+	}
+}
+
+// WithDirectory specifies the base directory to write files to. Files are partitioned into subdirectories by
+// event type and epoch
+func WithDirectory(directory string) ParquetOptionFunc {
+	return func(o *ParquetOutput) {
+		o.directory = directory
+	}
+}
+
+// WithMaxRows specifies the number of rows at which a file is rotated. A value of 0 disables rotation by row count
+func WithMaxRows(maxRows int) ParquetOptionFunc {
+	return func(o *ParquetOutput) {
+		o.maxRows = maxRows
+	}
+}
+
+// WithRotateInterval specifies how long a file is kept open before it is rotated. A value of 0 disables rotation
+// by time
+func WithRotateInterval(rotateInterval time.Duration) ParquetOptionFunc {
+	return func(o *ParquetOutput) {
+		o.rotateInterval = rotateInterval
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+const (
+	// How often open files are checked against the rotate interval
+	rotateCheckInterval = 1 * time.Second
+	// Suffix used for files that are still being written
+	inProgressSuffix = ".tmp"
+)
+
+type ParquetOutput struct {
+	errorChan      chan error
+	eventChan      chan event.Event
+	doneChan       chan error
+	logger         plugin.Logger
+	directory      string
+	maxRows        int
+	rotateInterval time.Duration
+	files          map[partitionKey]*partitionFile
+	networkMagic   uint32
+}
+
+// partitionKey identifies the file that an event is written to
+type partitionKey struct {
+	eventType string
+	epoch     string
+}
+
+type partitionFile struct {
+	path   string
+	file   *os.File
+	writer *parquet.Writer
+	rows   int
+	opened time.Time
+}
+
+func New(options ...ParquetOptionFunc) *ParquetOutput {
+	p := &ParquetOutput{
+		errorChan:      make(chan error),
+		eventChan:      make(chan event.Event, 10),
+		doneChan:       make(chan error, 1),
+		directory:      "parquet",
+		maxRows:        100000,
+		rotateInterval: 1 * time.Hour,
+		files:          make(map[partitionKey]*partitionFile),
+	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// Start the parquet output
+func (p *ParquetOutput) Start() error {
+	go func() {
+		ticker := time.NewTicker(rotateCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.rotateExpired(); err != nil {
+					p.errorChan <- err
+				}
+			case evt, ok := <-p.eventChan:
+				// Channel has been closed, which means we're shutting down
+				if !ok {
+					p.doneChan <- p.closeAll()
+					return
+				}
+				if err := p.handleEvent(evt); err != nil {
+					p.errorChan <- err
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// handleEvent writes the event to the file for its event type and epoch. A rollback finalizes all open files
+// before it is written, so that rows for the abandoned blocks are never mixed into files for the new chain
+func (p *ParquetOutput) handleEvent(evt event.Event) error {
+	var row any
+	var slot uint64
+	switch payload := evt.Payload.(type) {
+	case chainsync.BlockEvent:
+		ctx, _ := evt.Context.(chainsync.BlockContext)
+		p.networkMagic = ctx.NetworkMagic
+		slot = ctx.SlotNumber
+		row = newBlockRow(evt, ctx, payload)
+	case chainsync.TransactionEvent:
+		ctx, _ := evt.Context.(chainsync.TransactionContext)
+		p.networkMagic = ctx.NetworkMagic
+		slot = ctx.SlotNumber
+		row = newTransactionRow(evt, ctx, payload)
+	case chainsync.RollbackEvent:
+		if err := p.closeAll(); err != nil {
+			return err
+		}
+		slot = payload.SlotNumber
+		row = newRollbackRow(evt, payload)
+	default:
+		if p.logger != nil {
+			p.logger.Debugf("skipping unsupported event type: %s", evt.Type)
+		}
+		return nil
+	}
+	key := partitionKey{
+		eventType: evt.Type,
+		epoch:     "unknown",
+	}
+	// Rollback events carry no context, so the network of the last block or transaction is used
+	if epoch, err := chainsync.EpochFromSlot(p.networkMagic, slot); err == nil {
+		key.epoch = fmt.Sprintf("%d", epoch)
+	}
+	pf, err := p.partitionFile(key, row)
+	if err != nil {
+		return err
+	}
+	if err := pf.writer.Write(row); err != nil {
+		return fmt.Errorf("failed to write row to %s: %w", pf.path, err)
+	}
+	pf.rows++
+	if p.maxRows > 0 && pf.rows >= p.maxRows {
+		return p.closeFile(key)
+	}
+	return nil
+}
+
+// partitionFile returns the open file for the partition, creating it with the schema of the row if needed
+func (p *ParquetOutput) partitionFile(
+	key partitionKey,
+	row any,
+) (*partitionFile, error) {
+	if pf, ok := p.files[key]; ok {
+		return pf, nil
+	}
+	dir := filepath.Join(p.directory, key.eventType, "epoch="+key.epoch)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	now := time.Now()
+	path := filepath.Join(
+		dir,
+		fmt.Sprintf("%s-%d.parquet", key.eventType, now.UnixNano()),
+	)
+	file, err := os.Create(path + inProgressSuffix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file %s: %w", path, err)
+	}
+	pf := &partitionFile{
+		path:   path,
+		file:   file,
+		writer: parquet.NewWriter(file, parquet.SchemaOf(row)),
+		opened: now,
+	}
+	p.files[key] = pf
+	return pf, nil
+}
+
+// closeFile finalizes the file for the partition. Files are written under a temporary name and only renamed
+// into place once complete, so readers never see a partially written file
+func (p *ParquetOutput) closeFile(key partitionKey) error {
+	pf, ok := p.files[key]
+	if !ok {
+		return nil
+	}
+	delete(p.files, key)
+	err := pf.writer.Close()
+	if closeErr := pf.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to close file %s: %w", pf.path, err)
+	}
+	if err := os.Rename(pf.path+inProgressSuffix, pf.path); err != nil {
+		return fmt.Errorf("failed to finalize file %s: %w", pf.path, err)
+	}
+	return nil
+}
+
+// closeAll finalizes all open files
+func (p *ParquetOutput) closeAll() error {
+	var ret error
+	for key := range p.files {
+		if err := p.closeFile(key); err != nil && ret == nil {
+			ret = err
+		}
+	}
+	return ret
+}
+
+// rotateExpired finalizes any open files that have been open longer than the rotate interval
+func (p *ParquetOutput) rotateExpired() error {
+	if p.rotateInterval <= 0 {
+		return nil
+	}
+	var ret error
+	for key, pf := range p.files {
+		if time.Since(pf.opened) < p.rotateInterval {
+			continue
+		}
+		if err := p.closeFile(key); err != nil && ret == nil {
+			ret = err
+		}
+	}
+	return ret
+}
+
+// Stop the parquet output. All open files are finalized before returning
+func (p *ParquetOutput) Stop() error {
+	close(p.eventChan)
+	err := <-p.doneChan
+	close(p.errorChan)
+	return err
+}
+
+// ErrorChan returns the plugin's error channel
+func (p *ParquetOutput) ErrorChan() chan error {
+	return p.errorChan
+}
+
+// InputChan returns the input event channel
+func (p *ParquetOutput) InputChan() chan<- event.Event {
+	return p.eventChan
+}
+
+// OutputChan always returns nil
+func (p *ParquetOutput) OutputChan() <-chan event.Event {
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	output_parquet "github.com/blinklabs-io/adder/output/parquet"
+)
+
+const mainnetNetworkMagic = 764824073
+
+func newBlockEvent(slot uint64) event.Event {
+	return event.New(
+		"chainsync.block",
+		time.Unix(1700000000, 0).UTC(),
+		chainsync.BlockContext{
+			BlockNumber:  slot / 20,
+			SlotNumber:   slot,
+			NetworkMagic: mainnetNetworkMagic,
+			Era:          "Conway",
+		},
+		chainsync.BlockEvent{
+			BlockHash:        "abcd",
+			IssuerVkey:       "ef01",
+			BlockBodySize:    1024,
+			TransactionCount: 3,
+		},
+	)
+}
+
+// openParquetFiles returns the finalized Parquet files under the directory
+func openParquetFiles(t *testing.T, dir string) map[string]*parquet.File {
+	ret := map[string]*parquet.File{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if !strings.HasSuffix(path, ".parquet") {
+			t.Fatalf("unexpected file: %s", path)
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		t.Cleanup(func() { f.Close() })
+		pf, err := parquet.OpenFile(f, info.Size())
+		if err != nil {
+			return err
+		}
+		ret[path] = pf
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error reading files: %s", err)
+	}
+	return ret
+}
+
+func TestParquetOutputBlocks(t *testing.T) {
+	dir := t.TempDir()
+	p := output_parquet.New(output_parquet.WithDirectory(dir))
+	assert.NoError(t, p.Start())
+	for i := 0; i < 5; i++ {
+		// Slots in epoch 508
+		p.InputChan() <- newBlockEvent(134092810 + uint64(i)*20)
+	}
+	assert.NoError(t, p.Stop())
+
+	files := openParquetFiles(t, dir)
+	if !assert.Len(t, files, 1) {
+		return
+	}
+	for path, f := range files {
+		assert.Equal(
+			t,
+			filepath.Join(dir, "chainsync.block", "epoch=508"),
+			filepath.Dir(path),
+		)
+		assert.Equal(t, int64(5), f.NumRows())
+		columns := []string{}
+		for _, field := range f.Schema().Fields() {
+			columns = append(columns, field.Name())
+		}
+		assert.Equal(
+			t,
+			[]string{
+				"timestamp",
+				"slot",
+				"block_number",
+				"block_hash",
+				"era",
+				"issuer_vkey",
+				"block_body_size",
+				"transaction_count",
+			},
+			columns,
+		)
+	}
+}
+
+func TestParquetOutputRotation(t *testing.T) {
+	dir := t.TempDir()
+	p := output_parquet.New(
+		output_parquet.WithDirectory(dir),
+		output_parquet.WithMaxRows(2),
+	)
+	assert.NoError(t, p.Start())
+	for i := 0; i < 3; i++ {
+		p.InputChan() <- newBlockEvent(134092810 + uint64(i)*20)
+	}
+	// A rollback finalizes the open file before it is written
+	p.InputChan() <- event.New(
+		"chainsync.rollback",
+		time.Now(),
+		nil,
+		chainsync.RollbackEvent{BlockHash: "abcd", SlotNumber: 134092810},
+	)
+	assert.NoError(t, p.Stop())
+
+	rows := map[string][]int64{}
+	for path, f := range openParquetFiles(t, dir) {
+		rel, err := filepath.Rel(dir, filepath.Dir(path))
+		assert.NoError(t, err)
+		rows[rel] = append(rows[rel], f.NumRows())
+	}
+	blockRows := rows[filepath.Join("chainsync.block", "epoch=508")]
+	assert.ElementsMatch(t, []int64{2, 1}, blockRows)
+	assert.Equal(
+		t,
+		[]int64{1},
+		rows[filepath.Join("chainsync.rollback", "epoch=508")],
+	)
+}
@@ -0,0 +1,76 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"time"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	directory      string
+	maxRows        uint
+	rotateInterval uint
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeOutput,
+			Name:               "parquet",
+			Description:        "write events to Parquet files partitioned by event type and epoch",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "directory",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the base directory to write files to",
+					DefaultValue: "parquet",
+					Dest:         &(cmdlineOptions.directory),
+				},
+				{
+					Name:         "max-rows",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the number of rows at which a file is rotated (0 to disable)",
+					DefaultValue: uint(100000),
+					Dest:         &(cmdlineOptions.maxRows),
+				},
+				{
+					Name:         "rotate-interval",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies how long in seconds a file is kept open before it is rotated (0 to disable)",
+					DefaultValue: uint(3600),
+					Dest:         &(cmdlineOptions.rotateInterval),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "output.parquet"),
+		),
+		WithDirectory(cmdlineOptions.directory),
+		WithMaxRows(int(cmdlineOptions.maxRows)),
+		WithRotateInterval(
+			time.Duration(cmdlineOptions.rotateInterval)*time.Second,
+		),
+	)
+	return p
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+)
+
+type blockRow struct {
+	Timestamp        time.Time `parquet:"timestamp"`
+	Slot             uint64    `parquet:"slot"`
+	BlockNumber      uint64    `parquet:"block_number"`
+	BlockHash        string    `parquet:"block_hash"`
+	Era              string    `parquet:"era"`
+	IssuerVkey       string    `parquet:"issuer_vkey"`
+	BlockBodySize    uint64    `parquet:"block_body_size"`
+	TransactionCount uint64    `parquet:"transaction_count"`
+}
+
+type transactionRow struct {
+	Timestamp       time.Time `parquet:"timestamp"`
+	Slot            uint64    `parquet:"slot"`
+	BlockNumber     uint64    `parquet:"block_number"`
+	BlockHash       string    `parquet:"block_hash"`
+	TransactionHash string    `parquet:"transaction_hash"`
+	TransactionIdx  uint32    `parquet:"transaction_idx"`
+	Era             string    `parquet:"era"`
+	Fee             uint64    `parquet:"fee"`
+	TTL             uint64    `parquet:"ttl"`
+	InputCount      uint32    `parquet:"input_count"`
+	OutputCount     uint32    `parquet:"output_count"`
+}
+
+type rollbackRow struct {
+	Timestamp time.Time `parquet:"timestamp"`
+	Slot      uint64    `parquet:"slot"`
+	BlockHash string    `parquet:"block_hash"`
+}
+
+func newBlockRow(
+	evt event.Event,
+	ctx chainsync.BlockContext,
+	be chainsync.BlockEvent,
+) *blockRow {
+	return &blockRow{
+		Timestamp:        evt.Timestamp,
+		Slot:             ctx.SlotNumber,
+		BlockNumber:      ctx.BlockNumber,
+		BlockHash:        be.BlockHash,
+		Era:              ctx.Era,
+		IssuerVkey:       be.IssuerVkey,
+		BlockBodySize:    be.BlockBodySize,
+		TransactionCount: be.TransactionCount,
+	}
+}
+
+func newTransactionRow(
+	evt event.Event,
+	ctx chainsync.TransactionContext,
+	te chainsync.TransactionEvent,
+) *transactionRow {
+	return &transactionRow{
+		Timestamp:       evt.Timestamp,
+		Slot:            ctx.SlotNumber,
+		BlockNumber:     ctx.BlockNumber,
+		BlockHash:       te.BlockHash,
+		TransactionHash: ctx.TransactionHash,
+		TransactionIdx:  ctx.TransactionIdx,
+		Era:             ctx.Era,
+		Fee:             te.Fee,
+		TTL:             te.TTL,
+		InputCount:      uint32(len(te.Inputs)),
+		OutputCount:     uint32(len(te.Outputs)),
+	}
+}
+
+func newRollbackRow(
+	evt event.Event,
+	re chainsync.RollbackEvent,
+) *rollbackRow {
+	return &rollbackRow{
+		Timestamp: evt.Timestamp,
+		Slot:      re.SlotNumber,
+		BlockHash: re.BlockHash,
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"time"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	dsn           string
+	tableName     string
+	batchSize     uint
+	flushInterval uint
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeOutput,
+			Name:               "postgres",
+			Description:        "insert events into a PostgreSQL table",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "dsn",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the database connection string",
+					DefaultValue: "postgres://localhost:5432/adder?sslmode=disable",
+					Dest:         &(cmdlineOptions.dsn),
+				},
+				{
+					Name:         "table-name",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the table to insert events into",
+					DefaultValue: "events",
+					Dest:         &(cmdlineOptions.tableName),
+				},
+				{
+					Name:         "batch-size",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the number of events at which a batch is inserted early",
+					DefaultValue: uint(100),
+					Dest:         &(cmdlineOptions.batchSize),
+				},
+				{
+					Name:         "flush-interval",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies how often in milliseconds batched events are inserted",
+					DefaultValue: uint(1000),
+					Dest:         &(cmdlineOptions.flushInterval),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	if cmdlineOptions.flushInterval == 0 {
+		panic("flush interval must be greater than 0")
+	}
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "output.postgres"),
+		),
+		WithDSN(cmdlineOptions.dsn),
+		WithTableName(cmdlineOptions.tableName),
+		WithBatchSize(int(cmdlineOptions.batchSize)),
+		WithFlushInterval(
+			time.Duration(cmdlineOptions.flushInterval)*time.Millisecond,
+		),
+	)
+	return p
+}
@@ -0,0 +1,212 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/plugin"
+
+	"github.com/lib/pq"
+)
+
+type PostgresOutput struct {
+	errorChan     chan error
+	eventChan     chan event.Event
+	doneChan      chan error
+	logger        plugin.Logger
+	dsn           string
+	tableName     string
+	batchSize     int
+	flushInterval time.Duration
+	db            *sql.DB
+	batch         []event.Event
+}
+
+func New(options ...PostgresOptionFunc) *PostgresOutput {
+	p := &PostgresOutput{
+		errorChan:     make(chan error),
+		eventChan:     make(chan event.Event, 10),
+		doneChan:      make(chan error, 1),
+		dsn:           "postgres://localhost:5432/adder?sslmode=disable",
+		tableName:     "events",
+		batchSize:     100,
+		flushInterval: 1 * time.Second,
+	}
+	for _, option := range options {
+		option(p)
+	}
+	return p
+}
+
+// Start the postgres output. The events table is created if it doesn't already exist
+func (p *PostgresOutput) Start() error {
+	if p.db == nil {
+		db, err := sql.Open("postgres", p.dsn)
+		if err != nil {
+			return fmt.Errorf("failed to open database: %w", err)
+		}
+		p.db = db
+	}
+	if err := p.db.Ping(); err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if _, err := p.db.Exec(p.createTableQuery()); err != nil {
+		return fmt.Errorf("failed to create table %s: %w", p.tableName, err)
+	}
+	go func() {
+		ticker := time.NewTicker(p.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.flush(); err != nil {
+					p.errorChan <- err
+				}
+			case evt, ok := <-p.eventChan:
+				// Channel has been closed, which means we're shutting down
+				if !ok {
+					err := p.flush()
+					if closeErr := p.db.Close(); err == nil {
+						err = closeErr
+					}
+					p.doneChan <- err
+					return
+				}
+				p.batch = append(p.batch, evt)
+				if len(p.batch) >= p.batchSize {
+					if err := p.flush(); err != nil {
+						p.errorChan <- err
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *PostgresOutput) createTableQuery() string {
+	return fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s (
+	id SERIAL PRIMARY KEY,
+	type TEXT NOT NULL,
+	timestamp TIMESTAMPTZ NOT NULL,
+	slot BIGINT,
+	block_number BIGINT,
+	payload JSONB
+)`,
+		pq.QuoteIdentifier(p.tableName),
+	)
+}
+
+func (p *PostgresOutput) insertQuery() string {
+	return fmt.Sprintf(
+		"INSERT INTO %s (type, timestamp, slot, block_number, payload) VALUES ($1, $2, $3, $4, $5)",
+		pq.QuoteIdentifier(p.tableName),
+	)
+}
+
+// flush inserts the batched events in a single transaction. The batch is discarded if the insert fails, since
+// retrying a batch that the database rejected would fail the same way
+func (p *PostgresOutput) flush() error {
+	if len(p.batch) == 0 {
+		return nil
+	}
+	batch := p.batch
+	p.batch = nil
+	tx, err := p.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := p.insertBatch(tx, batch); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit %d event(s): %w", len(batch), err)
+	}
+	return nil
+}
+
+func (p *PostgresOutput) insertBatch(tx *sql.Tx, batch []event.Event) error {
+	stmt, err := tx.Prepare(p.insertQuery())
+	if err != nil {
+		return fmt.Errorf("failed to prepare insert: %w", err)
+	}
+	defer stmt.Close()
+	for _, evt := range batch {
+		payload, err := json.Marshal(evt.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode event payload: %w", err)
+		}
+		slot, blockNumber := eventPosition(evt)
+		if _, err := stmt.Exec(evt.Type, evt.Timestamp, slot, blockNumber, string(payload)); err != nil {
+			return fmt.Errorf("failed to insert event: %w", err)
+		}
+	}
+	return nil
+}
+
+// eventPosition returns the slot and block number for the event, where known
+func eventPosition(evt event.Event) (sql.NullInt64, sql.NullInt64) {
+	var slot, blockNumber sql.NullInt64
+	setPosition := func(slotNumber, blockNum uint64) {
+		slot = sql.NullInt64{Int64: int64(slotNumber), Valid: true}
+		blockNumber = sql.NullInt64{Int64: int64(blockNum), Valid: true}
+	}
+	switch ctx := evt.Context.(type) {
+	case chainsync.BlockContext:
+		setPosition(ctx.SlotNumber, ctx.BlockNumber)
+	case chainsync.TransactionContext:
+		setPosition(ctx.SlotNumber, ctx.BlockNumber)
+	case chainsync.CertificateContext:
+		setPosition(ctx.SlotNumber, ctx.BlockNumber)
+	}
+	switch payload := evt.Payload.(type) {
+	case chainsync.RollbackEvent:
+		slot = sql.NullInt64{Int64: int64(payload.SlotNumber), Valid: true}
+	case chainsync.ResetEvent:
+		slot = sql.NullInt64{Int64: int64(payload.SlotNumber), Valid: true}
+	}
+	return slot, blockNumber
+}
+
+// Stop the postgres output. Any batched events are inserted before returning
+func (p *PostgresOutput) Stop() error {
+	close(p.eventChan)
+	err := <-p.doneChan
+	close(p.errorChan)
+	return err
+}
+
+// ErrorChan returns the plugin's error channel
+func (p *PostgresOutput) ErrorChan() chan error {
+	return p.errorChan
+}
+
+// InputChan returns the input event channel
+func (p *PostgresOutput) InputChan() chan<- event.Event {
+	return p.eventChan
+}
+
+// OutputChan always returns nil
+func (p *PostgresOutput) OutputChan() <-chan event.Event {
+	return nil
+}
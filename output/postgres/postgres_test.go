@@ -0,0 +1,116 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package postgres_test
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/output/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	createTableRegexp = regexp.QuoteMeta(`CREATE TABLE IF NOT EXISTS "events"`)
+	insertRegexp      = regexp.QuoteMeta(`INSERT INTO "events" (type, timestamp, slot, block_number, payload)`)
+)
+
+func newMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("unexpected error creating mock database: %s", err)
+	}
+	mock.ExpectPing()
+	mock.ExpectExec(createTableRegexp).WillReturnResult(sqlmock.NewResult(0, 0))
+	return db, mock
+}
+
+func TestBatchInsert(t *testing.T) {
+	db, mock := newMockDB(t)
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// A full batch is inserted right away, and the remainder when stopping
+	mock.ExpectBegin()
+	prepare := mock.ExpectPrepare(insertRegexp)
+	prepare.ExpectExec().
+		WithArgs("chainsync.block", timestamp, int64(3456), int64(12), `{"blockBodySize":0,"issuerVkey":"","blockHash":"abcd","transactionCount":0,"totalFees":0,"totalOutput":0,"scriptTransactionCount":0,"governanceTransactionCount":0}`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	prepare.ExpectExec().
+		WithArgs("chainsync.rollback", timestamp, int64(3400), nil, `{"blockHash":"ef01","slotNumber":3400}`).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectPrepare(insertRegexp).ExpectExec().
+		WithArgs("chainsync.reset", timestamp, int64(3500), nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	p := postgres.New(
+		postgres.WithDB(db),
+		postgres.WithBatchSize(2),
+		postgres.WithFlushInterval(time.Hour),
+	)
+	assert.NoError(t, p.Start())
+	p.InputChan() <- event.New(
+		"chainsync.block",
+		timestamp,
+		chainsync.BlockContext{BlockNumber: 12, SlotNumber: 3456},
+		chainsync.BlockEvent{BlockHash: "abcd"},
+	)
+	p.InputChan() <- event.New(
+		"chainsync.rollback",
+		timestamp,
+		nil,
+		chainsync.RollbackEvent{BlockHash: "ef01", SlotNumber: 3400},
+	)
+	p.InputChan() <- event.New(
+		"chainsync.reset",
+		timestamp,
+		nil,
+		chainsync.ResetEvent{SlotNumber: 3500},
+	)
+	assert.NoError(t, p.Stop())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInsertError(t *testing.T) {
+	db, mock := newMockDB(t)
+	mock.ExpectBegin()
+	mock.ExpectPrepare(insertRegexp).ExpectExec().
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+	mock.ExpectClose()
+
+	p := postgres.New(
+		postgres.WithDB(db),
+		postgres.WithFlushInterval(10*time.Millisecond),
+	)
+	assert.NoError(t, p.Start())
+	p.InputChan() <- event.New("chainsync.rollback", time.Now(), nil, chainsync.RollbackEvent{})
+	select {
+	case err := <-p.ErrorChan():
+		assert.ErrorContains(t, err, "connection reset")
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for error")
+	}
+	assert.NoError(t, p.Stop())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -80,19 +80,15 @@ func (p *PushOutput) Start() error {
 				return
 			}
 
-			switch evt.Type {
+			eventType := event.BaseType(evt.Type)
+			if !chainsync.HasTypedPayload(evt) {
+				// Fall back to the generic message for payloads without the chainsync types
+				eventType = ""
+			}
+			switch eventType {
 			case "chainsync.block":
-				payload := evt.Payload
-				if payload == nil {
-					panic(fmt.Errorf("ERROR: %v", payload))
-				}
-				context := evt.Context
-				if context == nil {
-					panic(fmt.Errorf("ERROR: %v", context))
-				}
-
-				be := payload.(chainsync.BlockEvent)
-				bc := context.(chainsync.BlockContext)
+				be := evt.Payload.(chainsync.BlockEvent)
+				bc := evt.Context.(chainsync.BlockContext)
 				fmt.Println("Adder")
 				fmt.Printf(
 					"New Block!\nBlockNumber: %d, SlotNumber: %d\nHash: %s",
@@ -114,29 +110,15 @@ func (p *PushOutput) Start() error {
 				p.processFcmNotifications(title, body)
 
 			case "chainsync.rollback":
-				payload := evt.Payload
-				if payload == nil {
-					panic(fmt.Errorf("ERROR: %v", payload))
-				}
-
-				re := payload.(chainsync.RollbackEvent)
+				re := evt.Payload.(chainsync.RollbackEvent)
 				fmt.Println("Adder")
 				fmt.Printf("Rollback!\nSlotNumber: %d\nBlockHash: %s",
 					re.SlotNumber,
 					re.BlockHash,
 				)
 			case "chainsync.transaction":
-				payload := evt.Payload
-				if payload == nil {
-					panic(fmt.Errorf("ERROR: %v", payload))
-				}
-				context := evt.Context
-				if context == nil {
-					panic(fmt.Errorf("ERROR: %v", context))
-				}
-
-				te := payload.(chainsync.TransactionEvent)
-				tc := context.(chainsync.TransactionContext)
+				te := evt.Payload.(chainsync.TransactionEvent)
+				tc := evt.Context.(chainsync.TransactionContext)
 
 				// Create notification message
 				title := "Adder"
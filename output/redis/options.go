@@ -0,0 +1,62 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import "github.com/blinklabs-io/adder/plugin"
+
+type RedisOptionFunc func(*RedisOutput)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) RedisOptionFunc {
+	return func(o *RedisOutput) {
+		o.logger = logger
+	}
+}
+
+// WithAddr specifies the address of the Redis server in the form "host:port"
+func WithAddr(addr string) RedisOptionFunc {
+	return func(o *RedisOutput) {
+		o.addr = addr
+	}
+}
+
+// WithPassword specifies the password to use for authentication
+func WithPassword(password string) RedisOptionFunc {
+	return func(o *RedisOutput) {
+		o.password = password
+	}
+}
+
+// WithDB specifies the Redis database number to use
+func WithDB(db int) RedisOptionFunc {
+	return func(o *RedisOutput) {
+		o.db = db
+	}
+}
+
+// WithStreamKey specifies the key of the stream events are added to
+func WithStreamKey(streamKey string) RedisOptionFunc {
+	return func(o *RedisOutput) {
+		o.streamKey = streamKey
+	}
+}
+
+// WithMaxLen specifies the approximate maximum number of entries to keep in the stream. The default of 0 keeps
+// all entries
+func WithMaxLen(maxLen int64) RedisOptionFunc {
+	return func(o *RedisOutput) {
+		o.maxLen = maxLen
+	}
+}
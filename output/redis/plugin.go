@@ -0,0 +1,90 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	addr      string
+	password  string
+	db        uint
+	streamKey string
+	maxLen    uint
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeOutput,
+			Name:               "redis",
+			Description:        "add events to a Redis stream",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "addr",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the address of the Redis server in the form 'host:port'",
+					DefaultValue: "localhost:6379",
+					Dest:         &(cmdlineOptions.addr),
+				},
+				{
+					Name:         "password",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the password to use for authentication",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.password),
+				},
+				{
+					Name:         "db",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the Redis database number to use",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.db),
+				},
+				{
+					Name:         "stream-key",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the key of the stream events are added to",
+					DefaultValue: "cardano",
+					Dest:         &(cmdlineOptions.streamKey),
+				},
+				{
+					Name:         "max-len",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the approximate maximum number of entries to keep in the stream (0 to keep all)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.maxLen),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "output.redis"),
+		),
+		WithAddr(cmdlineOptions.addr),
+		WithPassword(cmdlineOptions.password),
+		WithDB(int(cmdlineOptions.db)),
+		WithStreamKey(cmdlineOptions.streamKey),
+		WithMaxLen(int64(cmdlineOptions.maxLen)),
+	)
+	return p
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+type RedisOutput struct {
+	errorChan chan error
+	eventChan chan event.Event
+	doneChan  chan struct{}
+	logger    plugin.Logger
+	addr      string
+	password  string
+	db        int
+	streamKey string
+	maxLen    int64
+	client    *redis.Client
+}
+
+func New(options ...RedisOptionFunc) *RedisOutput {
+	r := &RedisOutput{
+		errorChan: make(chan error),
+		eventChan: make(chan event.Event, 10),
+		doneChan:  make(chan struct{}),
+		addr:      "localhost:6379",
+		streamKey: "cardano",
+	}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Start the Redis output
+func (r *RedisOutput) Start() error {
+	// The client maintains a pool of connections to the server
+	r.client = redis.NewClient(
+		&redis.Options{
+			Addr:     r.addr,
+			Password: r.password,
+			DB:       r.db,
+		},
+	)
+	if err := r.client.Ping(context.Background()).Err(); err != nil {
+		r.client.Close()
+		return fmt.Errorf("failed to connect to Redis server: %w", err)
+	}
+	if r.logger != nil {
+		r.logger.Infof("connected to Redis server at %s", r.addr)
+	}
+	go r.publishLoop()
+	return nil
+}
+
+func (r *RedisOutput) publishLoop() {
+	defer close(r.doneChan)
+	for {
+		evt, ok := <-r.eventChan
+		// Channel has been closed, which means we're shutting down
+		if !ok {
+			return
+		}
+		values, err := streamValues(evt)
+		if err != nil {
+			if r.logger != nil {
+				r.logger.Errorf("failed to encode event: %s", err)
+			}
+			continue
+		}
+		args := &redis.XAddArgs{
+			Stream: r.streamKey,
+			Values: values,
+		}
+		if r.maxLen > 0 {
+			// Approximate trimming is much cheaper for the server than exact trimming
+			args.MaxLen = r.maxLen
+			args.Approx = true
+		}
+		if err := r.client.XAdd(context.Background(), args).Err(); err != nil {
+			r.errorChan <- fmt.Errorf("failed to add event to Redis stream %s: %w", r.streamKey, err)
+		}
+	}
+}
+
+// streamValues returns the fields of the stream entry for an event
+func streamValues(evt event.Event) (map[string]any, error) {
+	payload, err := json.Marshal(evt.Payload)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]any{
+		"type":      evt.Type,
+		"timestamp": evt.Timestamp.Format(time.RFC3339Nano),
+		"payload":   string(payload),
+	}
+	if evt.Context != nil {
+		context, err := json.Marshal(evt.Context)
+		if err != nil {
+			return nil, err
+		}
+		values["context"] = string(context)
+	}
+	return values, nil
+}
+
+// Stop the Redis output, waiting for any in-flight events to be added before closing the client
+func (r *RedisOutput) Stop() error {
+	close(r.eventChan)
+	var err error
+	if r.client != nil {
+		<-r.doneChan
+		err = r.client.Close()
+	}
+	close(r.errorChan)
+	return err
+}
+
+// ErrorChan returns the output error channel
+func (r *RedisOutput) ErrorChan() chan error {
+	return r.errorChan
+}
+
+// InputChan returns the input event channel
+func (r *RedisOutput) InputChan() chan<- event.Event {
+	return r.eventChan
+}
+
+// OutputChan always returns nil
+func (r *RedisOutput) OutputChan() <-chan event.Event {
+	return nil
+}
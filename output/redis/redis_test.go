@@ -0,0 +1,68 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redis_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/output/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRedisStreamEntry(t *testing.T) {
+	s := miniredis.RunT(t)
+	r := redis.New(
+		redis.WithLogger(zap.NewNop().Sugar()),
+		redis.WithAddr(s.Addr()),
+		redis.WithStreamKey("test-events"),
+		redis.WithMaxLen(100),
+	)
+	require.NoError(t, r.Start())
+	ts := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	r.InputChan() <- event.New(
+		"chainsync.block",
+		ts,
+		chainsync.BlockContext{BlockNumber: 123, SlotNumber: 456},
+		chainsync.BlockEvent{BlockHash: "abcd"},
+	)
+	// Stop waits for the pending event to be added
+	require.NoError(t, r.Stop())
+
+	entries, err := s.Stream("test-events")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	values := map[string]string{}
+	for i := 0; i+1 < len(entries[0].Values); i += 2 {
+		values[entries[0].Values[i]] = entries[0].Values[i+1]
+	}
+	assert.Equal(t, "chainsync.block", values["type"])
+	assert.Equal(t, ts.Format(time.RFC3339Nano), values["timestamp"])
+	assert.Contains(t, values["payload"], `"blockHash":"abcd"`)
+	assert.Contains(t, values["context"], `"slotNumber":456`)
+}
+
+func TestRedisStartError(t *testing.T) {
+	s := miniredis.RunT(t)
+	addr := s.Addr()
+	s.Close()
+	r := redis.New(redis.WithAddr(addr))
+	assert.Error(t, r.Start())
+}
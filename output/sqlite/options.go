@@ -0,0 +1,60 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"time"
+
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+type SqliteOptionFunc func(*SqliteOutput)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) SqliteOptionFunc {
+	return func(o *SqliteOutput) {
+		o.logger = logger
+	}
+}
+
+// WithPath specifies the path of the SQLite database file, which is created if it doesn't exist. The default is
+// adder.db
+func WithPath(path string) SqliteOptionFunc {
+	return func(o *SqliteOutput) {
+		o.path = path
+	}
+}
+
+// WithBatchSize specifies the number of events at which the batch is written early, before the flush interval
+func WithBatchSize(batchSize int) SqliteOptionFunc {
+	return func(o *SqliteOutput) {
+		o.batchSize = batchSize
+	}
+}
+
+// WithFlushInterval specifies how often batched events are written
+func WithFlushInterval(flushInterval time.Duration) SqliteOptionFunc {
+	return func(o *SqliteOutput) {
+		o.flushInterval = flushInterval
+	}
+}
+
+// WithWAL specifies whether to use write-ahead logging, which allows the database to be queried while events are
+// being written. It's enabled by default
+func WithWAL(wal bool) SqliteOptionFunc {
+	return func(o *SqliteOutput) {
+		o.wal = wal
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"time"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	path          string
+	batchSize     uint
+	flushInterval uint
+	wal           bool
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeOutput,
+			Name:               "sqlite",
+			Description:        "write events to queryable tables in a local SQLite database",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "path",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the path of the database file",
+					DefaultValue: "adder.db",
+					Dest:         &(cmdlineOptions.path),
+				},
+				{
+					Name:         "batch-size",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the number of events at which a batch is written early",
+					DefaultValue: uint(100),
+					Dest:         &(cmdlineOptions.batchSize),
+				},
+				{
+					Name:         "flush-interval",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies how often in milliseconds batched events are written",
+					DefaultValue: uint(1000),
+					Dest:         &(cmdlineOptions.flushInterval),
+				},
+				{
+					Name:         "wal",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "use write-ahead logging so the database can be queried while events are written",
+					DefaultValue: true,
+					Dest:         &(cmdlineOptions.wal),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	if cmdlineOptions.flushInterval == 0 {
+		panic("flush interval must be greater than 0")
+	}
+	p := New(
+		WithLogger(
+			logging.GetLogger().With("plugin", "output.sqlite"),
+		),
+		WithPath(cmdlineOptions.path),
+		WithBatchSize(int(cmdlineOptions.batchSize)),
+		WithFlushInterval(
+			time.Duration(cmdlineOptions.flushInterval)*time.Millisecond,
+		),
+		WithWAL(cmdlineOptions.wal),
+	)
+	return p
+}
@@ -0,0 +1,344 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/plugin"
+
+	// Pure Go SQLite driver, since release builds don't use cgo
+	_ "modernc.org/sqlite"
+)
+
+// Statements to create the tables and indexes, which are run on every start
+var schemaStatements = []string{
+	`CREATE TABLE IF NOT EXISTS blocks (
+	hash TEXT PRIMARY KEY,
+	slot INTEGER NOT NULL,
+	block_number INTEGER NOT NULL,
+	era TEXT NOT NULL,
+	issuer_vkey TEXT NOT NULL,
+	body_size INTEGER NOT NULL,
+	transaction_count INTEGER NOT NULL,
+	total_fees INTEGER NOT NULL,
+	total_output INTEGER NOT NULL,
+	timestamp TIMESTAMP NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS blocks_slot ON blocks (slot)`,
+	`CREATE TABLE IF NOT EXISTS transactions (
+	hash TEXT PRIMARY KEY,
+	block_hash TEXT NOT NULL,
+	slot INTEGER NOT NULL,
+	block_number INTEGER NOT NULL,
+	tx_index INTEGER NOT NULL,
+	fee INTEGER NOT NULL,
+	input_count INTEGER NOT NULL,
+	output_count INTEGER NOT NULL,
+	payload TEXT NOT NULL,
+	timestamp TIMESTAMP NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS transactions_slot ON transactions (slot)`,
+	`CREATE INDEX IF NOT EXISTS transactions_block_hash ON transactions (block_hash)`,
+	`CREATE TABLE IF NOT EXISTS events (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	type TEXT NOT NULL,
+	slot INTEGER,
+	block_hash TEXT,
+	payload TEXT NOT NULL,
+	timestamp TIMESTAMP NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS events_slot ON events (slot)`,
+	`CREATE INDEX IF NOT EXISTS events_type ON events (type)`,
+}
+
+const (
+	insertBlockQuery = `INSERT OR REPLACE INTO blocks (hash, slot, block_number, era, issuer_vkey, body_size, transaction_count, total_fees, total_output, timestamp)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	insertTransactionQuery = `INSERT OR REPLACE INTO transactions (hash, block_hash, slot, block_number, tx_index, fee, input_count, output_count, payload, timestamp)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	insertEventQuery = `INSERT INTO events (type, slot, block_hash, payload, timestamp) VALUES (?, ?, ?, ?, ?)`
+	// Blocks and transactions after the rollback point are no longer on the chain
+	rollbackBlocksQuery       = `DELETE FROM blocks WHERE slot > ?`
+	rollbackTransactionsQuery = `DELETE FROM transactions WHERE slot > ?`
+)
+
+type SqliteOutput struct {
+	errorChan     chan error
+	eventChan     chan event.Event
+	doneChan      chan error
+	logger        plugin.Logger
+	path          string
+	batchSize     int
+	flushInterval time.Duration
+	wal           bool
+	db            *sql.DB
+	batch         []event.Event
+}
+
+func New(options ...SqliteOptionFunc) *SqliteOutput {
+	s := &SqliteOutput{
+		errorChan:     make(chan error),
+		eventChan:     make(chan event.Event, 10),
+		doneChan:      make(chan error, 1),
+		path:          "adder.db",
+		batchSize:     100,
+		flushInterval: 1 * time.Second,
+		wal:           true,
+	}
+	for _, option := range options {
+		option(s)
+	}
+	return s
+}
+
+// Start the SQLite output. The database file and its tables are created if they don't already exist
+func (s *SqliteOutput) Start() error {
+	db, err := sql.Open("sqlite", s.path)
+	if err != nil {
+		return fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLite only supports a single writer, so there's no benefit to more connections
+	db.SetMaxOpenConns(1)
+	if s.wal {
+		// WAL mode allows other processes to query the database while events are being written
+		if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+			db.Close()
+			return fmt.Errorf("failed to enable WAL mode: %w", err)
+		}
+	}
+	for _, stmt := range schemaStatements {
+		if _, err := db.Exec(stmt); err != nil {
+			db.Close()
+			return fmt.Errorf("failed to create schema: %w", err)
+		}
+	}
+	s.db = db
+	go func() {
+		ticker := time.NewTicker(s.flushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.flush(); err != nil {
+					s.errorChan <- err
+				}
+			case evt, ok := <-s.eventChan:
+				// Channel has been closed, which means we're shutting down
+				if !ok {
+					err := s.flush()
+					if closeErr := s.db.Close(); err == nil {
+						err = closeErr
+					}
+					s.doneChan <- err
+					return
+				}
+				s.batch = append(s.batch, evt)
+				if len(s.batch) >= s.batchSize {
+					if err := s.flush(); err != nil {
+						s.errorChan <- err
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// flush writes the batched events in a single transaction. The batch is discarded if the write fails, since
+// retrying a batch that the database rejected would fail the same way
+func (s *SqliteOutput) flush() error {
+	if len(s.batch) == 0 {
+		return nil
+	}
+	batch := s.batch
+	s.batch = nil
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := s.writeBatch(tx, batch); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit %d event(s): %w", len(batch), err)
+	}
+	return nil
+}
+
+// batchStatements holds the prepared statements used to write a batch
+type batchStatements struct {
+	insertBlock          *sql.Stmt
+	insertTransaction    *sql.Stmt
+	insertEvent          *sql.Stmt
+	rollbackBlocks       *sql.Stmt
+	rollbackTransactions *sql.Stmt
+}
+
+func prepareBatchStatements(tx *sql.Tx) (*batchStatements, error) {
+	stmts := &batchStatements{}
+	for _, tmpStmt := range []struct {
+		dest  **sql.Stmt
+		query string
+	}{
+		{&stmts.insertBlock, insertBlockQuery},
+		{&stmts.insertTransaction, insertTransactionQuery},
+		{&stmts.insertEvent, insertEventQuery},
+		{&stmts.rollbackBlocks, rollbackBlocksQuery},
+		{&stmts.rollbackTransactions, rollbackTransactionsQuery},
+	} {
+		stmt, err := tx.Prepare(tmpStmt.query)
+		if err != nil {
+			stmts.close()
+			return nil, fmt.Errorf("failed to prepare statement: %w", err)
+		}
+		*tmpStmt.dest = stmt
+	}
+	return stmts, nil
+}
+
+func (b *batchStatements) close() {
+	for _, stmt := range []*sql.Stmt{
+		b.insertBlock,
+		b.insertTransaction,
+		b.insertEvent,
+		b.rollbackBlocks,
+		b.rollbackTransactions,
+	} {
+		if stmt != nil {
+			stmt.Close()
+		}
+	}
+}
+
+func (s *SqliteOutput) writeBatch(tx *sql.Tx, batch []event.Event) error {
+	stmts, err := prepareBatchStatements(tx)
+	if err != nil {
+		return err
+	}
+	defer stmts.close()
+	for _, evt := range batch {
+		if err := writeEvent(stmts, evt); err != nil {
+			return fmt.Errorf("failed to write %s event: %w", evt.Type, err)
+		}
+	}
+	return nil
+}
+
+// writeEvent writes block and transaction events to their own tables and any other events to the events table.
+// A rollback also removes the blocks and transactions after the rollback point
+func writeEvent(stmts *batchStatements, evt event.Event) error {
+	switch payload := evt.Payload.(type) {
+	case chainsync.BlockEvent:
+		ctx, _ := evt.Context.(chainsync.BlockContext)
+		_, err := stmts.insertBlock.Exec(
+			payload.BlockHash,
+			ctx.SlotNumber,
+			ctx.BlockNumber,
+			ctx.Era,
+			payload.IssuerVkey,
+			payload.BlockBodySize,
+			payload.TransactionCount,
+			payload.TotalFees,
+			payload.TotalOutput,
+			evt.Timestamp,
+		)
+		return err
+	case chainsync.TransactionEvent:
+		ctx, _ := evt.Context.(chainsync.TransactionContext)
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		_, err = stmts.insertTransaction.Exec(
+			ctx.TransactionHash,
+			payload.BlockHash,
+			ctx.SlotNumber,
+			ctx.BlockNumber,
+			ctx.TransactionIdx,
+			payload.Fee,
+			len(payload.Inputs),
+			len(payload.Outputs),
+			string(data),
+			evt.Timestamp,
+		)
+		return err
+	case chainsync.RollbackEvent:
+		if _, err := stmts.rollbackBlocks.Exec(payload.SlotNumber); err != nil {
+			return err
+		}
+		if _, err := stmts.rollbackTransactions.Exec(payload.SlotNumber); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(evt.Payload)
+	if err != nil {
+		return err
+	}
+	slot, blockHash := eventPosition(evt)
+	_, err = stmts.insertEvent.Exec(evt.Type, slot, blockHash, string(data), evt.Timestamp)
+	return err
+}
+
+// eventPosition returns the slot and block hash for events other than blocks and transactions, where known
+func eventPosition(evt event.Event) (sql.NullInt64, sql.NullString) {
+	var slot sql.NullInt64
+	var blockHash sql.NullString
+	switch ctx := evt.Context.(type) {
+	case chainsync.CertificateContext:
+		slot = sql.NullInt64{Int64: int64(ctx.SlotNumber), Valid: true}
+	}
+	switch payload := evt.Payload.(type) {
+	case chainsync.RollbackEvent:
+		slot = sql.NullInt64{Int64: int64(payload.SlotNumber), Valid: true}
+		blockHash = sql.NullString{String: payload.BlockHash, Valid: true}
+	case chainsync.ResetEvent:
+		slot = sql.NullInt64{Int64: int64(payload.SlotNumber), Valid: true}
+		blockHash = sql.NullString{String: payload.BlockHash, Valid: true}
+	}
+	return slot, blockHash
+}
+
+// Stop the SQLite output. Any batched events are written before returning
+func (s *SqliteOutput) Stop() error {
+	close(s.eventChan)
+	var err error
+	if s.db != nil {
+		err = <-s.doneChan
+	}
+	close(s.errorChan)
+	return err
+}
+
+// ErrorChan returns the plugin's error channel
+func (s *SqliteOutput) ErrorChan() chan error {
+	return s.errorChan
+}
+
+// InputChan returns the input event channel
+func (s *SqliteOutput) InputChan() chan<- event.Event {
+	return s.eventChan
+}
+
+// OutputChan always returns nil
+func (s *SqliteOutput) OutputChan() <-chan event.Event {
+	return nil
+}
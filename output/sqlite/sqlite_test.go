@@ -0,0 +1,108 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlite_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/output/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteEvents(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "adder.db")
+	s := sqlite.New(
+		sqlite.WithPath(dbPath),
+		sqlite.WithBatchSize(2),
+		sqlite.WithFlushInterval(time.Hour),
+	)
+	assert.NoError(t, s.Start())
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, evt := range []event.Event{
+		event.New(
+			"chainsync.block",
+			timestamp,
+			chainsync.BlockContext{BlockNumber: 12, SlotNumber: 3456, Era: "Conway"},
+			chainsync.BlockEvent{BlockHash: "abcd", TransactionCount: 1, TotalFees: 170000},
+		),
+		event.New(
+			"chainsync.transaction",
+			timestamp,
+			chainsync.TransactionContext{BlockNumber: 12, SlotNumber: 3456, TransactionHash: "deadbeef"},
+			chainsync.TransactionEvent{BlockHash: "abcd", Fee: 170000},
+		),
+		event.New(
+			"chainsync.block",
+			timestamp,
+			chainsync.BlockContext{BlockNumber: 13, SlotNumber: 3500, Era: "Conway"},
+			chainsync.BlockEvent{BlockHash: "ef01"},
+		),
+		// Removes the second block, and is written to the events table
+		event.New(
+			"chainsync.rollback",
+			timestamp,
+			nil,
+			chainsync.RollbackEvent{BlockHash: "abcd", SlotNumber: 3456},
+		),
+	} {
+		s.InputChan() <- evt
+	}
+	// The remainder of the batch is written when stopping
+	assert.NoError(t, s.Stop())
+
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("unexpected error opening database: %s", err)
+	}
+	defer db.Close()
+	var blockHash, era string
+	var blockNumber, totalFees uint64
+	assert.NoError(
+		t,
+		db.QueryRow("SELECT hash, block_number, era, total_fees FROM blocks WHERE slot = 3456").
+			Scan(&blockHash, &blockNumber, &era, &totalFees),
+	)
+	assert.Equal(t, "abcd", blockHash)
+	assert.Equal(t, uint64(12), blockNumber)
+	assert.Equal(t, "Conway", era)
+	assert.Equal(t, uint64(170000), totalFees)
+	var blockCount int
+	assert.NoError(t, db.QueryRow("SELECT COUNT(*) FROM blocks").Scan(&blockCount))
+	assert.Equal(t, 1, blockCount)
+	var txHash string
+	var fee uint64
+	assert.NoError(
+		t,
+		db.QueryRow("SELECT hash, fee FROM transactions WHERE block_hash = 'abcd'").Scan(&txHash, &fee),
+	)
+	assert.Equal(t, "deadbeef", txHash)
+	assert.Equal(t, uint64(170000), fee)
+	var eventType string
+	var slot uint64
+	assert.NoError(
+		t,
+		db.QueryRow("SELECT type, slot FROM events").Scan(&eventType, &slot),
+	)
+	assert.Equal(t, "chainsync.rollback", eventType)
+	assert.Equal(t, uint64(3456), slot)
+	var journalMode string
+	assert.NoError(t, db.QueryRow("PRAGMA journal_mode").Scan(&journalMode))
+	assert.Equal(t, "wal", journalMode)
+}
@@ -0,0 +1,93 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/gouroboros/ledger"
+)
+
+type assetQuantity struct {
+	name     string
+	quantity uint64
+}
+
+// assetSummary returns a summary of the native assets moved by a transaction, one per line, listing up to maxAssets
+// of them by descending quantity. The quantity moved for each asset is the larger of the amount paid to the outputs
+// and the amount spent from the resolved inputs, so burned assets are included when the inputs have been resolved.
+// An empty string is returned if the transaction doesn't move any native assets
+func assetSummary(te chainsync.TransactionEvent, maxAssets int) string {
+	paid := sumAssets(te.Outputs)
+	spent := sumAssets(te.ResolvedInputs)
+	for name, quantity := range spent {
+		paid[name] = max(paid[name], quantity)
+	}
+	if len(paid) == 0 {
+		return ""
+	}
+	assets := make([]assetQuantity, 0, len(paid))
+	for name, quantity := range paid {
+		assets = append(assets, assetQuantity{name: name, quantity: quantity})
+	}
+	sort.Slice(assets, func(i, j int) bool {
+		if assets[i].quantity != assets[j].quantity {
+			return assets[i].quantity > assets[j].quantity
+		}
+		return assets[i].name < assets[j].name
+	})
+	lines := []string{}
+	for idx, asset := range assets {
+		if idx == maxAssets {
+			lines = append(lines, fmt.Sprintf("and %d more", len(assets)-maxAssets))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s: %d", asset.name, asset.quantity))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sumAssets totals the native assets carried by the specified outputs, keyed by '<policy ID>.<asset name>'
+func sumAssets(outputs []ledger.TransactionOutput) map[string]uint64 {
+	ret := map[string]uint64{}
+	for _, output := range outputs {
+		assets := output.Assets()
+		if assets == nil {
+			continue
+		}
+		for _, policyId := range assets.Policies() {
+			for _, assetName := range assets.Assets(policyId) {
+				name := fmt.Sprintf("%s.%s", policyId.String(), displayAssetName(assetName))
+				ret[name] += assets.Asset(policyId, assetName)
+			}
+		}
+	}
+	return ret
+}
+
+// displayAssetName returns the asset name as text if it's printable, or hex-encoded otherwise
+func displayAssetName(assetName []byte) string {
+	for _, r := range string(assetName) {
+		if r == unicode.ReplacementChar || !unicode.IsPrint(r) {
+			return hex.EncodeToString(assetName)
+		}
+	}
+	return string(assetName)
+}
@@ -0,0 +1,51 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type webhookMetrics struct {
+	deliveries prometheus.Counter
+	failures   prometheus.Counter
+}
+
+func newWebhookMetrics() *webhookMetrics {
+	return &webhookMetrics{
+		deliveries: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "adder_webhook_deliveries_total",
+			Help: "Number of events successfully delivered to the webhook",
+		}),
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "adder_webhook_failures_total",
+			Help: "Number of events that failed to be delivered to the webhook",
+		}),
+	}
+}
+
+// RegisterMetrics registers the webhook output collectors with the provided registry
+func (w *WebhookOutput) RegisterMetrics(registerer prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		w.metrics.deliveries,
+		w.metrics.failures,
+	}
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -14,7 +14,11 @@
 
 package webhook
 
-import "github.com/blinklabs-io/adder/plugin"
+import (
+	"time"
+
+	"github.com/blinklabs-io/adder/plugin"
+)
 
 // import "github.com/blinklabs-io/adder/event"
 
@@ -49,3 +53,67 @@ func WithFormat(format string) WebhookOptionFunc {
 		o.format = format
 	}
 }
+
+func WithLargeIntsAsStrings(largeIntsAsStrings bool) WebhookOptionFunc {
+	return func(o *WebhookOutput) {
+		o.jsonOptions.LargeIntsAsStrings = largeIntsAsStrings
+	}
+}
+
+// WithMaskAddresses specifies whether to mask the middle of addresses in the JSON payload of the adder format
+func WithMaskAddresses(maskAddresses bool) WebhookOptionFunc {
+	return func(o *WebhookOutput) {
+		o.jsonOptions.MaskAddresses = maskAddresses
+	}
+}
+
+// WithAssetSummary specifies the maximum number of native assets to list in transaction messages for the discord
+// format, largest quantity first. A value of 0 (the default) disables the asset summary
+func WithAssetSummary(maxAssets int) WebhookOptionFunc {
+	return func(o *WebhookOutput) {
+		o.maxAssets = maxAssets
+	}
+}
+
+// WithMaxRetries specifies how many times a failed delivery is retried. Only network errors and 5xx responses are retried
+func WithMaxRetries(maxRetries int) WebhookOptionFunc {
+	return func(o *WebhookOutput) {
+		o.maxRetries = maxRetries
+	}
+}
+
+// WithInitialBackoff specifies the delay before the first retry of a failed delivery
+func WithInitialBackoff(initialBackoff time.Duration) WebhookOptionFunc {
+	return func(o *WebhookOutput) {
+		o.initialBackoff = initialBackoff
+	}
+}
+
+// WithMaxBackoff specifies the upper bound for the delay between retries
+func WithMaxBackoff(maxBackoff time.Duration) WebhookOptionFunc {
+	return func(o *WebhookOutput) {
+		o.maxBackoff = maxBackoff
+	}
+}
+
+// WithBackoffFactor specifies the multiplier applied to the delay after each retry
+func WithBackoffFactor(backoffFactor float64) WebhookOptionFunc {
+	return func(o *WebhookOutput) {
+		o.backoffFactor = backoffFactor
+	}
+}
+
+// WithSortKeys specifies whether to render the keys of all JSON objects in sorted order for reproducible output
+func WithSortKeys(sortKeys bool) WebhookOptionFunc {
+	return func(o *WebhookOutput) {
+		o.jsonOptions.SortKeys = sortKeys
+	}
+}
+
+// WithSerializeWorkers specifies the number of workers used to format payloads in parallel. Payloads are still
+// delivered in the order the events were received. A value of 1 or less formats them on the delivery goroutine
+func WithSerializeWorkers(serializeWorkers int) WebhookOptionFunc {
+	return func(o *WebhookOutput) {
+		o.serializeWorkers = serializeWorkers
+	}
+}
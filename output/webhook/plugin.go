@@ -15,16 +15,27 @@
 package webhook
 
 import (
+	"time"
+
 	"github.com/blinklabs-io/adder/internal/logging"
 	"github.com/blinklabs-io/adder/plugin"
 )
 
 var cmdlineOptions struct {
-	format     string
-	url        string
-	username   string
-	password   string
-	skipVerify bool
+	format             string
+	url                string
+	username           string
+	password           string
+	skipVerify         bool
+	largeIntsAsStrings bool
+	sortKeys           bool
+	assetSummary       uint
+	maskAddresses      bool
+	maxRetries         uint
+	initialBackoff     uint
+	maxBackoff         uint
+	backoffFactor      uint
+	serializeWorkers   uint
 }
 
 func init() {
@@ -70,6 +81,69 @@ func init() {
 					DefaultValue: "",
 					Dest:         &(cmdlineOptions.password),
 				},
+				{
+					Name:         "large-ints-as-strings",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "render integers outside of the JavaScript safe integer range as strings",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.largeIntsAsStrings),
+				},
+				{
+					Name:         "sort-keys",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "render the keys of all JSON objects in sorted order for reproducible output",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.sortKeys),
+				},
+				{
+					Name:         "mask-addresses",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "mask the middle of addresses in the JSON payload",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.maskAddresses),
+				},
+				{
+					Name:         "asset-summary",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the maximum number of native assets to list in discord transaction messages (0 to disable)",
+					DefaultValue: uint(0),
+					Dest:         &(cmdlineOptions.assetSummary),
+				},
+				{
+					Name:         "max-retries",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies how many times to retry a delivery that failed with a network error or 5xx response",
+					DefaultValue: uint(3),
+					Dest:         &(cmdlineOptions.maxRetries),
+				},
+				{
+					Name:         "initial-backoff",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the delay in milliseconds before the first retry",
+					DefaultValue: uint(1000),
+					Dest:         &(cmdlineOptions.initialBackoff),
+				},
+				{
+					Name:         "max-backoff",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the maximum delay in milliseconds between retries",
+					DefaultValue: uint(30000),
+					Dest:         &(cmdlineOptions.maxBackoff),
+				},
+				{
+					Name:         "backoff-factor",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the multiplier applied to the delay after each retry",
+					DefaultValue: uint(2),
+					Dest:         &(cmdlineOptions.backoffFactor),
+				},
+				{
+					Name:         "serialize-workers",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the number of workers used to format payloads in parallel",
+					DefaultValue: uint(1),
+					Dest:         &(cmdlineOptions.serializeWorkers),
+				},
 			},
 		},
 	)
@@ -83,6 +157,19 @@ func NewFromCmdlineOptions() plugin.Plugin {
 		WithUrl(cmdlineOptions.url, cmdlineOptions.skipVerify),
 		WithBasicAuth(cmdlineOptions.username, cmdlineOptions.password),
 		WithFormat(cmdlineOptions.format),
+		WithLargeIntsAsStrings(cmdlineOptions.largeIntsAsStrings),
+		WithSortKeys(cmdlineOptions.sortKeys),
+		WithAssetSummary(int(cmdlineOptions.assetSummary)),
+		WithMaskAddresses(cmdlineOptions.maskAddresses),
+		WithMaxRetries(int(cmdlineOptions.maxRetries)),
+		WithInitialBackoff(
+			time.Duration(cmdlineOptions.initialBackoff)*time.Millisecond,
+		),
+		WithMaxBackoff(
+			time.Duration(cmdlineOptions.maxBackoff)*time.Millisecond,
+		),
+		WithBackoffFactor(float64(cmdlineOptions.backoffFactor)),
+		WithSerializeWorkers(int(cmdlineOptions.serializeWorkers)),
 	)
 	return p
 }
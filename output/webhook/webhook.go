@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	// cbor "github.com/fxamacker/cbor/v2"
@@ -41,81 +43,116 @@ const (
 )
 
 type WebhookOutput struct {
-	errorChan  chan error
-	eventChan  chan event.Event
-	logger     plugin.Logger
-	format     string
-	url        string
-	username   string
-	password   string
-	skipVerify bool
+	errorChan        chan error
+	eventChan        chan event.Event
+	logger           plugin.Logger
+	format           string
+	url              string
+	username         string
+	password         string
+	skipVerify       bool
+	jsonOptions      event.JSONOptions
+	maxAssets        int
+	metrics          *webhookMetrics
+	maxRetries       int
+	initialBackoff   time.Duration
+	maxBackoff       time.Duration
+	backoffFactor    float64
+	doneChan         chan struct{}
+	waitGroup        sync.WaitGroup
+	failedChan       chan event.Event
+	reportFailed     atomic.Bool
+	serializeWorkers int
 }
 
 func New(options ...WebhookOptionFunc) *WebhookOutput {
 	w := &WebhookOutput{
-		errorChan:  make(chan error),
-		eventChan:  make(chan event.Event, 10),
-		format:     "adder",
-		url:        "http://localhost:3000",
-		skipVerify: false,
+		errorChan:      make(chan error),
+		eventChan:      make(chan event.Event, 10),
+		format:         "adder",
+		url:            "http://localhost:3000",
+		skipVerify:     false,
+		metrics:        newWebhookMetrics(),
+		maxRetries:     3,
+		initialBackoff: 1 * time.Second,
+		maxBackoff:     30 * time.Second,
+		backoffFactor:  2,
+		doneChan:       make(chan struct{}),
+		failedChan:     make(chan event.Event, 10),
 	}
 	for _, option := range options {
 		option(w)
 	}
+	if w.logger == nil {
+		w.logger = logging.GetLogger()
+	}
 	return w
 }
 
 // Start the webhook output
 func (w *WebhookOutput) Start() error {
-	logger := logging.GetLogger()
+	logger := w.logger
 	logger.Infof("starting webhook server")
+	w.waitGroup.Add(1)
 	go func() {
+		defer w.waitGroup.Done()
+		// Format payloads across a pool of workers, while still delivering them in order
+		if w.serializeWorkers > 1 {
+			serialized := event.SerializeOrdered(
+				w.eventChan,
+				w.serializeWorkers,
+				func(evt event.Event) ([]byte, error) {
+					// Leave events without a payload for handleEvent to report
+					if evt.Payload == nil {
+						return nil, nil
+					}
+					return formatWebhook(&evt, w.format, w.jsonOptions, w.maxAssets), nil
+				},
+			)
+			for result := range serialized {
+				w.handleEvent(result.Event, result.Data)
+			}
+			return
+		}
 		for {
 			evt, ok := <-w.eventChan
 			// Channel has been closed, which means we're shutting down
 			if !ok {
 				return
 			}
-			payload := evt.Payload
-			if payload == nil {
-				panic(fmt.Errorf("ERROR: %v", payload))
-			}
-			context := evt.Context
-			switch evt.Type {
-			case "chainsync.block":
-				if context == nil {
-					panic(fmt.Errorf("ERROR: %v", context))
-				}
-				be := payload.(chainsync.BlockEvent)
-				bc := context.(chainsync.BlockContext)
-				evt.Payload = be
-				evt.Context = bc
-			case "chainsync.rollback":
-				re := payload.(chainsync.RollbackEvent)
-				evt.Payload = re
-			case "chainsync.transaction":
-				te := payload.(chainsync.TransactionEvent)
-				evt.Payload = te
-			default:
-				logger.Errorf("unknown event type: %s", evt.Type)
-				return
-			}
-			// TODO: error handle
-			err := w.SendWebhook(&evt)
-			if err != nil {
-				logger.Errorf("ERROR: %s", err)
-			}
+			w.handleEvent(evt, nil)
 		}
 	}()
 	return nil
 }
 
+// handleEvent sends the event to the webhook, using data as the payload if it has already been formatted. Event
+// types without a specific format are sent as generic JSON
+func (w *WebhookOutput) handleEvent(evt event.Event, data []byte) {
+	if evt.Payload == nil {
+		w.logger.Errorf("skipping %s event without a payload", evt.Type)
+		return
+	}
+	if data == nil {
+		data = formatWebhook(&evt, w.format, w.jsonOptions, w.maxAssets)
+	}
+	err := w.sendFormattedWebhook(&evt, data)
+	if err != nil {
+		w.logger.Errorf("ERROR: %s", err)
+	}
+}
+
 func basicAuth(username, password string) string {
 	auth := username + ":" + password
 	return "Basic " + base64.StdEncoding.EncodeToString([]byte(auth))
 }
 
-func formatWebhook(e *event.Event, format string) []byte {
+func formatWebhook(
+	e *event.Event,
+	format string,
+	jsonOptions event.JSONOptions,
+	maxAssets int,
+) []byte {
 	var data []byte
 	var err error
 	switch format {
@@ -124,7 +161,12 @@ func formatWebhook(e *event.Event, format string) []byte {
 		var dme DiscordMessageEmbed
 		var dmes []*DiscordMessageEmbed
 		var dmefs []*DiscordMessageEmbedField
-		switch e.Type {
+		eventType := event.BaseType(e.Type)
+		if !chainsync.HasTypedPayload(*e) {
+			// Fall back to generic content for payloads we can't build an embed from
+			eventType = ""
+		}
+		switch eventType {
 		case "chainsync.block":
 			be := e.Payload.(chainsync.BlockEvent)
 			bc := e.Context.(chainsync.BlockContext)
@@ -158,6 +200,21 @@ func formatWebhook(e *event.Event, format string) []byte {
 				Name:  "Block Hash",
 				Value: be.BlockHash,
 			})
+		case "chainsync.reset":
+			re := e.Payload.(chainsync.ResetEvent)
+			dme.Title = "Cardano Chain Reset"
+			dmefs = append(dmefs, &DiscordMessageEmbedField{
+				Name:  "Reason",
+				Value: re.Reason,
+			})
+			dmefs = append(dmefs, &DiscordMessageEmbedField{
+				Name:  "Slot Number",
+				Value: fmt.Sprintf("%d", re.SlotNumber),
+			})
+			dmefs = append(dmefs, &DiscordMessageEmbedField{
+				Name:  "Block Hash",
+				Value: re.BlockHash,
+			})
 		case "chainsync.transaction":
 			te := e.Payload.(chainsync.TransactionEvent)
 			tc := e.Context.(chainsync.TransactionContext)
@@ -182,6 +239,14 @@ func formatWebhook(e *event.Event, format string) []byte {
 				Name:  "Fee",
 				Value: fmt.Sprintf("%d", te.Fee),
 			})
+			if maxAssets > 0 {
+				if summary := assetSummary(te, maxAssets); summary != "" {
+					dmefs = append(dmefs, &DiscordMessageEmbedField{
+						Name:  "Assets",
+						Value: summary,
+					})
+				}
+			}
 			dmefs = append(dmefs, &DiscordMessageEmbedField{
 				Name:  "Transaction Hash",
 				Value: tc.TransactionHash,
@@ -200,7 +265,7 @@ func formatWebhook(e *event.Event, format string) []byte {
 			return data
 		}
 	default:
-		data, err = json.Marshal(e)
+		data, err = event.MarshalJSON(e, jsonOptions)
 		if err != nil {
 			return data
 		}
@@ -237,10 +302,77 @@ func getBaseURL(networkMagic uint32) string {
 	}
 }
 
+// SendWebhook sends the event to the configured URL. Deliveries that fail due to a network error or a 5xx response
+// are retried with exponential backoff. Once the retries are exhausted, the error is also sent to the error channel,
+// or the failed events channel if it's in use
 func (w *WebhookOutput) SendWebhook(e *event.Event) error {
-	logger := logging.GetLogger()
-	logger.Infof("sending event %s to %s", e.Type, w.url)
-	data := formatWebhook(e, w.format)
+	return w.sendFormattedWebhook(
+		e,
+		formatWebhook(e, w.format, w.jsonOptions, w.maxAssets),
+	)
+}
+
+// sendFormattedWebhook delivers the already formatted payload for the event, as described for SendWebhook
+func (w *WebhookOutput) sendFormattedWebhook(e *event.Event, data []byte) error {
+	w.logger.Infof("sending event %s to %s", e.Type, w.url)
+	backoff := w.initialBackoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := w.sendWebhook(data)
+		if err == nil {
+			return nil
+		}
+		if !retryable {
+			w.reportFailedEvent(e, err)
+			return err
+		}
+		if attempt >= w.maxRetries {
+			err = fmt.Errorf(
+				"failed to send webhook after %d attempts: %w",
+				attempt+1,
+				err,
+			)
+			if !w.reportFailedEvent(e, err) {
+				// Don't block if nothing is listening for errors
+				select {
+				case w.errorChan <- err:
+				default:
+				}
+			}
+			return err
+		}
+		w.logger.Warnf(
+			"failed to send webhook, retrying in %s: %s",
+			backoff,
+			err,
+		)
+		select {
+		case <-w.doneChan:
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = min(
+			time.Duration(float64(backoff)*w.backoffFactor),
+			w.maxBackoff,
+		)
+	}
+}
+
+// reportFailedEvent sends a dead letter event for an event that couldn't be delivered to the failed events channel.
+// It returns false if the failed events channel isn't in use
+func (w *WebhookOutput) reportFailedEvent(e *event.Event, err error) bool {
+	if !w.reportFailed.Load() {
+		return false
+	}
+	select {
+	case w.failedChan <- event.NewDeadLetterEvent(*e, "webhook", err):
+	case <-w.doneChan:
+	}
+	return true
+}
+
+// sendWebhook makes a single attempt at sending the webhook payload. The returned bool indicates whether a failed
+// attempt should be retried
+func (w *WebhookOutput) sendWebhook(data []byte) (bool, error) {
 	// Setup request
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -251,7 +383,7 @@ func (w *WebhookOutput) SendWebhook(e *event.Event) error {
 		bytes.NewReader(data),
 	)
 	if err != nil {
-		return fmt.Errorf("%s", err)
+		return false, fmt.Errorf("%s", err)
 	}
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add(
@@ -278,27 +410,41 @@ func (w *WebhookOutput) SendWebhook(e *event.Event) error {
 	// Send payload
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("%s", err)
+		w.metrics.failures.Inc()
+		return true, fmt.Errorf("%s", err)
 	}
+	defer resp.Body.Close()
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("%s", err)
+		w.metrics.failures.Inc()
+		return true, fmt.Errorf("%s", err)
 	}
-	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		w.metrics.failures.Inc()
+		return resp.StatusCode >= 500, fmt.Errorf(
+			"webhook returned status %d: %s",
+			resp.StatusCode,
+			string(respBody),
+		)
+	}
+	w.metrics.deliveries.Inc()
 
-	logger.Infof("sent: %s, payload: %s, body: %s, response: %s, status: %d",
+	w.logger.Infof("sent: %s, payload: %s, body: %s, response: %s, status: %d",
 		w.url,
 		string(data),
 		string(respBody),
 		resp.Status,
 		resp.StatusCode,
 	)
-	return nil
+	return false, nil
 }
 
 // Stop the embedded output
 func (w *WebhookOutput) Stop() error {
+	close(w.doneChan)
 	close(w.eventChan)
+	// Wait for any in-flight delivery to finish before closing the error channel
+	w.waitGroup.Wait()
 	close(w.errorChan)
 	return nil
 }
@@ -308,6 +454,13 @@ func (w *WebhookOutput) ErrorChan() chan error {
 	return w.errorChan
 }
 
+// FailedEvents returns a channel that receives a dead letter event for each event that couldn't be delivered. Once
+// this has been called, events that fail after exhausting their retries are no longer reported on the error channel
+func (w *WebhookOutput) FailedEvents() <-chan event.Event {
+	w.reportFailed.Store(true)
+	return w.failedChan
+}
+
 // InputChan returns the input event channel
 func (w *WebhookOutput) InputChan() chan<- event.Event {
 	return w.eventChan
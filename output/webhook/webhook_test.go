@@ -0,0 +1,386 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/input/chainsync"
+	"github.com/blinklabs-io/adder/output/webhook"
+	"github.com/blinklabs-io/gouroboros/cbor"
+	"github.com/blinklabs-io/gouroboros/ledger"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// newTestServer returns a server that responds with the specified status codes in order, followed by 200 for
+// any further requests
+func newTestServer(statusCodes ...int) (*httptest.Server, *atomic.Int32) {
+	var requestCount atomic.Int32
+	server := httptest.NewServer(
+		http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			idx := int(requestCount.Add(1)) - 1
+			if idx < len(statusCodes) {
+				rw.WriteHeader(statusCodes[idx])
+				return
+			}
+			rw.WriteHeader(http.StatusOK)
+		}),
+	)
+	return server, &requestCount
+}
+
+func newTestWebhook(url string, maxRetries int) *webhook.WebhookOutput {
+	return webhook.New(
+		webhook.WithLogger(zap.NewNop().Sugar()),
+		webhook.WithUrl(url, false),
+		webhook.WithMaxRetries(maxRetries),
+		webhook.WithInitialBackoff(10*time.Millisecond),
+		webhook.WithMaxBackoff(50*time.Millisecond),
+	)
+}
+
+func newTestEvent() *event.Event {
+	evt := event.New(
+		"chainsync.rollback",
+		time.Now(),
+		nil,
+		chainsync.RollbackEvent{BlockHash: "abcd", SlotNumber: 1234},
+	)
+	return &evt
+}
+
+func TestSendWebhookRetry(t *testing.T) {
+	server, requestCount := newTestServer(
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+	)
+	defer server.Close()
+	w := newTestWebhook(server.URL, 3)
+
+	assert.NoError(t, w.SendWebhook(newTestEvent()))
+	assert.Equal(t, int32(3), requestCount.Load())
+}
+
+func TestSendWebhookNoRetryClientError(t *testing.T) {
+	server, requestCount := newTestServer(http.StatusBadRequest)
+	defer server.Close()
+	w := newTestWebhook(server.URL, 3)
+
+	assert.ErrorContains(t, w.SendWebhook(newTestEvent()), "status 400")
+	assert.Equal(t, int32(1), requestCount.Load())
+}
+
+func TestSendWebhookRetriesExhausted(t *testing.T) {
+	server, requestCount := newTestServer(
+		http.StatusInternalServerError,
+		http.StatusInternalServerError,
+		http.StatusInternalServerError,
+	)
+	defer server.Close()
+	w := newTestWebhook(server.URL, 2)
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- <-w.ErrorChan()
+	}()
+	// Give the listener a chance to start, since the error is sent without blocking
+	time.Sleep(10 * time.Millisecond)
+
+	err := w.SendWebhook(newTestEvent())
+	assert.ErrorContains(t, err, "after 3 attempts")
+	assert.Equal(t, int32(3), requestCount.Load())
+	select {
+	case chanErr := <-errChan:
+		assert.Equal(t, err, chanErr)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for error on error channel")
+	}
+}
+
+func TestSendWebhookFailedEvents(t *testing.T) {
+	server, _ := newTestServer(http.StatusBadRequest)
+	defer server.Close()
+	w := newTestWebhook(server.URL, 2)
+	failedChan := w.FailedEvents()
+
+	evt := newTestEvent()
+	err := w.SendWebhook(evt)
+	assert.ErrorContains(t, err, "status 400")
+	select {
+	case failedEvt := <-failedChan:
+		assert.Equal(t, "dead_letter", failedEvt.Type)
+		deadLetter := failedEvt.Payload.(event.DeadLetterEvent)
+		assert.Equal(t, "webhook", deadLetter.Output)
+		assert.Equal(t, err.Error(), deadLetter.Error)
+		assert.Equal(t, *evt, deadLetter.Event)
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for failed event")
+	}
+}
+
+func TestSerializeWorkers(t *testing.T) {
+	var mutex sync.Mutex
+	bodies := [][]byte{}
+	server := httptest.NewServer(
+		http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			body, _ := io.ReadAll(req.Body)
+			mutex.Lock()
+			bodies = append(bodies, body)
+			mutex.Unlock()
+		}),
+	)
+	defer server.Close()
+	w := webhook.New(
+		webhook.WithLogger(zap.NewNop().Sugar()),
+		webhook.WithUrl(server.URL, false),
+		webhook.WithSortKeys(true),
+		webhook.WithSerializeWorkers(4),
+	)
+	assert.NoError(t, w.Start())
+	expected := [][]byte{}
+	for i := 0; i < 50; i++ {
+		evt := event.New(
+			"chainsync.rollback",
+			time.Unix(int64(i), 0),
+			nil,
+			chainsync.RollbackEvent{BlockHash: "abcd", SlotNumber: uint64(i)},
+		)
+		data, err := event.MarshalJSON(evt, event.JSONOptions{SortKeys: true})
+		assert.NoError(t, err)
+		expected = append(expected, data)
+		w.InputChan() <- evt
+	}
+	assert.Eventually(
+		t,
+		func() bool {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return len(bodies) == len(expected)
+		},
+		5*time.Second,
+		10*time.Millisecond,
+	)
+	assert.NoError(t, w.Stop())
+	assert.Equal(t, expected, bodies)
+}
+
+func TestUnknownEventTypes(t *testing.T) {
+	bodyChan := make(chan []byte, 10)
+	server := httptest.NewServer(
+		http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			body, _ := io.ReadAll(req.Body)
+			bodyChan <- body
+		}),
+	)
+	defer server.Close()
+	w := webhook.New(
+		webhook.WithLogger(zap.NewNop().Sugar()),
+		webhook.WithUrl(server.URL, false),
+	)
+	assert.NoError(t, w.Start())
+	defer func() {
+		_ = w.Stop()
+	}()
+	// Event types without a specific format are sent as generic JSON, and don't stop later events from being sent
+	evts := []event.Event{
+		event.New(
+			"wallet.activity",
+			time.Unix(0, 0),
+			nil,
+			map[string]any{"address": "addr1"},
+		),
+		event.New(
+			"chainsync.certificate",
+			time.Unix(1, 0),
+			chainsync.TransactionContext{TransactionHash: "abcd"},
+			map[string]any{"certificateType": "stakeRegistration"},
+		),
+		event.New(
+			"chainsync.rollback",
+			time.Unix(2, 0),
+			nil,
+			chainsync.RollbackEvent{BlockHash: "abcd", SlotNumber: 2},
+		),
+	}
+	for _, evt := range evts {
+		w.InputChan() <- evt
+	}
+	for _, evt := range evts {
+		expected, err := event.MarshalJSON(evt, event.JSONOptions{})
+		assert.NoError(t, err)
+		select {
+		case body := <-bodyChan:
+			assert.JSONEq(t, string(expected), string(body))
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %s event", evt.Type)
+		}
+	}
+}
+
+type mockOutput struct {
+	ledger.TransactionOutput
+	assets *ledger.MultiAsset[ledger.MultiAssetTypeOutput]
+}
+
+func (o mockOutput) Assets() *ledger.MultiAsset[ledger.MultiAssetTypeOutput] {
+	return o.assets
+}
+
+func newAssets(
+	t *testing.T,
+	assets map[ledger.Blake2b224]map[cbor.ByteString]uint64,
+) *ledger.MultiAsset[ledger.MultiAssetTypeOutput] {
+	data, err := cbor.Encode(assets)
+	if err != nil {
+		t.Fatalf("unexpected error encoding CBOR: %s", err)
+	}
+	ret := &ledger.MultiAsset[ledger.MultiAssetTypeOutput]{}
+	if err := ret.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("unexpected error decoding CBOR: %s", err)
+	}
+	return ret
+}
+
+func TestDiscordAssetSummary(t *testing.T) {
+	policyId := ledger.Blake2b224{0x01}
+	evt := event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{TransactionHash: "abcd"},
+		chainsync.TransactionEvent{
+			Outputs: []ledger.TransactionOutput{
+				mockOutput{
+					assets: newAssets(t, map[ledger.Blake2b224]map[cbor.ByteString]uint64{
+						policyId: {
+							cbor.NewByteString([]byte("small")):    5,
+							cbor.NewByteString([]byte{0x00, 0xff}): 500,
+							cbor.NewByteString([]byte("smallest")): 1,
+						},
+					}),
+				},
+				mockOutput{
+					assets: newAssets(t, map[ledger.Blake2b224]map[cbor.ByteString]uint64{
+						policyId: {cbor.NewByteString([]byte("small")): 10},
+					}),
+				},
+			},
+		},
+	)
+	for _, maxAssets := range []int{0, 2} {
+		bodyChan := make(chan []byte, 1)
+		server := httptest.NewServer(
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, _ := io.ReadAll(req.Body)
+				bodyChan <- body
+			}),
+		)
+		w := webhook.New(
+			webhook.WithLogger(zap.NewNop().Sugar()),
+			webhook.WithUrl(server.URL, false),
+			webhook.WithFormat("discord"),
+			webhook.WithAssetSummary(maxAssets),
+		)
+		assert.NoError(t, w.SendWebhook(&evt))
+		server.Close()
+
+		var msg webhook.DiscordWebhookEvent
+		assert.NoError(t, json.Unmarshal(<-bodyChan, &msg))
+		fields := map[string]string{}
+		for _, field := range msg.Embeds[0].Fields {
+			fields[field.Name] = field.Value
+		}
+		if maxAssets == 0 {
+			assert.NotContains(t, fields, "Assets")
+			continue
+		}
+		assert.Equal(
+			t,
+			policyId.String()+".00ff: 500\n"+
+				policyId.String()+".small: 15\n"+
+				"and 1 more",
+			fields["Assets"],
+		)
+	}
+}
+
+func TestDiscordGenericPayload(t *testing.T) {
+	// Events from the replay input or the project filter carry generic maps rather than chainsync types
+	evt := event.New(
+		"chainsync.transaction",
+		time.Now(),
+		map[string]any{"transactionHash": "abcd"},
+		map[string]any{"fee": 123},
+	)
+	bodyChan := make(chan []byte, 1)
+	server := httptest.NewServer(
+		http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			body, _ := io.ReadAll(req.Body)
+			bodyChan <- body
+		}),
+	)
+	defer server.Close()
+	w := webhook.New(
+		webhook.WithLogger(zap.NewNop().Sugar()),
+		webhook.WithUrl(server.URL, false),
+		webhook.WithFormat("discord"),
+	)
+	assert.NoError(t, w.SendWebhook(&evt))
+
+	var msg webhook.DiscordWebhookEvent
+	assert.NoError(t, json.Unmarshal(<-bodyChan, &msg))
+	assert.Equal(t, "map[fee:123]", msg.Content)
+}
+
+func TestMaskAddresses(t *testing.T) {
+	address := "addr1qx2fxv2umyhttkxyxp8x0dlpdt3k6cwng5pxj3jhsydzer3n0d3vllmyqwsx5wktcd8cc3sq835lu7drv2xwl2wywfgse35a3x"
+	evt := event.New(
+		"chainsync.transaction",
+		time.Now(),
+		chainsync.TransactionContext{},
+		map[string]any{"outputs": []any{map[string]any{"address": address}}},
+	)
+	for _, maskAddresses := range []bool{false, true} {
+		bodyChan := make(chan []byte, 1)
+		server := httptest.NewServer(
+			http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, _ := io.ReadAll(req.Body)
+				bodyChan <- body
+			}),
+		)
+		w := webhook.New(
+			webhook.WithLogger(zap.NewNop().Sugar()),
+			webhook.WithUrl(server.URL, false),
+			webhook.WithMaskAddresses(maskAddresses),
+		)
+		assert.NoError(t, w.SendWebhook(&evt))
+		server.Close()
+
+		body := string(<-bodyChan)
+		if maskAddresses {
+			assert.Contains(t, body, "addr1qx2fxv...e35a3x")
+			assert.NotContains(t, body, address)
+		} else {
+			assert.Contains(t, body, address)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/blinklabs-io/adder/api"
+)
+
+var routesRegistered = false
+
+// RegisterRoutes mounts the websocket endpoint on the shared API server when enabled
+func (w *WebsocketOutput) RegisterRoutes() {
+	if routesRegistered || !w.useAPIServer {
+		return
+	}
+
+	apiInstance := api.GetInstance()
+	apiInstance.AddRoute("GET", w.path, w.handleRoute)
+
+	routesRegistered = true
+}
+
+func (w *WebsocketOutput) handleRoute(ctx *gin.Context) {
+	w.handleWebsocket(ctx.Writer, ctx.Request)
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+type WebsocketOptionFunc func(*WebsocketOutput)
+
+// WithLogger specifies the logger object to use for logging messages
+func WithLogger(logger plugin.Logger) WebsocketOptionFunc {
+	return func(o *WebsocketOutput) {
+		o.logger = logger
+	}
+}
+
+// WithListenAddress specifies the address for the websocket server to listen on in the form 'host:port'
+func WithListenAddress(listenAddress string) WebsocketOptionFunc {
+	return func(o *WebsocketOutput) {
+		o.listenAddress = listenAddress
+	}
+}
+
+// WithPath specifies the path of the websocket endpoint
+func WithPath(path string) WebsocketOptionFunc {
+	return func(o *WebsocketOutput) {
+		o.path = path
+	}
+}
+
+// WithAllowedOrigins specifies the origins allowed to connect in addition to the same host. An origin of "*"
+// allows any origin
+func WithAllowedOrigins(allowedOrigins []string) WebsocketOptionFunc {
+	return func(o *WebsocketOutput) {
+		o.allowedOrigins = allowedOrigins[:]
+	}
+}
+
+// WithBufferSize specifies the number of events buffered for each client. Clients that fall further behind than
+// this are dropped
+func WithBufferSize(bufferSize int) WebsocketOptionFunc {
+	return func(o *WebsocketOutput) {
+		o.bufferSize = bufferSize
+	}
+}
+
+// WithUseAPIServer specifies whether to mount the websocket endpoint on the shared API server instead of starting
+// a separate server
+func WithUseAPIServer(useAPIServer bool) WebsocketOptionFunc {
+	return func(o *WebsocketOutput) {
+		o.useAPIServer = useAPIServer
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"strings"
+
+	"github.com/blinklabs-io/adder/internal/logging"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+var cmdlineOptions struct {
+	listenAddress  string
+	path           string
+	allowedOrigins string
+	bufferSize     uint
+	useAPIServer   bool
+}
+
+func init() {
+	plugin.Register(
+		plugin.PluginEntry{
+			Type:               plugin.PluginTypeOutput,
+			Name:               "websocket",
+			Description:        "push events to websocket clients as JSON",
+			NewFromOptionsFunc: NewFromCmdlineOptions,
+			Options: []plugin.PluginOption{
+				{
+					Name:         "listen-address",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the address for the websocket server to listen on in the form 'host:port'",
+					DefaultValue: ":8081",
+					Dest:         &(cmdlineOptions.listenAddress),
+				},
+				{
+					Name:         "path",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the path of the websocket endpoint",
+					DefaultValue: "/ws",
+					Dest:         &(cmdlineOptions.path),
+				},
+				{
+					Name:         "allowed-origins",
+					Type:         plugin.PluginOptionTypeString,
+					Description:  "specifies the origins allowed to connect, separated by commas ('*' for any)",
+					DefaultValue: "",
+					Dest:         &(cmdlineOptions.allowedOrigins),
+				},
+				{
+					Name:         "buffer-size",
+					Type:         plugin.PluginOptionTypeUint,
+					Description:  "specifies the number of events buffered for each client before it is dropped",
+					DefaultValue: uint(100),
+					Dest:         &(cmdlineOptions.bufferSize),
+				},
+				{
+					Name:         "use-api-server",
+					Type:         plugin.PluginOptionTypeBool,
+					Description:  "mount the websocket endpoint on the API server instead of starting a separate server",
+					DefaultValue: false,
+					Dest:         &(cmdlineOptions.useAPIServer),
+				},
+			},
+		},
+	)
+}
+
+func NewFromCmdlineOptions() plugin.Plugin {
+	opts := []WebsocketOptionFunc{
+		WithLogger(
+			logging.GetLogger().With("plugin", "output.websocket"),
+		),
+		WithListenAddress(cmdlineOptions.listenAddress),
+		WithPath(cmdlineOptions.path),
+		WithBufferSize(int(cmdlineOptions.bufferSize)),
+		WithUseAPIServer(cmdlineOptions.useAPIServer),
+	}
+	if cmdlineOptions.allowedOrigins != "" {
+		opts = append(
+			opts,
+			WithAllowedOrigins(
+				strings.Split(cmdlineOptions.allowedOrigins, ","),
+			),
+		)
+	}
+	p := New(opts...)
+	return p
+}
@@ -0,0 +1,276 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+const (
+	// How long to wait for a frame to be written to a client before giving up
+	writeTimeout = 10 * time.Second
+)
+
+type WebsocketOutput struct {
+	errorChan      chan error
+	eventChan      chan event.Event
+	doneChan       chan struct{}
+	logger         plugin.Logger
+	listenAddress  string
+	path           string
+	allowedOrigins []string
+	bufferSize     int
+	useAPIServer   bool
+	upgrader       websocket.Upgrader
+	server         *http.Server
+	clientsMutex   sync.Mutex
+	clients        map[*client]struct{}
+	stopping       bool
+	clientWait     sync.WaitGroup
+}
+
+// client is a connected websocket client
+type client struct {
+	conn     *websocket.Conn
+	sendChan chan []byte
+}
+
+func New(options ...WebsocketOptionFunc) *WebsocketOutput {
+	w := &WebsocketOutput{
+		errorChan:     make(chan error),
+		eventChan:     make(chan event.Event, 10),
+		doneChan:      make(chan struct{}),
+		listenAddress: ":8081",
+		path:          "/ws",
+		bufferSize:    100,
+		clients:       make(map[*client]struct{}),
+	}
+	for _, option := range options {
+		option(w)
+	}
+	w.upgrader = websocket.Upgrader{
+		CheckOrigin: w.checkOrigin,
+	}
+	return w
+}
+
+// Start the websocket output
+func (w *WebsocketOutput) Start() error {
+	// The handler is mounted on the shared API server by RegisterRoutes instead
+	if !w.useAPIServer {
+		listener, err := net.Listen("tcp", w.listenAddress)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", w.listenAddress, err)
+		}
+		mux := http.NewServeMux()
+		mux.HandleFunc(w.path, w.handleWebsocket)
+		w.server = &http.Server{
+			Handler:           mux,
+			ReadHeaderTimeout: 60 * time.Second,
+		}
+		go func() {
+			if err := w.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				w.errorChan <- fmt.Errorf("websocket server failed: %w", err)
+			}
+		}()
+		if w.logger != nil {
+			w.logger.Infof("websocket server listening on %s", listener.Addr().String())
+		}
+	}
+	go func() {
+		defer close(w.doneChan)
+		for {
+			evt, ok := <-w.eventChan
+			// Channel has been closed, which means we're shutting down
+			if !ok {
+				w.closeClients()
+				return
+			}
+			data, err := json.Marshal(evt)
+			if err != nil {
+				if w.logger != nil {
+					w.logger.Errorf("failed to encode event: %s", err)
+				}
+				continue
+			}
+			w.broadcast(data)
+		}
+	}()
+	return nil
+}
+
+// checkOrigin allows requests without an Origin header, from the same host, or from one of the allowed origins.
+// An allowed origin of "*" allows any origin
+func (w *WebsocketOutput) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowedOrigin := range w.allowedOrigins {
+		if allowedOrigin == "*" || allowedOrigin == origin {
+			return true
+		}
+	}
+	originUrl, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return originUrl.Host == r.Host
+}
+
+// handleWebsocket upgrades the request to a websocket connection and registers the client
+func (w *WebsocketOutput) handleWebsocket(
+	rw http.ResponseWriter,
+	r *http.Request,
+) {
+	conn, err := w.upgrader.Upgrade(rw, r, nil)
+	if err != nil {
+		// The upgrader has already replied with an error
+		return
+	}
+	c := &client{
+		conn:     conn,
+		sendChan: make(chan []byte, w.bufferSize),
+	}
+	w.clientsMutex.Lock()
+	if w.stopping {
+		w.clientsMutex.Unlock()
+		_ = conn.Close()
+		return
+	}
+	w.clients[c] = struct{}{}
+	w.clientWait.Add(1)
+	w.clientsMutex.Unlock()
+	go w.clientWriteLoop(c)
+	go w.clientReadLoop(c)
+}
+
+// clientWriteLoop writes queued events to the client until its send channel is closed
+func (w *WebsocketOutput) clientWriteLoop(c *client) {
+	defer w.clientWait.Done()
+	defer c.conn.Close()
+	for data := range c.sendChan {
+		_ = c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if err := c.conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			w.removeClient(c)
+			// Drain anything queued before the channel was closed
+			for range c.sendChan {
+			}
+			return
+		}
+	}
+	_ = c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	_ = c.conn.WriteMessage(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+	)
+}
+
+// clientReadLoop reads from the client to process control frames and notice when it disconnects
+func (w *WebsocketOutput) clientReadLoop(c *client) {
+	for {
+		if _, _, err := c.conn.ReadMessage(); err != nil {
+			w.removeClient(c)
+			return
+		}
+	}
+}
+
+// removeClient unregisters the client and closes its send channel, if it's still registered
+func (w *WebsocketOutput) removeClient(c *client) {
+	w.clientsMutex.Lock()
+	defer w.clientsMutex.Unlock()
+	if _, ok := w.clients[c]; !ok {
+		return
+	}
+	delete(w.clients, c)
+	close(c.sendChan)
+}
+
+// broadcast queues the event for all connected clients. Clients whose buffer is full are dropped rather than
+// blocking the pipeline
+func (w *WebsocketOutput) broadcast(data []byte) {
+	w.clientsMutex.Lock()
+	defer w.clientsMutex.Unlock()
+	for c := range w.clients {
+		select {
+		case c.sendChan <- data:
+		default:
+			if w.logger != nil {
+				w.logger.Warnf("dropping websocket client that can't keep up")
+			}
+			delete(w.clients, c)
+			close(c.sendChan)
+			// Closing the connection unblocks any write in progress
+			_ = c.conn.Close()
+		}
+	}
+}
+
+// closeClients closes the send channel of all connected clients, which closes their connection once any queued
+// events have been sent
+func (w *WebsocketOutput) closeClients() {
+	w.clientsMutex.Lock()
+	defer w.clientsMutex.Unlock()
+	w.stopping = true
+	for c := range w.clients {
+		delete(w.clients, c)
+		close(c.sendChan)
+	}
+}
+
+// Stop the websocket output. Queued events are sent to connected clients before their connections are closed
+func (w *WebsocketOutput) Stop() error {
+	close(w.eventChan)
+	<-w.doneChan
+	w.clientWait.Wait()
+	if w.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), writeTimeout)
+		defer cancel()
+		if err := w.server.Shutdown(ctx); err != nil {
+			return err
+		}
+	}
+	close(w.errorChan)
+	return nil
+}
+
+// ErrorChan returns the plugin's error channel
+func (w *WebsocketOutput) ErrorChan() chan error {
+	return w.errorChan
+}
+
+// InputChan returns the input event channel
+func (w *WebsocketOutput) InputChan() chan<- event.Event {
+	return w.eventChan
+}
+
+// OutputChan always returns nil
+func (w *WebsocketOutput) OutputChan() <-chan event.Event {
+	return nil
+}
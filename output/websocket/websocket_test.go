@@ -0,0 +1,146 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package websocket_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/blinklabs-io/adder/api"
+	"github.com/blinklabs-io/adder/event"
+	output_websocket "github.com/blinklabs-io/adder/output/websocket"
+)
+
+// freeAddress returns a local address with a port that is free to listen on
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func dial(t *testing.T, url string, header http.Header) *websocket.Conn {
+	conn, _, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		t.Fatalf("unexpected error connecting: %s", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	// Give the server a moment to register the client
+	time.Sleep(50 * time.Millisecond)
+	return conn
+}
+
+func readEvent(t *testing.T, conn *websocket.Conn) map[string]any {
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	msgType, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("unexpected error reading: %s", err)
+	}
+	assert.Equal(t, websocket.TextMessage, msgType)
+	var evt map[string]any
+	assert.NoError(t, json.Unmarshal(data, &evt))
+	return evt
+}
+
+func TestWebsocketOutput(t *testing.T) {
+	address := freeAddress(t)
+	w := output_websocket.New(output_websocket.WithListenAddress(address))
+	assert.NoError(t, w.Start())
+	conn := dial(t, "ws://"+address+"/ws", nil)
+	for i := 0; i < 3; i++ {
+		w.InputChan() <- event.New("chainsync.block", time.Now(), nil, map[string]int{"idx": i})
+	}
+	for i := 0; i < 3; i++ {
+		evt := readEvent(t, conn)
+		assert.Equal(t, "chainsync.block", evt["type"])
+		assert.Equal(t, map[string]any{"idx": float64(i)}, evt["payload"])
+	}
+	assert.NoError(t, w.Stop())
+	// The connection is closed cleanly on shutdown
+	_, _, err := conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseNormalClosure))
+}
+
+func TestWebsocketOutputAllowedOrigins(t *testing.T) {
+	address := freeAddress(t)
+	w := output_websocket.New(
+		output_websocket.WithListenAddress(address),
+		output_websocket.WithAllowedOrigins([]string{"https://dashboard.example.com"}),
+	)
+	assert.NoError(t, w.Start())
+	defer func() {
+		_ = w.Stop()
+	}()
+	dial(t, "ws://"+address+"/ws", http.Header{"Origin": {"https://dashboard.example.com"}})
+	_, resp, err := websocket.DefaultDialer.Dial(
+		"ws://"+address+"/ws",
+		http.Header{"Origin": {"https://other.example.com"}},
+	)
+	assert.Error(t, err)
+	if assert.NotNil(t, resp) {
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	}
+}
+
+func TestWebsocketOutputSlowClient(t *testing.T) {
+	address := freeAddress(t)
+	w := output_websocket.New(
+		output_websocket.WithListenAddress(address),
+		output_websocket.WithBufferSize(1),
+	)
+	assert.NoError(t, w.Start())
+	defer func() {
+		_ = w.Stop()
+	}()
+	conn := dial(t, "ws://"+address+"/ws", nil)
+	// Send more than the connection can buffer without the client reading, which must not block the pipeline
+	largePayload := strings.Repeat("x", 64*1024)
+	for i := 0; i < 500; i++ {
+		w.InputChan() <- event.New("chainsync.block", time.Now(), nil, largePayload)
+	}
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			// The connection was dropped rather than closed cleanly
+			assert.False(t, websocket.IsCloseError(err, websocket.CloseNormalClosure))
+			break
+		}
+	}
+}
+
+func TestWebsocketOutputAPIServer(t *testing.T) {
+	api.New(true)
+	w := output_websocket.New(output_websocket.WithUseAPIServer(true))
+	w.RegisterRoutes()
+	assert.NoError(t, w.Start())
+	defer func() {
+		_ = w.Stop()
+	}()
+	server := httptest.NewServer(api.GetInstance().Engine())
+	defer server.Close()
+	conn := dial(t, "ws"+strings.TrimPrefix(server.URL, "http")+"/ws", nil)
+	w.InputChan() <- event.New("chainsync.rollback", time.Now(), nil, nil)
+	assert.Equal(t, "chainsync.rollback", readEvent(t, conn)["type"])
+}
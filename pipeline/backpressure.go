@@ -0,0 +1,126 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"github.com/blinklabs-io/adder/event"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type PipelineOptionFunc func(*Pipeline)
+
+// WithDropOnFull specifies whether events are dropped, rather than waited on, when an output isn't ready to accept
+// them. This prevents a slow output from stalling the input, at the cost of losing events. The default is to wait
+func WithDropOnFull(dropOnFull bool) PipelineOptionFunc {
+	return func(p *Pipeline) {
+		p.dropOnFull = dropOnFull
+	}
+}
+
+// SetOutputBufferSize sets the number of events buffered for each output ahead of its input channel, which allows
+// an output to fall briefly behind without holding up the rest of the pipeline. Buffered events that haven't been
+// delivered when the pipeline stops are discarded. This must be called before Start, and a value of 0 (the default)
+// disables buffering
+func (p *Pipeline) SetOutputBufferSize(size int) {
+	p.outputBufferSize = size
+}
+
+type pipelineMetrics struct {
+	backpressure *prometheus.CounterVec
+	dropped      *prometheus.CounterVec
+	expired      *prometheus.CounterVec
+}
+
+func newPipelineMetrics() *pipelineMetrics {
+	return &pipelineMetrics{
+		backpressure: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "adder_pipeline_output_backpressure_total",
+			Help: "Number of events that an output wasn't ready to accept when they were sent",
+		}, []string{"output"}),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "adder_pipeline_output_dropped_total",
+			Help: "Number of events dropped because an output wasn't ready to accept them",
+		}, []string{"output"}),
+		expired: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "adder_pipeline_output_expired_total",
+			Help: "Number of events dropped because they were older than the maximum event age",
+		}, []string{"output"}),
+	}
+}
+
+// RegisterMetrics registers the pipeline collectors with the provided registry
+func (p *Pipeline) RegisterMetrics(registerer prometheus.Registerer) error {
+	collectors := []prometheus.Collector{
+		p.metrics.backpressure,
+		p.metrics.dropped,
+		p.metrics.expired,
+	}
+	for _, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendToOutput delivers an event to the output at the specified index, via its buffer if one is configured. If the
+// output isn't ready, the backpressure is recorded and the event is either dropped or waited on, depending on the
+// drop policy. Events older than the maximum event age are dropped
+func (p *Pipeline) sendToOutput(idx int, evt event.Event) {
+	if p.dropExpired(idx, evt) {
+		return
+	}
+	outputChan := p.outputs[idx].InputChan()
+	if p.outputBuffers != nil {
+		outputChan = p.outputBuffers[idx]
+	}
+	select {
+	case outputChan <- evt:
+		return
+	default:
+	}
+	name := p.outputName(idx)
+	p.metrics.backpressure.WithLabelValues(name).Inc()
+	if p.dropOnFull {
+		p.metrics.dropped.WithLabelValues(name).Inc()
+		return
+	}
+	outputChan <- evt
+}
+
+// outputBufferLoop copies events from an output's buffer to the output's input channel. Events that have grown
+// older than the maximum event age while buffered are dropped
+func (p *Pipeline) outputBufferLoop(
+	idx int,
+	buffer <-chan event.Event,
+	output chan<- event.Event,
+) {
+	for {
+		select {
+		case <-p.doneChan:
+			return
+		case evt := <-buffer:
+			if p.dropExpired(idx, evt) {
+				continue
+			}
+			select {
+			case output <- evt:
+			case <-p.doneChan:
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/plugin"
+)
+
+// FailedEventsProvider is implemented by outputs that can report events they've permanently failed to deliver
+type FailedEventsProvider interface {
+	// FailedEvents returns a channel that receives a dead letter event for each event that couldn't be delivered
+	FailedEvents() <-chan event.Event
+}
+
+// SetDeadLetter specifies an output that receives the events that other outputs report as permanently failed.
+// The dead letter output isn't sent any other events. This should be called before the pipeline is started
+func (p *Pipeline) SetDeadLetter(output plugin.Plugin) {
+	p.deadLetter = output
+}
+
+// startDeadLetter starts the dead letter output, if any, and the background processes that send it failed events
+func (p *Pipeline) startDeadLetter() error {
+	if p.deadLetter == nil {
+		return nil
+	}
+	if err := p.deadLetter.Start(); err != nil {
+		return err
+	}
+	// Start background error listener
+	go p.errorChanWait(p.deadLetter.ErrorChan(), -1)
+	for _, output := range p.outputs {
+		provider, ok := output.(FailedEventsProvider)
+		if !ok {
+			continue
+		}
+		go p.chanCopyLoop(provider.FailedEvents(), p.deadLetter.InputChan(), nil)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+)
+
+// BlockTimer is implemented by event contexts that can report the time of the block an event came from
+type BlockTimer interface {
+	BlockTime() (time.Time, error)
+}
+
+// WithMaxEventAge specifies the maximum age of an event, based on the time of the block it came from, for it to be
+// delivered to an output. Older events are dropped, which avoids sending outputs stale events after a long stall.
+// Events without a known block time are always delivered. A value of 0 (the default) disables the check
+func WithMaxEventAge(maxAge time.Duration) PipelineOptionFunc {
+	return func(p *Pipeline) {
+		p.maxEventAge = maxAge
+	}
+}
+
+// isExpired checks whether an event is older than the configured maximum event age
+func (p *Pipeline) isExpired(evt event.Event) bool {
+	if p.maxEventAge <= 0 {
+		return false
+	}
+	timer, ok := evt.Context.(BlockTimer)
+	if !ok {
+		return false
+	}
+	blockTime, err := timer.BlockTime()
+	if err != nil {
+		return false
+	}
+	return time.Since(blockTime) > p.maxEventAge
+}
+
+// dropExpired checks whether an event is too old to be delivered to the output at the specified index, and records
+// the drop if it is
+func (p *Pipeline) dropExpired(idx int, evt event.Event) bool {
+	if !p.isExpired(evt) {
+		return false
+	}
+	p.metrics.expired.WithLabelValues(p.outputName(idx)).Inc()
+	return true
+}
@@ -16,27 +16,53 @@ package pipeline
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/blinklabs-io/adder/event"
 	"github.com/blinklabs-io/adder/plugin"
 )
 
 type Pipeline struct {
-	inputs     []plugin.Plugin
-	filters    []plugin.Plugin
-	outputs    []plugin.Plugin
-	filterChan chan event.Event
-	outputChan chan event.Event
-	errorChan  chan error
-	doneChan   chan bool
+	inputs      []plugin.Plugin
+	filters     []plugin.Plugin
+	outputs     []plugin.Plugin
+	outputNames []string
+	filterChan  chan event.Event
+	outputChan  chan event.Event
+	errorChan   chan error
+	doneChan    chan bool
+	// Stats counters
+	inputStats  stageCounter
+	filterStats stageCounter
+	outputStats stageCounter
+	// Stop report details
+	reportMutex    sync.Mutex
+	startTime      time.Time
+	stopTime       time.Time
+	outputFailures []OutputFailure
+	stopOnce       sync.Once
+	stopErr        error
+	// Output backpressure handling
+	outputBufferSize int
+	outputBuffers    []chan event.Event
+	dropOnFull       bool
+	maxEventAge      time.Duration
+	metrics          *pipelineMetrics
+	// Output that receives events other outputs failed to deliver
+	deadLetter plugin.Plugin
 }
 
-func New() *Pipeline {
+func New(options ...PipelineOptionFunc) *Pipeline {
 	p := &Pipeline{
 		filterChan: make(chan event.Event),
 		outputChan: make(chan event.Event),
 		errorChan:  make(chan error),
 		doneChan:   make(chan bool),
+		metrics:    newPipelineMetrics(),
+	}
+	for _, option := range options {
+		option(p)
 	}
 	return p
 }
@@ -50,7 +76,13 @@ func (p *Pipeline) AddFilter(filter plugin.Plugin) {
 }
 
 func (p *Pipeline) AddOutput(output plugin.Plugin) {
+	p.AddNamedOutput("", output)
+}
+
+// AddNamedOutput adds an output that events can be routed to by name via their destinations
+func (p *Pipeline) AddNamedOutput(name string, output plugin.Plugin) {
 	p.outputs = append(p.outputs, output)
+	p.outputNames = append(p.outputNames, name)
 }
 
 func (p *Pipeline) ErrorChan() chan error {
@@ -59,15 +91,18 @@ func (p *Pipeline) ErrorChan() chan error {
 
 // Start initiates the configured plugins and starts the necessary background processes to run the pipeline
 func (p *Pipeline) Start() error {
+	p.reportMutex.Lock()
+	p.startTime = time.Now()
+	p.reportMutex.Unlock()
 	// Start inputs
 	for _, input := range p.inputs {
 		if err := input.Start(); err != nil {
 			return fmt.Errorf("failed to start input: %s", err)
 		}
 		// Start background process to send input events to combined filter channel
-		go p.chanCopyLoop(input.OutputChan(), p.filterChan)
+		go p.chanCopyLoop(input.OutputChan(), p.filterChan, &p.inputStats)
 		// Start background error listener
-		go p.errorChanWait(input.ErrorChan())
+		go p.errorChanWait(input.ErrorChan(), -1)
 	}
 	// Start filters
 	for idx, filter := range p.filters {
@@ -76,37 +111,61 @@ func (p *Pipeline) Start() error {
 		}
 		if idx == 0 {
 			// Start background process to send events from combined filter channel to first filter plugin
-			go p.chanCopyLoop(p.filterChan, filter.InputChan())
+			go p.chanCopyLoop(p.filterChan, filter.InputChan(), nil)
 		} else {
 			// Start background process to send events from previous filter plugin to current filter plugin
-			go p.chanCopyLoop(p.filters[idx-1].OutputChan(), filter.InputChan())
+			go p.chanCopyLoop(
+				p.filters[idx-1].OutputChan(),
+				filter.InputChan(),
+				nil,
+			)
 		}
 		if idx == len(p.filters)-1 {
 			// Start background process to send events from last filter to combined output channel
-			go p.chanCopyLoop(filter.OutputChan(), p.outputChan)
+			go p.chanCopyLoop(filter.OutputChan(), p.outputChan, &p.filterStats)
 		}
 		// Start background error listener
-		go p.errorChanWait(filter.ErrorChan())
+		go p.errorChanWait(filter.ErrorChan(), -1)
 	}
 	if len(p.filters) == 0 {
 		// Start background process to send events from combined filter channel to combined output channel if
 		// there are no filter plugins
-		go p.chanCopyLoop(p.filterChan, p.outputChan)
+		go p.chanCopyLoop(p.filterChan, p.outputChan, &p.filterStats)
 	}
 	// Start outputs
-	for _, output := range p.outputs {
+	for idx, output := range p.outputs {
 		if err := output.Start(); err != nil {
 			return fmt.Errorf("failed to start output: %s", err)
 		}
 		// Start background error listener
-		go p.errorChanWait(output.ErrorChan())
+		go p.errorChanWait(output.ErrorChan(), idx)
+	}
+	// Start dead letter output
+	if err := p.startDeadLetter(); err != nil {
+		return fmt.Errorf("failed to start dead letter output: %s", err)
+	}
+	if p.outputBufferSize > 0 {
+		p.outputBuffers = make([]chan event.Event, len(p.outputs))
+		for idx, output := range p.outputs {
+			p.outputBuffers[idx] = make(chan event.Event, p.outputBufferSize)
+			go p.outputBufferLoop(idx, p.outputBuffers[idx], output.InputChan())
+		}
 	}
 	go p.outputChanLoop()
 	return nil
 }
 
-// Stop shuts down the pipeline and all plugins
+// Stop shuts down the pipeline and all plugins. All outputs are given the chance to stop and any failures are
+// recorded in the stop report. It's safe to call Stop more than once, and later calls wait for the first to finish
 func (p *Pipeline) Stop() error {
+	p.stopOnce.Do(func() {
+		p.stopErr = p.stop()
+	})
+	return p.stopErr
+}
+
+func (p *Pipeline) stop() error {
+	var ret error
 	close(p.doneChan)
 	close(p.errorChan)
 	close(p.filterChan)
@@ -118,27 +177,46 @@ func (p *Pipeline) Stop() error {
 		}
 	}
 	// Stop outputs
-	for _, output := range p.outputs {
+	for idx, output := range p.outputs {
 		if err := output.Stop(); err != nil {
-			return fmt.Errorf("failed to stop output: %s", err)
+			p.recordOutputFailure(idx, err)
+			if ret == nil {
+				ret = fmt.Errorf("failed to stop output: %s", err)
+			}
 		}
 	}
-	return nil
+	// Stop dead letter output
+	if p.deadLetter != nil {
+		if err := p.deadLetter.Stop(); err != nil && ret == nil {
+			ret = fmt.Errorf("failed to stop dead letter output: %s", err)
+		}
+	}
+	p.reportMutex.Lock()
+	p.stopTime = time.Now()
+	p.reportMutex.Unlock()
+	return ret
 }
 
-// chanCopyLoop is a generic function for reading an event from one channel and writing it to another in a loop
+// chanCopyLoop is a generic function for reading an event from one channel and writing it to another in a loop.
+// Copied events are recorded in the provided stats counter, if any. The loop ends when the pipeline is stopped
+// or the input channel is closed, such as by an input that has run out of events
 func (p *Pipeline) chanCopyLoop(
 	input <-chan event.Event,
 	output chan<- event.Event,
+	stats *stageCounter,
 ) {
 	for {
 		select {
 		case <-p.doneChan:
 			return
 		case evt, ok := <-input:
-			if ok {
-				// Copy input event to output chan
-				output <- evt
+			if !ok {
+				return
+			}
+			// Copy input event to output chan
+			output <- evt
+			if stats != nil {
+				stats.record()
 			}
 		}
 	}
@@ -152,19 +230,40 @@ func (p *Pipeline) outputChanLoop() {
 			return
 		case evt, ok := <-p.outputChan:
 			if ok {
-				// Send event to all output plugins
-				for _, output := range p.outputs {
-					output.InputChan() <- evt
+				// Send event to all output plugins, or only the output plugins it's routed to if it has destinations
+				for idx := range p.outputs {
+					if !p.isDestination(evt, p.outputNames[idx]) {
+						continue
+					}
+					p.sendToOutput(idx, evt)
 				}
+				p.outputStats.record()
 			}
 		}
 	}
 }
 
-// errorChanWait reads from an error channel. If an error is received, it's copied to the plugin error channel and the plugin stopped
-func (p *Pipeline) errorChanWait(errorChan chan error) {
+// isDestination checks whether an event should be delivered to the output with the specified name
+func (p *Pipeline) isDestination(evt event.Event, outputName string) bool {
+	if len(evt.Destinations) == 0 {
+		return true
+	}
+	for _, destination := range evt.Destinations {
+		if destination == outputName {
+			return true
+		}
+	}
+	return false
+}
+
+// errorChanWait reads from an error channel. If an error is received, it's copied to the plugin error channel and the plugin stopped.
+// The output index is used to record output errors in the stop report, and should be -1 for inputs and filters
+func (p *Pipeline) errorChanWait(errorChan chan error, outputIdx int) {
 	err, ok := <-errorChan
 	if ok {
+		if outputIdx >= 0 {
+			p.recordOutputFailure(outputIdx, err)
+		}
 		p.errorChan <- err
 		_ = p.Stop()
 	}
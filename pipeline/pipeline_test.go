@@ -0,0 +1,398 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/blinklabs-io/adder/event"
+	"github.com/blinklabs-io/adder/filter/router"
+	"github.com/blinklabs-io/adder/pipeline"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockPlugin passes events from its input channel to its output channel
+type mockPlugin struct {
+	inputChan  chan event.Event
+	outputChan chan event.Event
+	errorChan  chan error
+}
+
+func newMockPlugin() *mockPlugin {
+	return &mockPlugin{
+		inputChan:  make(chan event.Event),
+		outputChan: make(chan event.Event),
+		errorChan:  make(chan error),
+	}
+}
+
+func (m *mockPlugin) Start() error {
+	go func() {
+		for evt := range m.inputChan {
+			m.outputChan <- evt
+		}
+	}()
+	return nil
+}
+
+func (m *mockPlugin) Stop() error                    { return nil }
+func (m *mockPlugin) ErrorChan() chan error          { return m.errorChan }
+func (m *mockPlugin) InputChan() chan<- event.Event  { return m.inputChan }
+func (m *mockPlugin) OutputChan() <-chan event.Event { return m.outputChan }
+func (m *mockPlugin) send(evt event.Event)           { m.outputChan <- evt }
+func (m *mockPlugin) receive() event.Event           { return <-m.outputChan }
+
+func TestStats(t *testing.T) {
+	for _, filterCount := range []int{0, 2} {
+		input := newMockPlugin()
+		output := newMockPlugin()
+		pipe := pipeline.New()
+		pipe.AddInput(input)
+		for i := 0; i < filterCount; i++ {
+			pipe.AddFilter(newMockPlugin())
+		}
+		pipe.AddOutput(output)
+
+		stats := pipe.Stats()
+		assert.Equal(t, uint64(0), stats.Input.Events)
+		assert.Nil(t, stats.Input.LastEvent)
+
+		if err := pipe.Start(); err != nil {
+			t.Fatalf("unexpected error starting pipeline: %s", err)
+		}
+		// Feed events directly into the output channel of the mock input
+		for i := 0; i < 3; i++ {
+			input.send(event.New("test", time.Now(), nil, nil))
+			output.receive()
+		}
+		// Allow the output loop to record the last delivery
+		assert.Eventually(
+			t,
+			func() bool { return pipe.Stats().Output.Events == 3 },
+			time.Second,
+			10*time.Millisecond,
+		)
+
+		stats = pipe.Stats()
+		assert.Equal(t, uint64(3), stats.Input.Events, "filters: %d", filterCount)
+		assert.Equal(t, uint64(3), stats.Filter.Events, "filters: %d", filterCount)
+		assert.NotNil(t, stats.Input.LastEvent)
+		assert.NotNil(t, stats.Filter.LastEvent)
+		assert.NotNil(t, stats.Output.LastEvent)
+	}
+}
+
+func TestRouting(t *testing.T) {
+	input := newMockPlugin()
+	outputA := newMockPlugin()
+	outputB := newMockPlugin()
+	pipe := pipeline.New()
+	pipe.AddInput(input)
+	pipe.AddFilter(
+		router.New(
+			router.WithRoute("chainsync.governance", "a"),
+			router.WithRoute("chainsync.transaction", "b"),
+		),
+	)
+	pipe.AddNamedOutput("a", outputA)
+	pipe.AddNamedOutput("b", outputB)
+	if err := pipe.Start(); err != nil {
+		t.Fatalf("unexpected error starting pipeline: %s", err)
+	}
+
+	receivedA := make(chan string, 10)
+	receivedB := make(chan string, 10)
+	go func() {
+		for {
+			select {
+			case evt := <-outputA.outputChan:
+				receivedA <- evt.Type
+			case evt := <-outputB.outputChan:
+				receivedB <- evt.Type
+			}
+		}
+	}()
+	for _, eventType := range []string{"chainsync.governance", "chainsync.transaction", "chainsync.block"} {
+		input.send(event.New(eventType, time.Now(), nil, nil))
+	}
+	assert.Eventually(
+		t,
+		func() bool { return pipe.Stats().Output.Events == 3 },
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	assert.Equal(t, []string{"chainsync.governance", "chainsync.block"}, drain(t, receivedA, 2))
+	assert.Equal(t, []string{"chainsync.transaction", "chainsync.block"}, drain(t, receivedB, 2))
+}
+
+func drain(t *testing.T, ch chan string, count int) []string {
+	ret := []string{}
+	for i := 0; i < count; i++ {
+		select {
+		case item := <-ch:
+			ret = append(ret, item)
+		case <-time.After(time.Second):
+			t.Fatalf("timeout waiting for event %d", i)
+		}
+	}
+	select {
+	case item := <-ch:
+		t.Fatalf("unexpected extra event: %s", item)
+	case <-time.After(50 * time.Millisecond):
+	}
+	return ret
+}
+
+// mockTipInput is a mock input that reports when it has reached the chain tip
+type mockTipInput struct {
+	*mockPlugin
+	tipReachedChan chan struct{}
+}
+
+func (m *mockTipInput) TipReachedChan() <-chan struct{} { return m.tipReachedChan }
+
+func TestWaitForTip(t *testing.T) {
+	input := &mockTipInput{
+		mockPlugin:     newMockPlugin(),
+		tipReachedChan: make(chan struct{}),
+	}
+	pipe := pipeline.New()
+	pipe.AddInput(input)
+
+	// Context cancellation is respected before the tip is reached
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, pipe.WaitForTip(ctx), context.DeadlineExceeded)
+
+	doneChan := make(chan error)
+	go func() {
+		doneChan <- pipe.WaitForTip(context.Background())
+	}()
+	close(input.tipReachedChan)
+	select {
+	case err := <-doneChan:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for WaitForTip to return")
+	}
+}
+
+func TestWaitForTipNoNotifier(t *testing.T) {
+	pipe := pipeline.New()
+	pipe.AddInput(newMockPlugin())
+	assert.Error(t, pipe.WaitForTip(context.Background()))
+}
+
+// mockSlotInput is a mock input that reports the last slot processed
+type mockSlotInput struct {
+	*mockPlugin
+	lastSlot uint64
+}
+
+func (m *mockSlotInput) LastSlot() uint64 { return m.lastSlot }
+
+// mockFailingOutput is a mock output that fails to stop
+type mockFailingOutput struct {
+	*mockPlugin
+}
+
+func (m *mockFailingOutput) Stop() error { return errors.New("failed to flush") }
+
+func TestStopReport(t *testing.T) {
+	input := &mockSlotInput{mockPlugin: newMockPlugin(), lastSlot: 12345}
+	output := newMockPlugin()
+	failingOutput := &mockFailingOutput{newMockPlugin()}
+	pipe := pipeline.New()
+	pipe.AddInput(input)
+	pipe.AddNamedOutput("good", output)
+	pipe.AddNamedOutput("bad", failingOutput)
+	if err := pipe.Start(); err != nil {
+		t.Fatalf("unexpected error starting pipeline: %s", err)
+	}
+	for i := 0; i < 4; i++ {
+		input.send(event.New("test", time.Now(), nil, nil))
+		output.receive()
+		failingOutput.receive()
+	}
+	assert.Eventually(
+		t,
+		func() bool { return pipe.Stats().Output.Events == 4 },
+		time.Second,
+		10*time.Millisecond,
+	)
+	assert.ErrorContains(t, pipe.Stop(), "failed to flush")
+	// Stopping again returns the same result without stopping the plugins again
+	assert.ErrorContains(t, pipe.Stop(), "failed to flush")
+
+	report := pipe.StopReport()
+	assert.Equal(t, uint64(4), report.Stats.Input.Events)
+	assert.Equal(t, uint64(4), report.Stats.Output.Events)
+	assert.Equal(t, uint64(12345), report.LastSlot)
+	assert.False(t, report.StartTime.IsZero())
+	assert.False(t, report.StopTime.IsZero())
+	assert.Equal(t, report.StopTime.Sub(report.StartTime), report.Uptime)
+	assert.Equal(
+		t,
+		[]pipeline.OutputFailure{{Output: "bad", Error: "failed to flush"}},
+		report.FailedOutputs,
+	)
+}
+
+// mockDeadLetterOutput is a mock output that reports every event it receives as failed
+type mockDeadLetterOutput struct {
+	*mockPlugin
+	failedChan chan event.Event
+}
+
+func (m *mockDeadLetterOutput) Start() error {
+	go func() {
+		for evt := range m.inputChan {
+			m.failedChan <- event.NewDeadLetterEvent(evt, "failing", errors.New("delivery failed"))
+		}
+	}()
+	return nil
+}
+
+func (m *mockDeadLetterOutput) FailedEvents() <-chan event.Event { return m.failedChan }
+
+func TestDeadLetter(t *testing.T) {
+	input := newMockPlugin()
+	output := &mockDeadLetterOutput{
+		mockPlugin: newMockPlugin(),
+		failedChan: make(chan event.Event),
+	}
+	deadLetter := newMockPlugin()
+	pipe := pipeline.New()
+	pipe.AddInput(input)
+	pipe.AddOutput(output)
+	pipe.SetDeadLetter(deadLetter)
+	if err := pipe.Start(); err != nil {
+		t.Fatalf("unexpected error starting pipeline: %s", err)
+	}
+	defer pipe.Stop()
+
+	evt := event.New("test", time.Now(), nil, "payload")
+	input.send(evt)
+	failedEvt := deadLetter.receive()
+	assert.Equal(t, "dead_letter", failedEvt.Type)
+	assert.Equal(
+		t,
+		event.DeadLetterEvent{Output: "failing", Error: "delivery failed", Event: evt},
+		failedEvt.Payload,
+	)
+}
+
+func TestDropOnFull(t *testing.T) {
+	input := newMockPlugin()
+	// The output is never read from, so it stops accepting events almost immediately
+	output := newMockPlugin()
+	pipe := pipeline.New(pipeline.WithDropOnFull(true))
+	pipe.SetOutputBufferSize(2)
+	pipe.AddInput(input)
+	pipe.AddNamedOutput("slow", output)
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, pipe.RegisterMetrics(registry))
+	if err := pipe.Start(); err != nil {
+		t.Fatalf("unexpected error starting pipeline: %s", err)
+	}
+	// The input isn't held up by the slow output
+	for i := 0; i < 10; i++ {
+		input.send(event.New("test", time.Now(), nil, nil))
+	}
+	assert.Eventually(
+		t,
+		func() bool { return pipe.Stats().Output.Events == 10 },
+		time.Second,
+		10*time.Millisecond,
+	)
+
+	received := 0
+	for done := false; !done; {
+		select {
+		case <-output.outputChan:
+			received++
+		case <-time.After(50 * time.Millisecond):
+			done = true
+		}
+	}
+	dropped := counterValue(t, registry, "adder_pipeline_output_dropped_total")
+	assert.Greater(t, dropped, 0)
+	assert.Equal(t, 10, received+dropped)
+	assert.Equal(
+		t,
+		dropped,
+		counterValue(t, registry, "adder_pipeline_output_backpressure_total"),
+	)
+}
+
+func counterValue(t *testing.T, registry *prometheus.Registry, name string) int {
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("unexpected error gathering metrics: %s", err)
+	}
+	ret := 0
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			ret += int(metric.GetCounter().GetValue())
+		}
+	}
+	return ret
+}
+
+// mockBlockContext is a mock event context with a known block time
+type mockBlockContext struct {
+	blockTime time.Time
+}
+
+func (c mockBlockContext) BlockTime() (time.Time, error) { return c.blockTime, nil }
+
+func TestMaxEventAge(t *testing.T) {
+	input := newMockPlugin()
+	output := newMockPlugin()
+	pipe := pipeline.New(pipeline.WithMaxEventAge(time.Hour))
+	pipe.AddInput(input)
+	pipe.AddNamedOutput("sink", output)
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, pipe.RegisterMetrics(registry))
+	if err := pipe.Start(); err != nil {
+		t.Fatalf("unexpected error starting pipeline: %s", err)
+	}
+	defer pipe.Stop()
+
+	oldEvt := event.New(
+		"old",
+		time.Now(),
+		mockBlockContext{blockTime: time.Now().Add(-2 * time.Hour)},
+		nil,
+	)
+	freshEvt := event.New(
+		"fresh",
+		time.Now(),
+		mockBlockContext{blockTime: time.Now().Add(-time.Minute)},
+		nil,
+	)
+	input.send(oldEvt)
+	input.send(freshEvt)
+	assert.Equal(t, "fresh", output.receive().Type)
+	assert.Equal(t, 1, counterValue(t, registry, "adder_pipeline_output_expired_total"))
+}
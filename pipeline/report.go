@@ -0,0 +1,86 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"fmt"
+	"time"
+)
+
+// SlotReporter is implemented by inputs that can report the slot of the last block they processed
+type SlotReporter interface {
+	// LastSlot returns the slot of the last block processed, or 0 if no blocks have been processed
+	LastSlot() uint64
+}
+
+// StopReport summarizes a pipeline run, for reporting on shutdown
+type StopReport struct {
+	StartTime time.Time     `json:"startTime"`
+	StopTime  time.Time     `json:"stopTime"`
+	Uptime    time.Duration `json:"uptime"`
+	Stats     Stats         `json:"stats"`
+	// LastSlot is the highest slot reported by any input implementing SlotReporter
+	LastSlot      uint64          `json:"lastSlot"`
+	FailedOutputs []OutputFailure `json:"failedOutputs,omitempty"`
+}
+
+// OutputFailure describes an output that reported an error or failed to stop cleanly
+type OutputFailure struct {
+	Output string `json:"output"`
+	Error  string `json:"error"`
+}
+
+// StopReport returns a summary of the pipeline run. If the pipeline hasn't been stopped yet, the stop time and
+// uptime reflect the current time
+func (p *Pipeline) StopReport() StopReport {
+	p.reportMutex.Lock()
+	defer p.reportMutex.Unlock()
+	ret := StopReport{
+		StartTime: p.startTime,
+		StopTime:  p.stopTime,
+		Stats:     p.Stats(),
+	}
+	if ret.StopTime.IsZero() {
+		ret.StopTime = time.Now()
+	}
+	if !ret.StartTime.IsZero() {
+		ret.Uptime = ret.StopTime.Sub(ret.StartTime)
+	}
+	for _, input := range p.inputs {
+		if reporter, ok := input.(SlotReporter); ok {
+			ret.LastSlot = max(ret.LastSlot, reporter.LastSlot())
+		}
+	}
+	ret.FailedOutputs = append(ret.FailedOutputs, p.outputFailures...)
+	return ret
+}
+
+// recordOutputFailure records an error from the output at the specified index for the stop report
+func (p *Pipeline) recordOutputFailure(idx int, err error) {
+	p.reportMutex.Lock()
+	defer p.reportMutex.Unlock()
+	p.outputFailures = append(
+		p.outputFailures,
+		OutputFailure{Output: p.outputName(idx), Error: err.Error()},
+	)
+}
+
+// outputName returns the name of the output at the specified index, or its position if it's unnamed
+func (p *Pipeline) outputName(idx int) string {
+	if name := p.outputNames[idx]; name != "" {
+		return name
+	}
+	return fmt.Sprintf("#%d", idx)
+}
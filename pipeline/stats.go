@@ -0,0 +1,65 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a snapshot of the event throughput for each stage of the pipeline
+type Stats struct {
+	// Input covers events copied from input plugins into the pipeline
+	Input StageStats `json:"input"`
+	// Filter covers events that made it through all filter plugins (or all input events if there are no filters)
+	Filter StageStats `json:"filter"`
+	// Output covers events delivered to the output plugins
+	Output StageStats `json:"output"`
+}
+
+type StageStats struct {
+	Events    uint64     `json:"events"`
+	LastEvent *time.Time `json:"lastEvent,omitempty"`
+}
+
+type stageCounter struct {
+	events    atomic.Uint64
+	lastEvent atomic.Int64
+}
+
+func (s *stageCounter) record() {
+	s.events.Add(1)
+	s.lastEvent.Store(time.Now().UnixNano())
+}
+
+func (s *stageCounter) stats() StageStats {
+	ret := StageStats{
+		Events: s.events.Load(),
+	}
+	if lastEvent := s.lastEvent.Load(); lastEvent > 0 {
+		tmpTime := time.Unix(0, lastEvent)
+		ret.LastEvent = &tmpTime
+	}
+	return ret
+}
+
+// Stats returns a snapshot of the event counts and the time of the last event seen for each pipeline stage
+func (p *Pipeline) Stats() Stats {
+	return Stats{
+		Input:  p.inputStats.stats(),
+		Filter: p.filterStats.stats(),
+		Output: p.outputStats.stats(),
+	}
+}
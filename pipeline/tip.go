@@ -0,0 +1,48 @@
+// Copyright 2024 Blink Labs Software
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+)
+
+// TipNotifier is implemented by inputs that can report when they've caught up with the chain tip
+type TipNotifier interface {
+	// TipReachedChan returns a channel that is closed once the chain tip has been reached
+	TipReachedChan() <-chan struct{}
+}
+
+// WaitForTip blocks until all inputs that report their chain tip status have reached the tip, or the context is
+// done. An error is returned if none of the inputs report their chain tip status
+func (p *Pipeline) WaitForTip(ctx context.Context) error {
+	var tipChans []<-chan struct{}
+	for _, input := range p.inputs {
+		if notifier, ok := input.(TipNotifier); ok {
+			tipChans = append(tipChans, notifier.TipReachedChan())
+		}
+	}
+	if len(tipChans) == 0 {
+		return errors.New("no inputs report chain tip status")
+	}
+	for _, tipChan := range tipChans {
+		select {
+		case <-tipChan:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}